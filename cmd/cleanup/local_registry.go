@@ -2,9 +2,13 @@ package cleanup
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -19,6 +23,9 @@ import (
 
 type localRegistryCmd struct {
 	*flags.GlobalFlags
+
+	Keep      int
+	OlderThan string
 }
 
 func newLocalRegistryCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
@@ -32,6 +39,12 @@ func newLocalRegistryCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cob
 ######### devspace cleanup local-registry #############
 #######################################################
 Deletes the local image registry
+
+If --keep or --older-than is specified, only old images
+are deleted from the registry and the registry garbage
+collection is run afterwards:
+devspace cleanup local-registry --keep 3
+devspace cleanup local-registry --keep 3 --older-than 7d
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -39,6 +52,8 @@ Deletes the local image registry
 			return cmd.RunCleanupLocalRegistry(f, cobraCmd, args)
 		}}
 
+	localRegistryCmd.Flags().IntVar(&cmd.Keep, "keep", 0, "The amount of most recent images to keep per repository. Only old images are deleted instead of the whole registry")
+	localRegistryCmd.Flags().StringVar(&cmd.OlderThan, "older-than", "", "Only delete images that are older than this duration (e.g. 12h or 7d). Only old images are deleted instead of the whole registry")
 	return localRegistryCmd
 }
 
@@ -47,6 +62,11 @@ func (cmd *localRegistryCmd) RunCleanupLocalRegistry(f factory.Factory, cobraCmd
 	ctx := context.Background()
 	log := f.GetLog()
 
+	retention, err := cmd.retentionOptions(cobraCmd)
+	if err != nil {
+		return err
+	}
+
 	// set config root
 	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
 	if err != nil {
@@ -127,6 +147,17 @@ func (cmd *localRegistryCmd) RunCleanupLocalRegistry(f factory.Factory, cobraCmd
 	if !hasStatefulSet && !hasDeployment && !hasService {
 		log.Donef("No local registry found.")
 		return nil
+	} else if retention != nil {
+		devCtx := devspacecontext.NewContext(ctx, configInterface.Variables(), log).
+			WithConfig(configInterface).
+			WithKubeClient(client)
+		deleted, err := localregistry.Cleanup(devCtx, options, *retention)
+		if err != nil {
+			return errors.Wrap(err, "clean up local registry images")
+		}
+
+		log.Donef("Successfully deleted %d image(s) from the local registry", len(deleted))
+		return nil
 	}
 
 	// prompt user since this is a destructive action
@@ -169,3 +200,37 @@ func (cmd *localRegistryCmd) RunCleanupLocalRegistry(f factory.Factory, cobraCmd
 	log.Donef("Successfully cleaned up local registry")
 	return nil
 }
+
+func (cmd *localRegistryCmd) retentionOptions(cobraCmd *cobra.Command) (*localregistry.RetentionOptions, error) {
+	if !cobraCmd.Flags().Changed("keep") && cmd.OlderThan == "" {
+		return nil, nil
+	} else if cmd.Keep < 0 {
+		return nil, errors.Errorf("--keep must be greater or equal than 0")
+	}
+
+	retention := &localregistry.RetentionOptions{Keep: cmd.Keep}
+	if cmd.OlderThan != "" {
+		olderThan, err := parseRetentionDuration(cmd.OlderThan)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse --older-than")
+		}
+
+		retention.OlderThan = olderThan
+	}
+
+	return retention, nil
+}
+
+// parseRetentionDuration parses a duration that additionally supports days, e.g. 7d
+func parseRetentionDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 0 {
+			return 0, errors.Errorf("invalid duration %s", value)
+		}
+
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -5,6 +5,12 @@ import (
 	flag "github.com/spf13/pflag"
 )
 
+const (
+	InactivityActionExit     = "exit"
+	InactivityActionSleep    = "sleep"
+	InactivityActionSleepAll = "sleep-all"
+)
+
 // GlobalFlags is the flags that contains the global flags
 type GlobalFlags struct {
 	Silent                   bool
@@ -14,6 +20,7 @@ type GlobalFlags struct {
 	DisableProfileActivation bool
 	SwitchContext            bool
 	InactivityTimeout        int
+	InactivityAction         string
 	KubeConfig               string
 	OverrideName             string
 	Namespace                string
@@ -21,6 +28,8 @@ type GlobalFlags struct {
 	ConfigPath               string
 	Profiles                 []string
 	Vars                     []string
+	EventsFile               string
+	EventsSocket             string
 
 	Flags *flag.FlagSet
 }
@@ -57,8 +66,11 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 	flags.StringVar(&globalFlags.KubeContext, "kube-context", "", "The kubernetes context to use")
 	flags.StringSliceVar(&globalFlags.Vars, "var", []string{}, "Variables to override during execution (e.g. --var=MYVAR=MYVALUE)")
 	flags.StringVar(&globalFlags.KubeConfig, "kubeconfig", "", "The kubeconfig path to use")
+	flags.StringVar(&globalFlags.EventsFile, "events-file", "", "If specified, DevSpace appends a newline delimited json record for every hook event to this file")
+	flags.StringVar(&globalFlags.EventsSocket, "events-socket", "", "If specified, DevSpace writes a newline delimited json record for every hook event to this unix socket")
 
-	flags.IntVar(&globalFlags.InactivityTimeout, "inactivity-timeout", 0, "Minutes the current user is inactive (no mouse or keyboard interaction) until DevSpace will exit automatically. 0 to disable. Only supported on windows and mac operating systems")
+	flags.IntVar(&globalFlags.InactivityTimeout, "inactivity-timeout", 0, "Minutes the current user is inactive (no mouse or keyboard interaction) until DevSpace will exit automatically. 0 to disable. On linux only input that is read from a terminal of the user and file sync count as activity")
+	flags.StringVar(&globalFlags.InactivityAction, "inactivity-action", InactivityActionExit, "What to do when the inactivity timeout is reached. exit: only exit DevSpace, sleep: also scale the dev pods to zero, sleep-all: also scale all deployments and statefulsets in the namespace to zero. Sleeping is only supported by devspace dev")
 	flags.AddFlag(&flag.Flag{
 		Name:   "config",
 		Usage:  "DEPRECATED: please use the DEVSPACE_CONFIG environment variable instead",
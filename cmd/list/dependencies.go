@@ -0,0 +1,132 @@
+package list
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type dependenciesCmd struct {
+	*flags.GlobalFlags
+
+	Graph  bool
+	Output string
+}
+
+func newDependenciesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &dependenciesCmd{GlobalFlags: globalFlags}
+
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Lists the dependencies of the project",
+		Long: `
+#######################################################
+############ devspace list dependencies ###############
+#######################################################
+Lists the resolved dependencies of the project with
+their source, pipeline, namespace and profiles
+
+With --graph the dependency graph is printed as tree,
+Graphviz DOT or Mermaid flowchart. Cycles, shared
+dependencies and duplicate sources are flagged with
+their resolution.
+
+Examples:
+devspace list dependencies
+devspace list dependencies --graph
+devspace list dependencies --graph -o dot | dot -Tsvg > deps.svg
+devspace list dependencies --graph -o mermaid
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunListDependencies(f)
+		}}
+
+	dependenciesCmd.Flags().BoolVar(&cmd.Graph, "graph", false, "Prints the dependency graph")
+	dependenciesCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the graph. Can be either tree, dot or mermaid")
+	return dependenciesCmd
+}
+
+// RunListDependencies runs the list dependencies command logic
+func (cmd *dependenciesCmd) RunListDependencies(f factory.Factory) error {
+	logger := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+	if cmd.Output != "" && !cmd.Graph {
+		return errors.New("--output can only be used together with --graph")
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		logger.Warnf("Unable to create new kubectl client: %v", err)
+		client = nil
+	}
+
+	parser := loader.NewEagerParser()
+	config, err := configLoader.LoadWithParser(context.Background(), nil, client, parser, configOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := devspacecontext.NewContext(context.Background(), config.Variables(), logger).
+		WithConfig(config).
+		WithKubeClient(client)
+	dependencies, err := dependency.NewManagerWithParser(ctx, configOptions, parser).ResolveAll(ctx, dependency.ResolveOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolve dependencies")
+	}
+
+	view := dependency.NewGraphView(ctx, dependencies)
+	if cmd.Graph {
+		return view.Print(os.Stdout, dependency.GraphFormat(cmd.Output))
+	}
+
+	if len(view.Nodes) == 1 {
+		logger.Info("No dependencies found")
+		return nil
+	}
+
+	headerColumnNames := []string{
+		"Name",
+		"Source",
+		"Pipeline",
+		"Namespace",
+		"Profiles",
+		"Required By",
+	}
+
+	rows := make([][]string, 0, len(view.Nodes)-1)
+	for _, node := range view.Nodes[1:] {
+		rows = append(rows, []string{
+			node.Name,
+			node.Source,
+			node.Pipeline,
+			node.Namespace,
+			strings.Join(node.Profiles, ", "),
+			strings.Join(node.Parents, ", "),
+		})
+	}
+
+	log.PrintTable(logger, headerColumnNames, rows)
+	return nil
+}
@@ -29,6 +29,7 @@ func NewListCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plu
 	listCmd.AddCommand(newPluginsCmd(f))
 	listCmd.AddCommand(newCommandsCmd(f, globalFlags))
 	listCmd.AddCommand(newNamespacesCmd(f, globalFlags))
+	listCmd.AddCommand(newDependenciesCmd(f, globalFlags))
 
 	// Add plugin commands
 	plugin.AddPluginCommands(listCmd, plugins, "list")
@@ -8,6 +8,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
 	"github.com/loft-sh/devspace/pkg/devspace/services/logs"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/loft-sh/devspace/cmd/flags"
@@ -37,6 +38,11 @@ type LogsCmd struct {
 	Follow            bool
 	Wait              bool
 	LastAmountOfLines int
+
+	All   bool
+	Since string
+	Grep  string
+	JSON  bool
 }
 
 // NewLogsCmd creates a new login command
@@ -51,16 +57,23 @@ func NewLogsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comman
 #################### devspace logs ####################
 #######################################################
 Prints the last log of a pod container and attachs 
-to it
+to it. With --all the logs of all pods and containers
+that match the selector are streamed.
 
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs --all -l app=backend -f --since 10m
+devspace logs --all --image-selector nginx --grep error --json
 #######################################################
 	`,
 		Args: cobra.NoArgs,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
+			if !cobraCmd.Flags().Changed("lines") && cmd.Since != "" {
+				cmd.LastAmountOfLines = -1
+			}
+
 			return cmd.RunLogs(f)
 		},
 	}
@@ -73,6 +86,10 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 	logsCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
+	logsCmd.Flags().BoolVar(&cmd.All, "all", false, "Stream the logs of all pods and containers that match the selector")
+	logsCmd.Flags().StringVar(&cmd.Since, "since", "", "Only print logs newer than a relative duration like 5s, 2m or 3h")
+	logsCmd.Flags().StringVar(&cmd.Grep, "grep", "", "Only print log lines that match the given regular expression")
+	logsCmd.Flags().BoolVar(&cmd.JSON, "json", false, "Print each log line as json object")
 
 	return logsCmd
 }
@@ -127,6 +144,10 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 		return err
 	}
 
+	if cmd.All || cmd.Since != "" || cmd.Grep != "" || cmd.JSON {
+		return cmd.runAggregatedLogs(ctx, imageSelector)
+	}
+
 	// Build options
 	options := targetselector.NewOptionsFromFlags(cmd.Container, cmd.LabelSelector, imageSelector, cmd.Namespace, cmd.Pod).
 		WithPick(cmd.Pick).
@@ -145,6 +166,60 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 	return nil
 }
 
+func (cmd *LogsCmd) runAggregatedLogs(ctx devspacecontext.Context, imageSelector []string) error {
+	options := logs.AggregateOptions{
+		Selector: selector.Selector{
+			ImageSelector:   imageSelector,
+			LabelSelector:   cmd.LabelSelector,
+			Pod:             cmd.Pod,
+			ContainerName:   cmd.Container,
+			Namespace:       cmd.Namespace,
+			FilterContainer: selector.FilterTerminatingContainers,
+		},
+		Follow: cmd.Follow,
+		JSON:   cmd.JSON,
+	}
+	if cmd.LastAmountOfLines >= 0 {
+		tail := int64(cmd.LastAmountOfLines)
+		options.Tail = &tail
+	}
+	if cmd.Since != "" {
+		since, err := time.ParseDuration(cmd.Since)
+		if err != nil {
+			return errors.Wrap(err, "parse --since")
+		}
+
+		options.Since = since
+	}
+	if cmd.Grep != "" {
+		grep, err := regexp.Compile(cmd.Grep)
+		if err != nil {
+			return errors.Wrap(err, "parse --grep")
+		}
+
+		options.Grep = grep
+	}
+
+	// without --all we only stream the selected container
+	if !cmd.All {
+		container, err := targetselector.NewTargetSelector(targetselector.NewOptionsFromFlags(cmd.Container, cmd.LabelSelector, imageSelector, cmd.Namespace, cmd.Pod).
+			WithPick(cmd.Pick).
+			WithWait(cmd.Wait).
+			WithContainerFilter(selector.FilterTerminatingContainers)).SelectSingleContainer(ctx.Context(), ctx.KubeClient(), ctx.Log())
+		if err != nil {
+			return err
+		}
+
+		options.Selector = selector.Selector{
+			Pod:           container.Pod.Name,
+			ContainerName: container.Container.Name,
+			Namespace:     container.Pod.Namespace,
+		}
+	}
+
+	return logs.StartAggregatedLogs(ctx, options, os.Stdout)
+}
+
 func getImageSelector(ctx devspacecontext.Context, configLoader loader.ConfigLoader, configOptions *loader.ConfigOptions, imageSelector string) ([]string, error) {
 	var imageSelectors []string
 	if imageSelector != "" {
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"github.com/spf13/cobra"
+)
+
+// NewPostRenderCmd returns the hidden cobra command that helm calls as post renderer
+func NewPostRenderCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    postrender.CommandName + " FILE",
+		Args:   cobra.ExactArgs(1),
+		Short:  "Applies the post render config of a deployment to the manifests from stdin",
+		Hidden: true,
+		// skip the root pre run, as helm reads the manifests from stdout
+		PersistentPreRunE: func(cobraCmd *cobra.Command, args []string) error {
+			return nil
+		},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			manifests, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+
+			out, err := postrender.RunHelmPostRenderer(context.Background(), args[0], string(manifests))
+			if err != nil {
+				return err
+			}
+
+			_, err = os.Stdout.Write([]byte(out))
+			return err
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RollbackCmd holds the required data for the rollback cmd
+type RollbackCmd struct {
+	*flags.GlobalFlags
+
+	To      int
+	History bool
+}
+
+// NewRollbackCmd creates a new rollback command
+func NewRollbackCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RollbackCmd{GlobalFlags: globalFlags}
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback [deployment]",
+		Short: "Rolls back deployments to a previous revision",
+		Long: `
+#######################################################
+################# devspace rollback ###################
+#######################################################
+Rolls back deployments to a previous deploy that is 
+stored in the remote cache. Helm deployments are rolled
+back with helm rollback, kubectl deployments re-apply 
+the stored rendered manifests.
+
+devspace rollback
+devspace rollback my-deployment
+devspace rollback my-deployment --to 3
+devspace rollback my-deployment --history
+#######################################################`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args)
+		},
+	}
+	rollbackCmd.Flags().IntVar(&cmd.To, "to", 0, "The revision to roll back to, defaults to the previous revision")
+	rollbackCmd.Flags().BoolVar(&cmd.History, "history", false, "Prints the stored deploy history instead of rolling back")
+
+	return rollbackCmd
+}
+
+// Run executes the rollback command logic
+func (cmd *RollbackCmd) Run(f factory.Factory, args []string) error {
+	logger := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return errors.Errorf("Unable to create new kubectl client: %v", err)
+	}
+
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return err
+	}
+
+	client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, logger)
+	if err != nil {
+		return err
+	}
+
+	configInterface, err := configLoader.LoadWithCache(context.Background(), localCache, client, configOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := devspacecontext.NewContext(context.Background(), configInterface.Variables(), logger).
+		WithConfig(configInterface).
+		WithKubeClient(client)
+
+	// helm deployments are stored in the remote cache by their release name
+	deployments := []string{}
+	for _, name := range args {
+		if deployConfig, ok := configInterface.Config().Deployments[name]; ok && deployConfig.Helm != nil && deployConfig.Helm.ReleaseName != "" {
+			name = deployConfig.Helm.ReleaseName
+		}
+
+		deployments = append(deployments, name)
+	}
+
+	if cmd.History {
+		return cmd.printHistory(ctx, deployments, logger)
+	}
+
+	return deploy.NewController().Rollback(ctx, deployments, &deploy.RollbackOptions{
+		To: cmd.To,
+	})
+}
+
+func (cmd *RollbackCmd) printHistory(ctx devspacecontext.Context, deployments []string, logger log.Logger) error {
+	values := [][]string{}
+	for _, deploymentCache := range ctx.Config().RemoteCache().ListDeployments() {
+		if len(deployments) > 0 && deployments[0] != deploymentCache.Name {
+			continue
+		}
+
+		for _, record := range deploymentCache.History {
+			images := []string{}
+			for image, tag := range record.ImageTags {
+				images = append(images, image+":"+tag)
+			}
+			sort.Strings(images)
+
+			gitCommit := record.GitCommit
+			if len(gitCommit) > 7 {
+				gitCommit = gitCommit[:7]
+			}
+
+			note := ""
+			if record.RollbackOf > 0 {
+				note = fmt.Sprintf("Rollback to %d", record.RollbackOf)
+			}
+
+			values = append(values, []string{
+				deploymentCache.Name,
+				strconv.Itoa(record.Revision),
+				record.Time.Local().Format("2006-01-02 15:04:05"),
+				record.User,
+				gitCommit,
+				strings.Join(images, ", "),
+				note,
+			})
+		}
+	}
+	if len(values) == 0 {
+		logger.Info("No deploy history found")
+		return nil
+	}
+
+	log.PrintTable(logger, []string{"Deployment", "Revision", "Deployed", "User", "Commit", "Images", "Note"}, values)
+	return nil
+}
@@ -30,7 +30,9 @@ import (
 	"github.com/loft-sh/devspace/cmd/list"
 	"github.com/loft-sh/devspace/cmd/remove"
 	"github.com/loft-sh/devspace/cmd/reset"
+	"github.com/loft-sh/devspace/cmd/secrets"
 	"github.com/loft-sh/devspace/cmd/set"
+	"github.com/loft-sh/devspace/cmd/ssh"
 	"github.com/loft-sh/devspace/cmd/update"
 	"github.com/loft-sh/devspace/cmd/use"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
@@ -76,6 +78,12 @@ func NewRootCmd(f factory.Factory) *cobra.Command {
 				}
 			}
 
+			// start the event stream
+			err := hook.StartEventStream(globalFlags.EventsFile, globalFlags.EventsSocket)
+			if err != nil {
+				return err
+			}
+
 			// parse the .env file
 			envFile := env.GlobalGetEnv("DEVSPACE_ENV_FILE")
 			if envFile != "" {
@@ -102,6 +110,12 @@ func NewRootCmd(f factory.Factory) *cobra.Command {
 
 				// call inactivity timeout
 				if globalFlags.InactivityTimeout > 0 {
+					switch globalFlags.InactivityAction {
+					case flags.InactivityActionExit, flags.InactivityActionSleep, flags.InactivityActionSleepAll:
+					default:
+						return fmt.Errorf("unknown --inactivity-action %s, please use one of: %s, %s, %s", globalFlags.InactivityAction, flags.InactivityActionExit, flags.InactivityActionSleep, flags.InactivityActionSleepAll)
+					}
+
 					m, err := idle.NewIdleMonitor()
 					if err != nil {
 						log.Warnf("Error creating inactivity monitor: %v", err)
@@ -156,11 +170,14 @@ func Execute() {
 		// Check if return code error
 		retCode, ok := errors.Cause(err).(*exit.ReturnCodeError)
 		if ok {
+			_ = hook.CloseEventStream()
 			os.Exit(retCode.ExitCode)
 		}
 
-		// error hooks
+		// error hooks, the event stream is closed before exiting, because deferred
+		// functions don't run on os.Exit
 		pluginErr := hook.ExecuteHooks(nil, map[string]interface{}{"error": err}, "root.errorExecution", "command:error")
+		_ = hook.CloseEventStream()
 		if pluginErr != nil {
 			f.GetLog().Fatalf("%+v", pluginErr)
 		}
@@ -170,7 +187,10 @@ func Execute() {
 		} else {
 			f.GetLog().Fatal(err)
 		}
-	} else if pluginErr != nil {
+	}
+
+	_ = hook.CloseEventStream()
+	if pluginErr != nil {
 		f.GetLog().Fatalf("%+v", pluginErr)
 	}
 }
@@ -270,6 +290,8 @@ Additional run commands:
 	rootCmd.AddCommand(remove.NewRemoveCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(reset.NewResetCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(set.NewSetCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(secrets.NewSecretsCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(ssh.NewSSHCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(use.NewUseCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(update.NewUpdateCmd(f, globalFlags, plugins))
 
@@ -284,12 +306,15 @@ Additional run commands:
 	rootCmd.AddCommand(NewLogsCmd(f, globalFlags))
 	rootCmd.AddCommand(NewOpenCmd(f, globalFlags))
 	rootCmd.AddCommand(NewUICmd(f, globalFlags))
+	rootCmd.AddCommand(NewStatusCmd(f, globalFlags))
+	rootCmd.AddCommand(NewRollbackCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRunCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewAttachCmd(f, globalFlags))
 	rootCmd.AddCommand(NewPrintCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRunPipelineCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewCompletionCmd())
 	rootCmd.AddCommand(NewVersionCmd())
+	rootCmd.AddCommand(NewPostRenderCmd())
 
 	// check overwrite commands
 	rootCmd.AddCommand(NewDevCmd(f, globalFlags, rawConfig))
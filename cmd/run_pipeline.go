@@ -23,8 +23,11 @@ import (
 	pipelinepkg "github.com/loft-sh/devspace/pkg/devspace/pipeline"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sleep"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/exit"
 	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/idle"
 	"github.com/loft-sh/devspace/pkg/util/interrupt"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -41,6 +44,7 @@ type RunPipelineCmd struct {
 
 	Tags                    []string
 	Render                  bool
+	Plan                    bool
 	Pipeline                string
 	SkipPush                bool
 	SkipPushLocalKubernetes bool
@@ -60,6 +64,7 @@ type RunPipelineCmd struct {
 	SkipDeploy  bool
 
 	ShowUI bool
+	JSON   bool
 
 	// used for testing to allow interruption
 	Ctx          context.Context
@@ -77,6 +82,7 @@ func (cmd *RunPipelineCmd) AddPipelineFlags(f factory.Factory, command *cobra.Co
 	command.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", cmd.BuildSequential, "Builds the images one after another instead of in parallel")
 	command.Flags().IntVar(&cmd.MaxConcurrentBuilds, "max-concurrent-builds", cmd.MaxConcurrentBuilds, "The maximum number of image builds built in parallel (0 for infinite)")
 	command.Flags().BoolVar(&cmd.Render, "render", cmd.Render, "If true will render manifests and print them instead of actually deploying them")
+	command.Flags().BoolVar(&cmd.Plan, "plan", cmd.Plan, "If true will print the changes a deploy would make in the cluster instead of deploying and exit with code 2 if there are changes")
 
 	command.Flags().BoolVar(&cmd.ForcePurge, "force-purge", cmd.ForcePurge, "Forces to purge every deployment even though it might be in use by another DevSpace project")
 	command.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", cmd.ForceDeploy, "Forces to deploy every deployment")
@@ -88,6 +94,7 @@ func (cmd *RunPipelineCmd) AddPipelineFlags(f factory.Factory, command *cobra.Co
 	command.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", cmd.SkipPushLocalKubernetes, "Skips image pushing, if a local kubernetes environment is detected")
 
 	command.Flags().BoolVar(&cmd.ShowUI, "show-ui", cmd.ShowUI, "Shows the ui server")
+	command.Flags().BoolVar(&cmd.JSON, "json", cmd.JSON, "Prints the timings of pipeline steps as json instead of a table")
 
 	if pipeline != nil {
 		for _, pipelineFlag := range pipeline.Flags {
@@ -212,7 +219,7 @@ func (cmd *RunPipelineCmd) Run(cobraCmd *cobra.Command, args []string, f factory
 	}
 
 	// Print upgrade message if new version available
-	if !cmd.Render {
+	if !cmd.Render && !cmd.Plan {
 		upgrade.PrintUpgradeMessage(cmd.Log)
 	} else if cmd.RenderWriter == nil {
 		cmd.RenderWriter = os.Stdout
@@ -391,8 +398,10 @@ func (cmd *RunPipelineCmd) BuildOptions(configOptions *loader.ConfigOptions) *Co
 			},
 			DeployOptions: deploy.Options{
 				ForceDeploy:  cmd.ForceDeploy,
-				Render:       cmd.Render,
+				Render:       cmd.Render || cmd.Plan,
 				RenderWriter: cmd.RenderWriter,
+				Plan:         cmd.Plan,
+				PlanResult:   &deploy.PlanResult{},
 				SkipDeploy:   cmd.SkipDeploy,
 			},
 			PurgeOptions: deploy.PurgeOptions{
@@ -403,6 +412,7 @@ func (cmd *RunPipelineCmd) BuildOptions(configOptions *loader.ConfigOptions) *Co
 				Only:       cmd.Dependency,
 				Sequential: cmd.SequentialDependencies,
 			},
+			StepsJSON: cmd.JSON,
 		},
 		ConfigOptions: configOptions,
 		Pipeline:      cmd.Pipeline,
@@ -414,7 +424,7 @@ func runPipeline(ctx devspacecontext.Context, args []string, options *CommandOpt
 	var configPipeline *latest.Pipeline
 	if ctx.Config().Config().Pipelines != nil && ctx.Config().Config().Pipelines[options.Pipeline] != nil {
 		configPipeline = ctx.Config().Config().Pipelines[options.Pipeline]
-		if configPipeline.Run == "" {
+		if configPipeline.Run == "" && len(configPipeline.Steps) == 0 {
 			defaultPipeline, _ := types.GetDefaultPipeline(options.Pipeline)
 			if defaultPipeline != nil {
 				configPipeline.Run = defaultPipeline.Run
@@ -458,6 +468,25 @@ func runPipeline(ctx devspacecontext.Context, args []string, options *CommandOpt
 		}
 	})
 
+	// workloads are only put to sleep and woken up by the dev pipeline, other
+	// commands like deploy or purge should leave them as they are
+	if options.Pipeline == "dev" {
+		// scale down the dev pods if the user is inactive
+		if options.InactivityTimeout > 0 && (options.InactivityAction == flags.InactivityActionSleep || options.InactivityAction == flags.InactivityActionSleepAll) {
+			idle.SetOnIdleFunction(func() error {
+				return sleep.Sleep(ctx, options.InactivityAction == flags.InactivityActionSleepAll)
+			})
+		}
+
+		// wake up workloads that were scaled down because of inactivity
+		if !options.DeployOptions.Render {
+			err = sleep.WakeUp(ctx)
+			if err != nil {
+				ctx.Log().Warnf("Error waking up workloads: %v", err)
+			}
+		}
+	}
+
 	// start ui & open
 	serv, err := dev.UI(ctx, options.UIPort, options.ShowUI, pipe)
 	if err != nil {
@@ -494,6 +523,18 @@ func runPipeline(ctx devspacecontext.Context, args []string, options *CommandOpt
 		return err
 	}
 
+	// exit with a non-zero code if the plan has changes, so it can be used as a gate. Pipelines
+	// can plan with create_deployments --plan, so the result is checked even without --plan.
+	if options.DeployOptions.PlanResult != nil && options.DeployOptions.PlanResult.Planned() {
+		if !options.DeployOptions.PlanResult.HasChanges() {
+			ctx.Log().Done("No changes, the cluster is up to date")
+			return nil
+		}
+
+		ctx.Log().Warnf("Plan: %s", options.DeployOptions.PlanResult.String())
+		return &exit.ReturnCodeError{ExitCode: 2}
+	}
+
 	return nil
 }
 
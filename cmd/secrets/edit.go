@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type editCmd struct {
+	recipientFlags
+}
+
+func newEditCmd(f factory.Factory) *cobra.Command {
+	cmd := &editCmd{}
+
+	editCmd := &cobra.Command{
+		Use:   "edit FILE",
+		Short: "Edits an age encrypted file",
+		Long: `
+#######################################################
+################ devspace secrets edit ################
+#######################################################
+Decrypts an age encrypted file into a temporary file,
+opens it with $EDITOR and encrypts it again after the
+editor was closed.
+
+Since age files do not contain the public keys they
+were encrypted to, all of them have to be specified
+with --recipient or --recipients-file, otherwise
+teammates would lose access to the file. Files
+encrypted with sops need to be edited with sops.
+
+devspace secrets edit secrets.yaml --recipients-file recipients.txt
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args[0])
+		},
+	}
+
+	cmd.recipientFlags.addFlags(editCmd, "The age public key (age1...) to encrypt the file to. Can be specified multiple times. Either this or --recipients-file is required")
+	return editCmd
+}
+
+// Run executes the command logic
+func (cmd *editCmd) Run(f factory.Factory, file string) error {
+	if len(cmd.Recipients) == 0 && cmd.RecipientsFile == "" {
+		return errors.New("age files do not contain the public keys they were encrypted to, please specify all of them with --recipient or --recipients-file")
+	}
+
+	encrypted, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	identities, err := secrets.LoadIdentities(cmd.IdentityFile)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := secrets.DecryptAge(encrypted, identities)
+	if err != nil {
+		return errors.Wrapf(err, "decrypt %s", file)
+	}
+
+	tempDir, err := os.MkdirTemp("", "devspace-secrets-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	tempFile := filepath.Join(tempDir, filepath.Base(file))
+	err = os.WriteFile(tempFile, plaintext, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = openEditor(tempFile)
+	if err != nil {
+		return err
+	}
+
+	edited, err := os.ReadFile(tempFile)
+	if err != nil {
+		return err
+	} else if bytes.Equal(edited, plaintext) {
+		f.GetLog().Infof("%s was not changed", file)
+		return nil
+	}
+
+	recipients, err := secrets.LoadRecipients(cmd.Recipients, cmd.RecipientsFile, identities)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err = secrets.Encrypt(edited, recipients)
+	if err != nil {
+		return errors.Wrapf(err, "encrypt %s", file)
+	}
+
+	err = os.WriteFile(file, encrypted, 0600)
+	if err != nil {
+		return err
+	}
+
+	f.GetLog().Donef("Saved %s", file)
+	return nil
+}
+
+func openEditor(file string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	editorCmd := exec.Command(editor[0], append(editor[1:], file)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	err := editorCmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "run editor %s", editor[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	fakefactory "github.com/loft-sh/devspace/pkg/util/factory/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestEditKeepsRecipients(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a shell")
+	}
+
+	dir := t.TempDir()
+	alice, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+	bob, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+
+	identityFile := filepath.Join(dir, "keys.txt")
+	err = os.WriteFile(identityFile, []byte(alice.String()+"\n"), 0600)
+	assert.NilError(t, err)
+	recipientsFile := filepath.Join(dir, "recipients.txt")
+	err = os.WriteFile(recipientsFile, []byte(alice.Recipient().String()+"\n"+bob.Recipient().String()+"\n"), 0600)
+	assert.NilError(t, err)
+
+	file := filepath.Join(dir, "secrets.yaml")
+	encrypted, err := secrets.Encrypt([]byte("password: old\n"), []age.Recipient{alice.Recipient(), bob.Recipient()})
+	assert.NilError(t, err)
+	err = os.WriteFile(file, encrypted, 0600)
+	assert.NilError(t, err)
+
+	editor := filepath.Join(dir, "editor.sh")
+	err = os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'password: new\\n' > \"$1\"\n"), 0700)
+	assert.NilError(t, err)
+	t.Setenv("EDITOR", editor)
+	f := &fakefactory.Factory{Log: log.Discard}
+
+	// without recipients the file would only be encrypted to the local identities
+	cmd := &editCmd{recipientFlags: recipientFlags{IdentityFile: identityFile}}
+	err = cmd.Run(f, file)
+	assert.ErrorContains(t, err, "--recipient or --recipients-file")
+
+	cmd.RecipientsFile = recipientsFile
+	err = cmd.Run(f, file)
+	assert.NilError(t, err)
+
+	for _, identity := range []*age.X25519Identity{alice, bob} {
+		doc, err := secrets.DecryptFile(file, []age.Identity{identity})
+		assert.NilError(t, err)
+		assert.Equal(t, doc["password"], "new")
+	}
+}
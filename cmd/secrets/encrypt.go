@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"os"
+
+	"filippo.io/age"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type encryptCmd struct {
+	recipientFlags
+
+	Output string
+}
+
+func newEncryptCmd(f factory.Factory) *cobra.Command {
+	cmd := &encryptCmd{}
+
+	encryptCmd := &cobra.Command{
+		Use:   "encrypt FILE",
+		Short: "Encrypts a yaml or json file with age",
+		Long: `
+#######################################################
+############### devspace secrets encrypt ##############
+#######################################################
+Encrypts a yaml or json file with age, so it can be
+committed and used as a variable source:
+
+vars:
+  DB_PASSWORD:
+    source: secret
+    file: secrets.yaml
+    path: database.password
+
+devspace secrets encrypt secrets.yaml
+devspace secrets encrypt secrets.yaml --recipient age1...
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args[0])
+		},
+	}
+
+	cmd.recipientFlags.addFlags(encryptCmd, "The age public key (age1...) to encrypt the file to. Can be specified multiple times. Defaults to the public keys of the local identities")
+	encryptCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The file to write the encrypted file to. Defaults to encrypting the file in place")
+	return encryptCmd
+}
+
+// Run executes the command logic
+func (cmd *encryptCmd) Run(f factory.Factory, file string) error {
+	plaintext, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	} else if secrets.IsEncrypted(plaintext) {
+		return errors.Errorf("%s is already encrypted", file)
+	}
+
+	recipients, err := cmd.loadRecipients()
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := secrets.Encrypt(plaintext, recipients)
+	if err != nil {
+		return errors.Wrapf(err, "encrypt %s", file)
+	}
+
+	output := cmd.Output
+	if output == "" {
+		output = file
+	}
+
+	err = os.WriteFile(output, encrypted, 0600)
+	if err != nil {
+		return err
+	}
+
+	f.GetLog().Donef("Encrypted %s to %s", file, output)
+	return nil
+}
+
+func (r *recipientFlags) loadRecipients() ([]age.Recipient, error) {
+	var identities []age.Identity
+	if len(r.Recipients) == 0 && r.RecipientsFile == "" {
+		var err error
+		identities, err = secrets.LoadIdentities(r.IdentityFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "no recipient specified and couldn't load local identity")
+		}
+	}
+
+	return secrets.LoadRecipients(r.Recipients, r.RecipientsFile, identities)
+}
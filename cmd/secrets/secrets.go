@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewSecretsCmd creates a new cobra command
+func NewSecretsCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	secretsCmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Encrypts and edits files for secret variables",
+		Long: `
+#######################################################
+################## devspace secrets ###################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	secretsCmd.AddCommand(newEncryptCmd(f))
+	secretsCmd.AddCommand(newEditCmd(f))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(secretsCmd, plugins, "secrets")
+	return secretsCmd
+}
+
+// recipientFlags are the flags that select the age recipients a file is encrypted to
+type recipientFlags struct {
+	Recipients     []string
+	RecipientsFile string
+	IdentityFile   string
+}
+
+func (r *recipientFlags) addFlags(cmd *cobra.Command, recipientUsage string) {
+	cmd.Flags().StringSliceVar(&r.Recipients, "recipient", []string{}, recipientUsage)
+	cmd.Flags().StringVar(&r.RecipientsFile, "recipients-file", "", "A file with one age public key per line to encrypt the file to")
+	cmd.Flags().StringVar(&r.IdentityFile, "identity-file", "", "The age identity file to use. Defaults to $SOPS_AGE_KEY, $SOPS_AGE_KEY_FILE or the sops default location")
+}
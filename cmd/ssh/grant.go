@@ -0,0 +1,87 @@
+package ssh
+
+import (
+	"os"
+	"os/user"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/ssh"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type grantCmd struct {
+	*flags.GlobalFlags
+
+	Key string
+}
+
+func newGrantCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &grantCmd{GlobalFlags: globalFlags}
+
+	grantCmd := &cobra.Command{
+		Use:   "grant USER",
+		Short: "Grants another user ssh access to the dev containers",
+		Long: `
+#######################################################
+################# devspace ssh grant ##################
+#######################################################
+Grants another user ssh access to all dev containers 
+that have ssh enabled. The public key is stored in the
+remote cache and is added to running ssh servers 
+immediately. Sessions are logged with the user name.
+
+devspace ssh grant alice --key ~/alice.pub
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args[0])
+		},
+	}
+
+	grantCmd.Flags().StringVar(&cmd.Key, "key", "", "The path to the public key of the user")
+	_ = grantCmd.MarkFlagRequired("key")
+	return grantCmd
+}
+
+// Run executes the command logic
+func (cmd *grantCmd) Run(f factory.Factory, userName string) error {
+	publicKey, err := os.ReadFile(cmd.Key)
+	if err != nil {
+		return errors.Wrap(err, "read public key")
+	}
+
+	grantedBy := ""
+	currentUser, err := user.Current()
+	if err == nil {
+		grantedBy = currentUser.Username
+	}
+
+	sshKey, err := ssh.NewSSHKey(userName, publicKey, grantedBy)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := loadContext(f, cmd.GlobalFlags)
+	if err != nil {
+		return err
+	}
+
+	ctx.Config().RemoteCache().SetSSHKey(sshKey)
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return errors.Wrap(err, "save remote cache")
+	}
+
+	err = ssh.UpdateGrantedKeysInDevContainers(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log().Donef("Granted ssh access to user %s (%s)", userName, sshKey.Fingerprint)
+	return nil
+}
@@ -0,0 +1,69 @@
+package ssh
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/ssh"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type revokeCmd struct {
+	*flags.GlobalFlags
+}
+
+func newRevokeCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &revokeCmd{GlobalFlags: globalFlags}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke USER",
+		Short: "Revokes the ssh access of another user",
+		Long: `
+#######################################################
+################# devspace ssh revoke #################
+#######################################################
+Revokes the ssh access of a user that was granted with
+devspace ssh grant. The key is removed from the remote
+cache and from running ssh servers, which close the
+open connections of the user within a few seconds.
+
+devspace ssh revoke alice
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args[0])
+		},
+	}
+
+	return revokeCmd
+}
+
+// Run executes the command logic
+func (cmd *revokeCmd) Run(f factory.Factory, userName string) error {
+	ctx, err := loadContext(f, cmd.GlobalFlags)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Config().RemoteCache().DeleteSSHKey(userName) {
+		return fmt.Errorf("user %s has no ssh access", userName)
+	}
+
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return errors.Wrap(err, "save remote cache")
+	}
+
+	err = ssh.UpdateGrantedKeysInDevContainers(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log().Donef("Revoked ssh access of user %s", userName)
+	return nil
+}
@@ -0,0 +1,73 @@
+package ssh
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewSSHCmd creates a new cobra command
+func NewSSHCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	sshCmd := &cobra.Command{
+		Use:   "ssh",
+		Short: "Manages ssh access to the dev containers",
+		Long: `
+#######################################################
+#################### devspace ssh #####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	sshCmd.AddCommand(newGrantCmd(f, globalFlags))
+	sshCmd.AddCommand(newRevokeCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(sshCmd, plugins, "ssh")
+	return sshCmd
+}
+
+func loadContext(f factory.Factory, globalFlags *flags.GlobalFlags) (devspacecontext.Context, error) {
+	logger := f.GetLog()
+	configLoader, err := f.NewConfigLoader(globalFlags.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return nil, err
+	} else if !configExists {
+		return nil, errors.New(message.ConfigNotFound)
+	}
+
+	client, err := f.NewKubeClientFromContext(globalFlags.KubeContext, globalFlags.Namespace)
+	if err != nil {
+		return nil, errors.Errorf("Unable to create new kubectl client: %v", err)
+	}
+
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err = kubectl.CheckKubeContext(client, localCache, globalFlags.NoWarn, globalFlags.SwitchContext, false, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	configInterface, err := configLoader.LoadWithCache(context.Background(), localCache, client, globalFlags.ToConfigOptions(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return devspacecontext.NewContext(context.Background(), configInterface.Variables(), logger).
+		WithConfig(configInterface).
+		WithKubeClient(client), nil
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/server"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// StatusCmd holds the status cmd flags
+type StatusCmd struct {
+	*flags.GlobalFlags
+
+	Host   string
+	Port   int
+	Output string
+}
+
+// StatusSession is the state of a single running DevSpace session
+type StatusSession struct {
+	URL string `json:"url"`
+
+	*server.Status
+}
+
+// NewStatusCmd creates a new status command
+func NewStatusCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &StatusCmd{GlobalFlags: globalFlags}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the state of running dev sessions",
+		Long: `
+#######################################################
+################### devspace status ###################
+#######################################################
+Shows the dev pods, sync, port forwarding, ssh and
+pipelines of all DevSpace sessions that are currently
+running on this machine.
+
+devspace status
+devspace status --port 8090
+devspace status -o json
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	statusCmd.Flags().StringVar(&cmd.Host, "host", "localhost", "The host of the DevSpace UI server to query")
+	statusCmd.Flags().IntVar(&cmd.Port, "port", 0, "The port of the DevSpace UI server to query. If empty, all default ports are searched")
+	statusCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty or json")
+	return statusCmd
+}
+
+// Run executes the command logic
+func (cmd *StatusCmd) Run(f factory.Factory) error {
+	if cmd.Output != "" && cmd.Output != "json" {
+		return errors.Errorf("unsupported output format %s, must be either empty or json", cmd.Output)
+	}
+
+	ports := []int{cmd.Port}
+	if cmd.Port == 0 {
+		ports = []int{}
+		for i := 0; i < 20; i++ {
+			ports = append(ports, server.DefaultPort+i)
+		}
+	}
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	sessions := []*StatusSession{}
+	for _, port := range ports {
+		domain := fmt.Sprintf("http://%s:%d", cmd.Host, port)
+		sessionStatus, err := getSessionStatus(client, domain)
+		if err != nil {
+			if cmd.Port != 0 {
+				return errors.Wrapf(err, "retrieve status from %s", domain)
+			}
+
+			continue
+		} else if sessionStatus.Name == "" && len(sessionStatus.DevPods) == 0 {
+			// this is a server started by devspace ui
+			continue
+		}
+
+		sessions = append(sessions, &StatusSession{
+			URL:    domain,
+			Status: sessionStatus,
+		})
+	}
+
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+		return nil
+	}
+
+	logger := f.GetLog()
+	if len(sessions) == 0 {
+		logger.Info("No running DevSpace sessions found")
+		return nil
+	}
+
+	for _, session := range sessions {
+		printSession(logger, session)
+	}
+
+	return nil
+}
+
+func getSessionStatus(client *http.Client, domain string) (*server.Status, error) {
+	response, err := client.Get(domain + "/api/status")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", response.StatusCode, string(contents))
+	}
+
+	sessionStatus := &server.Status{}
+	err = json.Unmarshal(contents, sessionStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionStatus, nil
+}
+
+func printSession(logger log.Logger, session *StatusSession) {
+	logger.Infof("Session %s (%s)", session.Name, session.URL)
+
+	if len(session.Pipelines) > 0 {
+		pipelines := [][]string{}
+		for _, p := range session.Pipelines {
+			pipelines = append(pipelines, []string{p.Name, p.Dependency})
+		}
+
+		log.PrintTable(logger, []string{"Pipeline", "Dependency"}, pipelines)
+	}
+
+	if len(session.DevPods) == 0 {
+		logger.Info("No dev pods are running")
+		return
+	}
+
+	devPods := [][]string{}
+	syncs := [][]string{}
+	forwards := [][]string{}
+	sshs := [][]string{}
+	for _, devPod := range session.DevPods {
+		name := devPodName(devPod)
+		devPods = append(devPods, []string{
+			name,
+			devPod.Namespace,
+			devPod.Pod,
+			devPod.Container,
+			duration.HumanDuration(time.Since(devPod.Started)),
+		})
+
+		for _, s := range devPod.Sync {
+			lastSync := "-"
+			if s.LastSync != nil {
+				lastSync = duration.HumanDuration(time.Since(*s.LastSync)) + " ago"
+			}
+
+			syncs = append(syncs, []string{
+				name,
+				s.Path,
+				s.Container,
+				s.Status,
+				lastSync,
+				strconv.Itoa(s.PendingChanges),
+				s.Error,
+			})
+		}
+
+		forwards = append(forwards, portForwardRows(name, "forward", devPod.PortForwards)...)
+		forwards = append(forwards, portForwardRows(name, "reverse", devPod.ReversePortForwards)...)
+		for _, s := range devPod.SSH {
+			sshs = append(sshs, []string{
+				name,
+				s.Host,
+				strconv.Itoa(s.Port),
+				s.Container,
+				healthString(s.Healthy),
+				s.Error,
+			})
+		}
+	}
+
+	log.PrintTable(logger, []string{"Dev Pod", "Namespace", "Pod", "Container", "Age"}, devPods)
+	if len(syncs) > 0 {
+		log.PrintTable(logger, []string{"Dev Pod", "Sync Path", "Container", "Status", "Last Sync", "Pending", "Error"}, syncs)
+	}
+	if len(forwards) > 0 {
+		log.PrintTable(logger, []string{"Dev Pod", "Type", "Ports", "Container", "Health", "Error"}, forwards)
+	}
+	if len(sshs) > 0 {
+		log.PrintTable(logger, []string{"Dev Pod", "SSH Host", "Port", "Container", "Health", "Error"}, sshs)
+	}
+}
+
+// devPodName prefixes the name of dev pods of dependencies with the dependency name
+func devPodName(devPod *status.DevPod) string {
+	if devPod.Dependency == "" {
+		return devPod.Name
+	}
+
+	return devPod.Dependency + "/" + devPod.Name
+}
+
+func portForwardRows(devPod, forwardType string, portForwards []*status.PortForward) [][]string {
+	rows := [][]string{}
+	for _, p := range portForwards {
+		rows = append(rows, []string{
+			devPod,
+			forwardType,
+			strings.Join(p.Ports, ", "),
+			p.Container,
+			healthString(p.Healthy),
+			p.Error,
+		})
+	}
+
+	return rows
+}
+
+func healthString(healthy bool) string {
+	if healthy {
+		return "healthy"
+	}
+
+	return "unhealthy"
+}
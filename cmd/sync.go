@@ -45,7 +45,8 @@ type SyncCmd struct {
 	Exclude []string
 	Path    string
 
-	InitialSync string
+	InitialSync      string
+	ConflictStrategy string
 
 	NoWatch               bool
 	DownloadOnInitialSync bool
@@ -94,6 +95,7 @@ devspace sync --path=.:/app --pod=my-pod --container=my-container
 
 	syncCmd.Flags().BoolVar(&cmd.DownloadOnInitialSync, "download-on-initial-sync", true, "DEPRECATED: Downloads all locally non existing remote files in the beginning")
 	syncCmd.Flags().StringVar(&cmd.InitialSync, "initial-sync", "", "The initial sync strategy to use (mirrorLocal, mirrorRemote, preferLocal, preferRemote, preferNewest, keepAll)")
+	syncCmd.Flags().StringVar(&cmd.ConflictStrategy, "conflict-strategy", "", "The strategy to resolve files that were changed on both sides (newest, local, remote, keepBoth)")
 
 	syncCmd.Flags().BoolVar(&cmd.NoWatch, "no-watch", false, "Synchronizes local and remote and then stops")
 
@@ -343,6 +345,14 @@ func (cmd *SyncCmd) applyFlagsToSyncConfig(syncConfig *latest.SyncConfig, option
 		syncConfig.InitialSync = latest.InitialSyncStrategy(cmd.InitialSync)
 	}
 
+	if cmd.ConflictStrategy != "" {
+		if !versions.ValidSyncConflictStrategy(latest.SyncConflictStrategy(cmd.ConflictStrategy)) {
+			return options, errors.Errorf("--conflict-strategy is not valid '%s'", cmd.ConflictStrategy)
+		}
+
+		syncConfig.ConflictStrategy = latest.SyncConflictStrategy(cmd.ConflictStrategy)
+	}
+
 	if cmd.Polling {
 		syncConfig.Polling = cmd.Polling
 	}
@@ -0,0 +1,143 @@
+package update
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// dependenciesCmd holds the cmd flags
+type dependenciesCmd struct {
+	*flags.GlobalFlags
+
+	Lock bool
+}
+
+// newDependenciesCmd creates a new command
+func newDependenciesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &dependenciesCmd{GlobalFlags: globalFlags}
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Updates the git repositories of the dependencies",
+		Long: `
+#######################################################
+########### devspace update dependencies ##############
+#######################################################
+Updates the git repositories and remote files of the
+dependencies, imports and helm charts defined in the
+devspace.yaml
+
+With --lock the commits and hashes in the devspace.lock
+are updated to the latest versions. Without --lock the
+sources are checked out at the locked versions.
+
+Examples:
+devspace update dependencies
+devspace update dependencies --lock
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		}}
+
+	dependenciesCmd.Flags().BoolVar(&cmd.Lock, "lock", false, "Updates the locked commits and hashes in the devspace.lock")
+	return dependenciesCmd
+}
+
+// Run executes the command logic
+func (cmd *dependenciesCmd) Run(f factory.Factory) error {
+	log := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// the locks are passed to every download through the context
+	locks := dependencyutil.NewLocks(cmd.Lock)
+	lockCtx := dependencyutil.WithLocks(context.Background(), locks)
+
+	// load the config, which downloads the imports
+	configOptions := cmd.ToConfigOptions()
+	parser := loader.NewEagerParser()
+	config, err := configLoader.LoadWithParser(lockCtx, nil, nil, parser, configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	ctx := devspacecontext.NewContext(lockCtx, config.Variables(), log).WithConfig(config)
+	dependencies, err := dependency.NewManagerWithParser(ctx, configOptions, parser).ResolveAll(ctx, dependency.ResolveOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolve dependencies")
+	}
+
+	// download the helm charts from git
+	err = downloadCharts(ctx)
+	if err != nil {
+		return err
+	}
+	err = downloadDependencyCharts(ctx, dependencies)
+	if err != nil {
+		return err
+	}
+
+	err = locks.Save(ctx.WorkingDir())
+	if err != nil {
+		return err
+	}
+
+	if cmd.Lock {
+		log.Donef("Successfully updated the dependencies and the lock file")
+	} else {
+		log.Donef("Successfully updated the dependencies")
+	}
+	return nil
+}
+
+func downloadDependencyCharts(ctx devspacecontext.Context, dependencies []types.Dependency) error {
+	for _, dependency := range dependencies {
+		err := downloadCharts(ctx.AsDependency(dependency))
+		if err != nil {
+			return err
+		}
+
+		err = downloadDependencyCharts(ctx, dependency.Children())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func downloadCharts(ctx devspacecontext.Context) error {
+	for _, deployment := range ctx.Config().Config().Deployments {
+		if deployment.Helm == nil || deployment.Helm.Chart == nil || deployment.Helm.Chart.Source == nil || deployment.Helm.Chart.Source.Git == "" {
+			continue
+		}
+
+		_, err := dependencyutil.DownloadDependency(ctx.Context(), ctx.WorkingDir(), deployment.Helm.Chart.Source, ctx.Log())
+		if err != nil {
+			return errors.Wrapf(err, "download chart of deployment %s", deployment.Name)
+		}
+	}
+
+	return nil
+}
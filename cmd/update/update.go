@@ -20,6 +20,7 @@ func NewUpdateCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []p
 		Args: cobra.NoArgs,
 	}
 	updateCmd.AddCommand(newPluginCmd(f))
+	updateCmd.AddCommand(newDependenciesCmd(f, globalFlags))
 
 	// Add plugin commands
 	plugin.AddPluginCommands(updateCmd, plugins, "update")
@@ -412,6 +412,15 @@ var Functions = []Function{
 		Group:       groupOther,
 		IsGlobal:    true,
 	},
+	{
+		Name:        "retry",
+		Description: `Executes the command provided as argument until it succeeds or the maximum number of attempts is reached`,
+		Args:        `[command]`,
+		Handler:     basiccommands.Retry,
+		Flags:       basiccommands.RetryOptions{},
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
 	{
 		Name:        "run_watch",
 		Description: `Executes the command provided as argument and watches for conditions to restart the command`,
@@ -429,6 +438,14 @@ var Functions = []Function{
 		Group:       groupOther,
 		IsGlobal:    true,
 	},
+	{
+		Name:        "timeout",
+		Description: "Executes the command provided as argument and stops it if it doesn't finish within the given duration (e.g. `timeout 5m create_deployments --all`). Exits with status 124 on timeout",
+		Args:        `[duration] [command]`,
+		Handler:     basiccommands.Timeout,
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
 	{
 		Name:        "xargs",
 		Description: "Reads from stdin, splits input by blanks and executes the command provided as argument for each blank-separated input value (often used in pipes, e.g. `echo 'image-1 image-2' | xargs build_images`)",
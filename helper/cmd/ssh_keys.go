@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	helperssh "github.com/loft-sh/devspace/helper/ssh"
+	"github.com/loft-sh/devspace/helper/util/stderrlog"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// SSHKeysCmd holds the ssh keys cmd flags
+type SSHKeysCmd struct {
+	GrantedKeys string
+}
+
+// NewSSHKeysCmd creates a new ssh keys command
+func NewSSHKeysCmd() *cobra.Command {
+	cmd := &SSHKeysCmd{}
+	sshKeysCmd := &cobra.Command{
+		Use:   "ssh-keys",
+		Short: "Replaces the granted keys of the ssh server",
+		Args:  cobra.NoArgs,
+		RunE:  cmd.Run,
+	}
+
+	sshKeysCmd.Flags().StringVar(&cmd.GrantedKeys, "granted-keys", "", "Base64 encoded granted keys, the comment of each key is the user name")
+	return sshKeysCmd
+}
+
+// Run runs the command logic
+func (cmd *SSHKeysCmd) Run(_ *cobra.Command, _ []string) error {
+	keyBytes, err := base64.StdEncoding.DecodeString(cmd.GrantedKeys)
+	if err != nil {
+		return fmt.Errorf("seems like the provided encoded string is not base64 encoded")
+	}
+
+	grantedKeys, err := helperssh.ParseGrantedKeys(keyBytes)
+	if err != nil {
+		return errors.Wrap(err, "parse granted keys")
+	}
+
+	err = helperssh.WriteGrantedKeys(keyBytes)
+	if err != nil {
+		return errors.Wrap(err, "write granted keys")
+	}
+
+	for user := range grantedKeys {
+		stderrlog.Debugf("Granted ssh access to user %s", user)
+	}
+	return nil
+}
@@ -181,6 +181,8 @@ type DownstreamClient interface {
 	Changes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Downstream_ChangesClient, error)
 	ChangesCount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangeAmount, error)
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error)
+	DownloadDelta(ctx context.Context, opts ...grpc.CallOption) (Downstream_DownloadDeltaClient, error)
 }
 
 type downstreamClient struct {
@@ -272,6 +274,46 @@ func (c *downstreamClient) Ping(ctx context.Context, in *Empty, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *downstreamClient) Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, "/remote.Downstream/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downstreamClient) DownloadDelta(ctx context.Context, opts ...grpc.CallOption) (Downstream_DownloadDeltaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Downstream_ServiceDesc.Streams[2], "/remote.Downstream/DownloadDelta", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &downstreamDownloadDeltaClient{stream}
+	return x, nil
+}
+
+type Downstream_DownloadDeltaClient interface {
+	Send(*FileSignature) error
+	Recv() (*DeltaChunk, error)
+	grpc.ClientStream
+}
+
+type downstreamDownloadDeltaClient struct {
+	grpc.ClientStream
+}
+
+func (x *downstreamDownloadDeltaClient) Send(m *FileSignature) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *downstreamDownloadDeltaClient) Recv() (*DeltaChunk, error) {
+	m := new(DeltaChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DownstreamServer is the server API for Downstream service.
 // All implementations must embed UnimplementedDownstreamServer
 // for forward compatibility
@@ -280,6 +322,8 @@ type DownstreamServer interface {
 	Changes(*Empty, Downstream_ChangesServer) error
 	ChangesCount(context.Context, *Empty) (*ChangeAmount, error)
 	Ping(context.Context, *Empty) (*Empty, error)
+	Capabilities(context.Context, *Empty) (*Capabilities, error)
+	DownloadDelta(Downstream_DownloadDeltaServer) error
 	mustEmbedUnimplementedDownstreamServer()
 }
 
@@ -299,6 +343,12 @@ func (UnimplementedDownstreamServer) ChangesCount(context.Context, *Empty) (*Cha
 func (UnimplementedDownstreamServer) Ping(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedDownstreamServer) Capabilities(context.Context, *Empty) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (UnimplementedDownstreamServer) DownloadDelta(Downstream_DownloadDeltaServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadDelta not implemented")
+}
 func (UnimplementedDownstreamServer) mustEmbedUnimplementedDownstreamServer() {}
 
 // UnsafeDownstreamServer may be embedded to opt out of forward compatibility for this service.
@@ -395,6 +445,50 @@ func _Downstream_Ping_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Downstream_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownstreamServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Downstream/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownstreamServer).Capabilities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downstream_DownloadDelta_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DownstreamServer).DownloadDelta(&downstreamDownloadDeltaServer{stream})
+}
+
+type Downstream_DownloadDeltaServer interface {
+	Send(*DeltaChunk) error
+	Recv() (*FileSignature, error)
+	grpc.ServerStream
+}
+
+type downstreamDownloadDeltaServer struct {
+	grpc.ServerStream
+}
+
+func (x *downstreamDownloadDeltaServer) Send(m *DeltaChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *downstreamDownloadDeltaServer) Recv() (*FileSignature, error) {
+	m := new(FileSignature)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Downstream_ServiceDesc is the grpc.ServiceDesc for Downstream service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -410,6 +504,10 @@ var Downstream_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Downstream_Ping_Handler,
 		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _Downstream_Capabilities_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -423,6 +521,12 @@ var Downstream_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Downstream_Changes_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DownloadDelta",
+			Handler:       _Downstream_DownloadDelta_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "remote.proto",
 }
@@ -437,6 +541,9 @@ type UpstreamClient interface {
 	Remove(ctx context.Context, opts ...grpc.CallOption) (Upstream_RemoveClient, error)
 	Execute(ctx context.Context, in *Command, opts ...grpc.CallOption) (*Empty, error)
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error)
+	Signatures(ctx context.Context, in *Paths, opts ...grpc.CallOption) (Upstream_SignaturesClient, error)
+	UploadDelta(ctx context.Context, opts ...grpc.CallOption) (Upstream_UploadDeltaClient, error)
 }
 
 type upstreamClient struct {
@@ -551,6 +658,81 @@ func (c *upstreamClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *upstreamClient) Capabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, "/remote.Upstream/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upstreamClient) Signatures(ctx context.Context, in *Paths, opts ...grpc.CallOption) (Upstream_SignaturesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[2], "/remote.Upstream/Signatures", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upstreamSignaturesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Upstream_SignaturesClient interface {
+	Recv() (*FileSignature, error)
+	grpc.ClientStream
+}
+
+type upstreamSignaturesClient struct {
+	grpc.ClientStream
+}
+
+func (x *upstreamSignaturesClient) Recv() (*FileSignature, error) {
+	m := new(FileSignature)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *upstreamClient) UploadDelta(ctx context.Context, opts ...grpc.CallOption) (Upstream_UploadDeltaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[3], "/remote.Upstream/UploadDelta", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upstreamUploadDeltaClient{stream}
+	return x, nil
+}
+
+type Upstream_UploadDeltaClient interface {
+	Send(*DeltaChunk) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type upstreamUploadDeltaClient struct {
+	grpc.ClientStream
+}
+
+func (x *upstreamUploadDeltaClient) Send(m *DeltaChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *upstreamUploadDeltaClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // UpstreamServer is the server API for Upstream service.
 // All implementations must embed UnimplementedUpstreamServer
 // for forward compatibility
@@ -561,6 +743,9 @@ type UpstreamServer interface {
 	Remove(Upstream_RemoveServer) error
 	Execute(context.Context, *Command) (*Empty, error)
 	Ping(context.Context, *Empty) (*Empty, error)
+	Capabilities(context.Context, *Empty) (*Capabilities, error)
+	Signatures(*Paths, Upstream_SignaturesServer) error
+	UploadDelta(Upstream_UploadDeltaServer) error
 	mustEmbedUnimplementedUpstreamServer()
 }
 
@@ -586,6 +771,15 @@ func (UnimplementedUpstreamServer) Execute(context.Context, *Command) (*Empty, e
 func (UnimplementedUpstreamServer) Ping(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedUpstreamServer) Capabilities(context.Context, *Empty) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (UnimplementedUpstreamServer) Signatures(*Paths, Upstream_SignaturesServer) error {
+	return status.Errorf(codes.Unimplemented, "method Signatures not implemented")
+}
+func (UnimplementedUpstreamServer) UploadDelta(Upstream_UploadDeltaServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadDelta not implemented")
+}
 func (UnimplementedUpstreamServer) mustEmbedUnimplementedUpstreamServer() {}
 
 // UnsafeUpstreamServer may be embedded to opt out of forward compatibility for this service.
@@ -723,6 +917,71 @@ func _Upstream_Ping_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Upstream_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpstreamServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Upstream/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpstreamServer).Capabilities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upstream_Signatures_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Paths)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpstreamServer).Signatures(m, &upstreamSignaturesServer{stream})
+}
+
+type Upstream_SignaturesServer interface {
+	Send(*FileSignature) error
+	grpc.ServerStream
+}
+
+type upstreamSignaturesServer struct {
+	grpc.ServerStream
+}
+
+func (x *upstreamSignaturesServer) Send(m *FileSignature) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upstream_UploadDelta_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UpstreamServer).UploadDelta(&upstreamUploadDeltaServer{stream})
+}
+
+type Upstream_UploadDeltaServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*DeltaChunk, error)
+	grpc.ServerStream
+}
+
+type upstreamUploadDeltaServer struct {
+	grpc.ServerStream
+}
+
+func (x *upstreamUploadDeltaServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *upstreamUploadDeltaServer) Recv() (*DeltaChunk, error) {
+	m := new(DeltaChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Upstream_ServiceDesc is the grpc.ServiceDesc for Upstream service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -746,6 +1005,10 @@ var Upstream_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Upstream_Ping_Handler,
 		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _Upstream_Capabilities_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -758,6 +1021,16 @@ var Upstream_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Upstream_Remove_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Signatures",
+			Handler:       _Upstream_Signatures_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadDelta",
+			Handler:       _Upstream_UploadDelta_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "remote.proto",
 }
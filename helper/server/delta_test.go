@@ -0,0 +1,218 @@
+//go:build !windows
+// +build !windows
+
+package server
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/delta"
+)
+
+func createDeltaTestFiles(t *testing.T) (string, string, []byte) {
+	fromDir := t.TempDir()
+	toDir := t.TempDir()
+
+	base := random(300 * 1024)
+	changed := append([]byte{}, base...)
+	copy(changed[150*1024:], []byte("changed contents"))
+
+	err := os.WriteFile(filepath.Join(fromDir, "big.db"), changed, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(toDir, "big.db"), base, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fromDir, toDir, changed
+}
+
+// runDeltaTestServer starts the server in the background and reports its error
+// when the test finishes
+func runDeltaTestServer(t *testing.T, start func() error) {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- start()
+	}()
+
+	t.Cleanup(func() {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				t.Error(err)
+			}
+		default:
+		}
+	})
+}
+
+func TestUpstreamDelta(t *testing.T) {
+	fromDir, toDir, changed := createDeltaTestFiles(t)
+
+	clientReader, clientWriter := io.Pipe()
+	serverReader, serverWriter := io.Pipe()
+
+	runDeltaTestServer(t, func() error {
+		return StartUpstreamServer(serverReader, clientWriter, &UpstreamOptions{
+			UploadPath:  toDir,
+			ExitOnClose: false,
+		})
+	})
+
+	conn, err := util.NewClientConnection(clientReader, serverWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := remote.NewUpstreamClient(conn)
+	capabilities, err := client.Capabilities(context.Background(), &remote.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	} else if !capabilities.DeltaTransfer {
+		t.Fatal("expected delta transfer to be supported")
+	}
+
+	signatureClient, err := client.Signatures(context.Background(), &remote.Paths{Paths: []string{"/big.db", "/missing.db"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := map[string]*remote.FileSignature{}
+	for {
+		signature, err := signatureClient.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		signatures[signature.Path] = signature
+	}
+	if signatures["/missing.db"] == nil || signatures["/missing.db"].Exists {
+		t.Fatal("expected missing file signature")
+	}
+	if signatures["/big.db"] == nil || !signatures["/big.db"].Exists {
+		t.Fatal("expected existing file signature")
+	}
+
+	f, err := os.Open(filepath.Join(fromDir, "big.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	uploadClient, err := client.UploadDelta(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transferred := 0
+	err = delta.Send(&remote.DeltaChunk{Path: "/big.db", MtimeUnix: 1000, Mode: 0644}, delta.FromRemote(signatures["/big.db"]), f, func(chunk *remote.DeltaChunk) error {
+		for _, op := range chunk.Operations {
+			transferred += len(op.Data)
+		}
+
+		return uploadClient.Send(chunk)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = uploadClient.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transferred >= 100*1024 {
+		t.Fatalf("expected only changed blocks to be transferred, got %d bytes", transferred)
+	}
+
+	data, err := os.ReadFile(filepath.Join(toDir, "big.db"))
+	if err != nil {
+		t.Fatal(err)
+	} else if string(data) != string(changed) {
+		t.Fatal("uploaded file does not match")
+	}
+
+	stat, err := os.Stat(filepath.Join(toDir, "big.db"))
+	if err != nil {
+		t.Fatal(err)
+	} else if stat.ModTime().Unix() != 1000 {
+		t.Fatalf("expected mtime 1000, got %d", stat.ModTime().Unix())
+	}
+}
+
+func TestDownstreamDelta(t *testing.T) {
+	fromDir, toDir, changed := createDeltaTestFiles(t)
+
+	clientReader, clientWriter := io.Pipe()
+	serverReader, serverWriter := io.Pipe()
+
+	runDeltaTestServer(t, func() error {
+		return StartDownstreamServer(serverReader, clientWriter, &DownstreamOptions{
+			RemotePath:  fromDir,
+			ExitOnClose: false,
+			Polling:     true,
+		})
+	})
+
+	conn, err := util.NewClientConnection(clientReader, serverWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := remote.NewDownstreamClient(conn)
+	downloadClient, err := client.DownloadDelta(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := delta.FileSignature(filepath.Join(toDir, "big.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = downloadClient.Send(delta.ToRemote("/big.db", signature))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = downloadClient.Send(&remote.FileSignature{Path: "/missing.db", Exists: true, BlockSize: delta.MinBlockSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = downloadClient.CloseSend()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	written := []string{}
+	err = delta.Receive(downloadClient.Recv, func(header *remote.DeltaChunk) (string, error) {
+		return filepath.Join(toDir, header.Path), nil
+	}, func(header *remote.DeltaChunk) error {
+		written = append(written, header.Path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(written) != 1 || written[0] != "/big.db" {
+		t.Fatalf("unexpected written files %v", written)
+	}
+
+	data, err := os.ReadFile(filepath.Join(toDir, "big.db"))
+	if err != nil {
+		t.Fatal(err)
+	} else if string(data) != string(changed) {
+		t.Fatal("downloaded file does not match")
+	}
+}
@@ -2,16 +2,18 @@ package server
 
 import (
 	"archive/tar"
-	"compress/gzip"
 	"context"
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/loft-sh/devspace/helper/util/compression"
+	"github.com/loft-sh/devspace/helper/util/delta"
 	"github.com/loft-sh/devspace/helper/util/pingtimeout"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
@@ -147,10 +149,15 @@ type Downstream struct {
 
 // Download sends the file at the temp download location to the client
 func (d *Downstream) Download(stream remote.Downstream_DownloadServer) error {
+	codec := ""
 	filesToCompress := make([]string, 0, 128)
 	for {
 		paths, err := stream.Recv()
 		if paths != nil {
+			if paths.Compression != "" {
+				codec = paths.Compression
+			}
+
 			filesToCompress = append(filesToCompress, paths.Paths...)
 		}
 
@@ -168,7 +175,7 @@ func (d *Downstream) Download(stream remote.Downstream_DownloadServer) error {
 	// Compress archive and send at the same time
 	errorChan := make(chan error)
 	go func() {
-		errorChan <- d.compress(writer, filesToCompress)
+		errorChan <- d.compress(writer, codec, filesToCompress)
 	}()
 
 	// Send compressed archive to client
@@ -196,14 +203,17 @@ func (d *Downstream) Download(stream remote.Downstream_DownloadServer) error {
 }
 
 // Compress compresses the given files and folders into a tar archive
-func (d *Downstream) compress(writer io.WriteCloser, files []string) error {
+func (d *Downstream) compress(writer io.WriteCloser, codec string, files []string) error {
 	defer writer.Close()
 
 	// Use compression
-	gw := gzip.NewWriter(writer)
-	defer gw.Close()
+	compressor, err := compression.NewWriter(codec, writer)
+	if err != nil {
+		return err
+	}
+	defer compressor.Close()
 
-	tarWriter := tar.NewWriter(gw)
+	tarWriter := tar.NewWriter(compressor)
 	defer tarWriter.Close()
 
 	writtenFiles := make(map[string]bool)
@@ -219,6 +229,62 @@ func (d *Downstream) compress(writer io.WriteCloser, files []string) error {
 	return nil
 }
 
+// Capabilities returns the features this downstream server supports
+func (d *Downstream) Capabilities(context.Context, *remote.Empty) (*remote.Capabilities, error) {
+	return &remote.Capabilities{
+		DeltaTransfer: true,
+		Compressions:  compression.Supported(),
+	}, nil
+}
+
+// DownloadDelta receives the signatures of files the client already has and sends only
+// the blocks that have changed. Files that do not exist anymore are skipped.
+func (d *Downstream) DownloadDelta(stream remote.Downstream_DownloadDeltaServer) error {
+	signatures := make([]*remote.FileSignature, 0, 16)
+	for {
+		signature, err := stream.Recv()
+		if signature != nil {
+			signatures = append(signatures, signature)
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, signature := range signatures {
+		err := d.sendDelta(signature, stream)
+		if err != nil {
+			return errors.Wrapf(err, "send delta %s", signature.Path)
+		}
+	}
+
+	return nil
+}
+
+func (d *Downstream) sendDelta(signature *remote.FileSignature, stream remote.Downstream_DownloadDeltaServer) error {
+	f, err := os.Open(path.Join(d.options.RemotePath, signature.Path))
+	if err != nil {
+		// File is suddenly not here anymore is ignored
+		return nil
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil || !stat.Mode().IsRegular() {
+		return nil
+	}
+
+	return delta.Send(&remote.DeltaChunk{
+		Path:      signature.Path,
+		MtimeUnix: stat.ModTime().Unix(),
+		Mode:      uint32(stat.Mode()),
+	}, delta.FromRemote(signature), f, stream.Send)
+}
+
 // Ping returns empty
 func (d *Downstream) Ping(context.Context, *remote.Empty) (*remote.Empty, error) {
 	if d.ping != nil {
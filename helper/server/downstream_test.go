@@ -125,7 +125,7 @@ func TestDownstreamServer(t *testing.T) {
 	w.Close()
 	log.Println("Downloaded complete file")
 
-	err = untarAll(r, &UpstreamOptions{UploadPath: toDir})
+	err = untarAll(r, "", &UpstreamOptions{UploadPath: toDir})
 	if err != nil {
 		t.Fatal(err)
 	}
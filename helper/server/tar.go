@@ -2,7 +2,6 @@ package server
 
 import (
 	"archive/tar"
-	"compress/gzip"
 	"io"
 	"os"
 	"os/exec"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 
 	"github.com/pkg/errors"
@@ -22,16 +22,16 @@ type fileInformation struct {
 	Mtime time.Time
 }
 
-func untarAll(reader io.ReadCloser, options *UpstreamOptions) error {
+func untarAll(reader io.ReadCloser, codec string, options *UpstreamOptions) error {
 	defer reader.Close()
 
-	gzr, err := gzip.NewReader(reader)
+	decompressor, err := compression.NewReader(codec, reader)
 	if err != nil {
 		return errors.Errorf("error decompressing: %v", err)
 	}
-	defer gzr.Close()
+	defer decompressor.Close()
 
-	tarReader := tar.NewReader(gzr)
+	tarReader := tar.NewReader(decompressor)
 	for {
 		shouldContinue, err := untarNext(tarReader, options)
 		if err != nil {
@@ -130,11 +130,21 @@ func untarNext(tarReader *tar.Reader, options *UpstreamOptions) (bool, error) {
 		return false, errors.Wrapf(err, "out file close %s", outFileName)
 	}
 
+	err = finishFile(outFileName, stat, header.FileInfo().Mode(), header.FileInfo().ModTime(), options)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// finishFile sets the permissions and mod time of a written file and executes the file change command
+func finishFile(outFileName string, stat os.FileInfo, mode os.FileMode, modTime time.Time, options *UpstreamOptions) error {
 	// Set old permissions and owner and group
 	if stat != nil {
 		if options.OverridePermission {
 			// Set permissions
-			_ = os.Chmod(outFileName, header.FileInfo().Mode())
+			_ = os.Chmod(outFileName, mode)
 		} else {
 			// Set old permissions correctly
 			_ = os.Chmod(outFileName, stat.Mode())
@@ -144,11 +154,11 @@ func untarNext(tarReader *tar.Reader, options *UpstreamOptions) (bool, error) {
 		_ = Chown(outFileName, stat)
 	} else {
 		// Set permissions
-		_ = os.Chmod(outFileName, header.FileInfo().Mode())
+		_ = os.Chmod(outFileName, mode)
 	}
 
-	// Set mod time from tar header
-	_ = os.Chtimes(outFileName, time.Now(), header.FileInfo().ModTime())
+	// Set mod time from header
+	_ = os.Chtimes(outFileName, time.Now(), modTime)
 
 	// Execute command if defined
 	if options.FileChangeCmd != "" {
@@ -163,11 +173,11 @@ func untarNext(tarReader *tar.Reader, options *UpstreamOptions) (bool, error) {
 
 		out, err := exec.Command(options.FileChangeCmd, cmdArgs...).CombinedOutput()
 		if err != nil {
-			return false, errors.Errorf("error executing command '%s %s': %s => %v", options.FileChangeCmd, strings.Join(cmdArgs, " "), string(out), err)
+			return errors.Errorf("error executing command '%s %s': %s => %v", options.FileChangeCmd, strings.Join(cmdArgs, " "), string(out), err)
 		}
 	}
 
-	return true, nil
+	return nil
 }
 
 func recursiveTar(basePath, relativePath string, writtenFiles map[string]bool, tw *tar.Writer, skipFolderContents bool) error {
@@ -5,7 +5,9 @@ import (
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/server/ignoreparser"
 	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/helper/util/crc32"
+	"github.com/loft-sh/devspace/helper/util/delta"
 	"github.com/loft-sh/devspace/helper/util/pingtimeout"
 	"github.com/loft-sh/devspace/helper/util/stderrlog"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
@@ -17,6 +19,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -239,6 +242,17 @@ func (u *Upstream) removeRecursive(absolutePath string) error {
 // Upload implements the server upload interface and writes all the data received to a
 // temporary file
 func (u *Upstream) Upload(stream remote.Upstream_UploadServer) error {
+	// the first chunk tells us how the archive is compressed
+	first, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		return errors.Wrap(err, "recv")
+	}
+
+	codec := ""
+	if first != nil {
+		codec = first.Compression
+	}
+
 	reader, writer := io.Pipe()
 
 	defer reader.Close()
@@ -246,10 +260,10 @@ func (u *Upstream) Upload(stream remote.Upstream_UploadServer) error {
 
 	writerErrChan := make(chan error)
 	go func() {
-		writerErrChan <- u.writeTar(writer, stream)
+		writerErrChan <- u.writeTar(writer, first, stream)
 	}()
 
-	err := untarAll(reader, u.options)
+	err = untarAll(reader, codec, u.options)
 	if err != nil {
 		return errors.Wrap(err, "untar all")
 	}
@@ -262,32 +276,102 @@ func (u *Upstream) Upload(stream remote.Upstream_UploadServer) error {
 	return stream.SendAndClose(&remote.Empty{})
 }
 
-func (u *Upstream) writeTar(writer io.WriteCloser, stream remote.Upstream_UploadServer) error {
+func (u *Upstream) writeTar(writer io.WriteCloser, first *remote.Chunk, stream remote.Upstream_UploadServer) error {
 	defer writer.Close()
 
 	// Receive file
-	for {
-		chunk, err := stream.Recv()
-		if chunk != nil {
-			n, err := writer.Write(chunk.Content)
-			if err != nil {
-				// this means the tar is done already, so we just exit here
-				if strings.Contains(err.Error(), "io: read/write on closed pipe") {
-					return nil
-				}
-
-				return err
-			} else if n != len(chunk.Content) {
-				return errors.Errorf("error writing data: bytes written %d != expected %d", n, len(chunk.Content))
+	chunk := first
+	for chunk != nil {
+		n, err := writer.Write(chunk.Content)
+		if err != nil {
+			// this means the tar is done already, so we just exit here
+			if strings.Contains(err.Error(), "io: read/write on closed pipe") {
+				return nil
 			}
+
+			return err
+		} else if n != len(chunk.Content) {
+			return errors.Errorf("error writing data: bytes written %d != expected %d", n, len(chunk.Content))
 		}
 
+		chunk, err = stream.Recv()
 		if err == io.EOF {
 			return nil
 		} else if err != nil {
 			return err
 		}
 	}
+
+	return nil
+}
+
+// Capabilities returns the features this upstream server supports
+func (u *Upstream) Capabilities(context.Context, *remote.Empty) (*remote.Capabilities, error) {
+	return &remote.Capabilities{
+		DeltaTransfer: true,
+		Compressions:  compression.Supported(),
+	}, nil
+}
+
+// Signatures sends the block checksums and modification times of the given files, which
+// the client uses to detect conflicts and to only upload the changed blocks. Files that are
+// too small to be transferred as delta are not read, only their size and modification time
+// are sent.
+func (u *Upstream) Signatures(paths *remote.Paths, stream remote.Upstream_SignaturesServer) error {
+	for _, relativePath := range paths.Paths {
+		absolutePath := filepath.Join(u.options.UploadPath, relativePath)
+		stat, err := os.Stat(absolutePath)
+		var signature *delta.Signature
+		if err == nil && stat.Mode().IsRegular() && stat.Size() < delta.MinFileSize {
+			signature = &delta.Signature{Size: stat.Size()}
+		} else if err == nil {
+			signature, err = delta.FileSignature(absolutePath)
+		}
+		if err != nil {
+			if !os.IsNotExist(err) {
+				stderrlog.Infof("Error signature %s: %v", relativePath, err)
+			}
+
+			err = stream.Send(&remote.FileSignature{Path: relativePath})
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		remoteSignature := delta.ToRemote(relativePath, signature)
+		remoteSignature.MtimeUnix = stat.ModTime().Unix()
+		err = stream.Send(remoteSignature)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UploadDelta rebuilds existing files from the received delta chunks
+func (u *Upstream) UploadDelta(stream remote.Upstream_UploadDeltaServer) error {
+	var stat os.FileInfo
+	err := delta.Receive(stream.Recv, func(header *remote.DeltaChunk) (string, error) {
+		outFileName := path.Join(u.options.UploadPath, header.Path)
+
+		var err error
+		stat, err = os.Stat(outFileName)
+		if err != nil {
+			return "", err
+		}
+
+		return outFileName, nil
+	}, func(header *remote.DeltaChunk) error {
+		return finishFile(path.Join(u.options.UploadPath, header.Path), stat, os.FileMode(header.Mode), time.Unix(header.MtimeUnix, 0), u.options)
+	})
+	if err != nil {
+		return errors.Wrap(err, "receive delta")
+	}
+
+	return stream.SendAndClose(&remote.Empty{})
 }
 
 func (u *Upstream) Execute(ctx context.Context, cmd *remote.Command) (*remote.Empty, error) {
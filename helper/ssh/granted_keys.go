@@ -0,0 +1,173 @@
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	"github.com/loft-sh/devspace/helper/util/stderrlog"
+	"github.com/pkg/errors"
+)
+
+// GrantedKeysDir is the directory that holds the granted keys file. It has to be
+// owned by the helper user and must not be accessible by anyone else.
+var GrantedKeysDir = filepath.Join(os.TempDir(), fmt.Sprintf("devspace-ssh-%d", os.Getuid()))
+
+// GrantedKeysFile holds the public keys of other users that were granted access
+// to the ssh server. The file is read on every authentication, so keys can be
+// granted and revoked while the server is running.
+var GrantedKeysFile = filepath.Join(GrantedKeysDir, "granted_keys")
+
+// grantCheckInterval is the interval in which open connections of granted users are
+// checked, connections of revoked users are closed
+var grantCheckInterval = 5 * time.Second
+
+// ParseGrantedKeys parses the granted keys in the authorized keys format. The
+// comment of each key is the user the key belongs to.
+func ParseGrantedKeys(data []byte) (map[string][]ssh.PublicKey, error) {
+	keys := map[string][]ssh.PublicKey{}
+	for len(data) > 0 {
+		key, comment, _, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			if len(strings.TrimSpace(string(data))) == 0 {
+				break
+			}
+
+			return nil, err
+		}
+
+		keys[comment] = append(keys[comment], key)
+		data = rest
+	}
+
+	return keys, nil
+}
+
+// WriteGrantedKeys replaces the granted keys file. If data is empty, the file is removed.
+func WriteGrantedKeys(data []byte) error {
+	err := os.Mkdir(GrantedKeysDir, 0700)
+	if err != nil && !os.IsExist(err) {
+		return err
+	}
+
+	err = checkPrivate(GrantedKeysDir, true)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		err = os.Remove(GrantedKeysFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	// write to a temporary file first, so the ssh server never reads a partial file
+	tempFile, err := os.CreateTemp(GrantedKeysDir, ".granted_keys-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	if err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), GrantedKeysFile)
+}
+
+func readGrantedKeys() ([]byte, error) {
+	err := checkPrivate(GrantedKeysDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPrivate(GrantedKeysFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(GrantedKeysFile)
+}
+
+// checkPrivate makes sure that the path is not a symlink, is owned by the current
+// user and cannot be accessed by anyone else
+func checkPrivate(path string, dir bool) error {
+	stat, err := os.Lstat(path)
+	if err != nil {
+		return err
+	} else if dir && !stat.IsDir() {
+		return errors.Errorf("%s is not a directory", path)
+	} else if !dir && !stat.Mode().IsRegular() {
+		return errors.Errorf("%s is not a regular file", path)
+	} else if !isOwnedByCurrentUser(stat) {
+		return errors.Errorf("%s is not owned by the current user", path)
+	} else if stat.Mode().Perm()&0077 != 0 {
+		return errors.Errorf("%s is accessible by other users (mode %v)", path, stat.Mode().Perm())
+	}
+
+	return nil
+}
+
+func findGrantedKey(key ssh.PublicKey) (string, bool) {
+	data, err := readGrantedKeys()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			stderrlog.Errorf("error reading granted keys: %v", err)
+		}
+
+		return "", false
+	}
+
+	grantedKeys, err := ParseGrantedKeys(data)
+	if err != nil {
+		stderrlog.Errorf("error parsing granted keys: %v", err)
+		return "", false
+	}
+
+	for user, userKeys := range grantedKeys {
+		for _, k := range userKeys {
+			if ssh.KeysEqual(k, key) {
+				return user, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// watchGrantedKey closes the connection as soon as the key of the user was revoked,
+// which ends all sessions and port forwardings of the connection
+func watchGrantedKey(done <-chan struct{}, conn net.Conn, key ssh.PublicKey, user string) {
+	ticker := time.NewTicker(grantCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			granted, ok := findGrantedKey(key)
+			if ok && granted == user {
+				continue
+			}
+
+			stderrlog.Infof("Access of user %s was revoked, closing connection from %s", user, conn.RemoteAddr().String())
+			_ = conn.Close()
+			return
+		}
+	}
+}
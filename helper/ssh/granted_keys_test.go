@@ -0,0 +1,155 @@
+//go:build !windows
+// +build !windows
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func setupGrantedKeysTest(t *testing.T) ssh.PublicKey {
+	grantedKeysDir, grantedKeysFile, interval := GrantedKeysDir, GrantedKeysFile, grantCheckInterval
+	GrantedKeysDir = filepath.Join(t.TempDir(), "ssh")
+	GrantedKeysFile = filepath.Join(GrantedKeysDir, "granted_keys")
+	grantCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		GrantedKeysDir, GrantedKeysFile, grantCheckInterval = grantedKeysDir, grantedKeysFile, interval
+	})
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := gossh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteGrantedKeys([]byte(strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))) + " alice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestGrantedKeysPermissions(t *testing.T) {
+	key := setupGrantedKeysTest(t)
+
+	stat, err := os.Stat(GrantedKeysDir)
+	if err != nil {
+		t.Fatal(err)
+	} else if stat.Mode().Perm() != 0700 {
+		t.Fatalf("expected directory mode 0700, got %v", stat.Mode().Perm())
+	}
+
+	user, ok := findGrantedKey(key)
+	if !ok || user != "alice" {
+		t.Fatalf("expected key of alice to be granted, got %s", user)
+	}
+
+	// a granted keys file other users can write to is not trusted
+	err = os.Chmod(GrantedKeysFile, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findGrantedKey(key); ok {
+		t.Fatal("expected world writable granted keys file to be refused")
+	}
+
+	// symlinks are not followed
+	data, err := os.ReadFile(GrantedKeysFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), "keys")
+	err = os.WriteFile(target, data, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Remove(GrantedKeysFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(target, GrantedKeysFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findGrantedKey(key); ok {
+		t.Fatal("expected symlinked granted keys file to be refused")
+	}
+
+	// the directory must not be accessible by other users
+	err = os.Remove(GrantedKeysFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chmod(GrantedKeysDir, 0777)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteGrantedKeys(data)
+	if err == nil {
+		t.Fatal("expected writing into a world writable directory to fail")
+	}
+	err = os.Chmod(GrantedKeysDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteGrantedKeys(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findGrantedKey(key); !ok {
+		t.Fatal("expected key to be granted again")
+	}
+}
+
+func TestRevokeClosesConnection(t *testing.T) {
+	key := setupGrantedKeysTest(t)
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		watchGrantedKey(done, serverConn, key, "alice")
+	}()
+
+	// the connection stays open while the key is granted
+	select {
+	case <-watcherDone:
+		t.Fatal("expected connection to stay open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	err := WriteGrantedKeys(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-watcherDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected connection to be closed after the key was revoked")
+	}
+
+	_, err = clientConn.Read(make([]byte, 1))
+	if err == nil {
+		t.Fatal("expected connection to be closed")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package ssh
+
+import (
+	"os"
+	"syscall"
+)
+
+func isOwnedByCurrentUser(stat os.FileInfo) bool {
+	sysStat, ok := stat.Sys().(*syscall.Stat_t)
+	return ok && int(sysStat.Uid) == os.Getuid()
+}
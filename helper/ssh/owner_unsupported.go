@@ -0,0 +1,10 @@
+//go:build windows
+// +build windows
+
+package ssh
+
+import "os"
+
+func isOwnedByCurrentUser(stat os.FileInfo) bool {
+	return true
+}
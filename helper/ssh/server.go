@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/sftp"
 	"io"
 	"log"
+	"net"
 	"os"
 	"os/exec"
 	"strings"
@@ -16,6 +17,16 @@ import (
 
 var DefaultPort = 8022
 
+// OwnerUser is the user name that is logged for sessions of the authorized keys
+const OwnerUser = "owner"
+
+type contextKey string
+
+const (
+	userContextKey contextKey = "devspace-user"
+	connContextKey contextKey = "devspace-conn"
+)
+
 func NewServer(addr string, hostKey []byte, keys []ssh.PublicKey) (*Server, error) {
 	shell, err := getShell()
 	if err != nil {
@@ -27,17 +38,32 @@ func NewServer(addr string, hostKey []byte, keys []ssh.PublicKey) (*Server, erro
 		shell: shell,
 		sshServer: ssh.Server{
 			Addr: addr,
+			ConnCallback: func(ctx ssh.Context, conn net.Conn) net.Conn {
+				ctx.SetValue(connContextKey, conn)
+				return conn
+			},
 			PublicKeyHandler: func(ctx ssh.Context, key ssh.PublicKey) bool {
 				if len(keys) == 0 {
+					ctx.SetValue(userContextKey, OwnerUser)
 					return true
 				}
 
 				for _, k := range keys {
 					if ssh.KeysEqual(k, key) {
+						ctx.SetValue(userContextKey, OwnerUser)
 						return true
 					}
 				}
 
+				user, ok := findGrantedKey(key)
+				if ok {
+					ctx.SetValue(userContextKey, user)
+					if conn, ok := ctx.Value(connContextKey).(net.Conn); ok {
+						go watchGrantedKey(ctx.Done(), conn, key, user)
+					}
+					return true
+				}
+
 				stderrlog.Debugf("Declined public key")
 				return false
 			},
@@ -94,7 +120,23 @@ func getShell() (string, error) {
 	return "bash", nil
 }
 
+func sessionUser(sess ssh.Session) string {
+	user, _ := sess.Context().Value(userContextKey).(string)
+	if user == "" {
+		return "unknown"
+	}
+
+	return user
+}
+
 func (s *Server) handler(sess ssh.Session) {
+	user := sessionUser(sess)
+	start := time.Now()
+	stderrlog.Infof("Started ssh session of user %s from %s (command: %q)", user, sess.RemoteAddr().String(), sess.RawCommand())
+	defer func() {
+		stderrlog.Infof("Ended ssh session of user %s from %s after %s", user, sess.RemoteAddr().String(), time.Since(start).Round(time.Second))
+	}()
+
 	cmd := s.getCommand(sess)
 	if ssh.AgentRequested(sess) {
 		l, err := ssh.NewAgentListener()
@@ -270,6 +312,7 @@ func exitWithError(s ssh.Session, err error) {
 }
 
 func SftpHandler(sess ssh.Session) {
+	stderrlog.Infof("Started sftp session of user %s from %s", sessionUser(sess), sess.RemoteAddr().String())
 	debugStream := io.Discard
 	serverOptions := []sftp.ServerOption{
 		sftp.WithDebug(debugStream),
@@ -205,6 +205,12 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		return errors.New("missing port")
 	}
 
+	if request.GetScheme() == remote.TunnelScheme_UDP {
+		return initUDPTunnel(stream, request)
+	} else if request.GetForward() {
+		return errors.New("forwarding is only supported for udp")
+	}
+
 	ln, err := net.Listen(strings.ToLower(request.GetScheme().String()), fmt.Sprintf(":%d", port))
 	if err != nil {
 		_ = stream.Send(&remote.SocketDataResponse{
@@ -245,3 +251,48 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		go readConn(stream.Context(), session, sessions)
 	}
 }
+
+func initUDPTunnel(stream remote.Tunnel_InitTunnelServer, request *remote.SocketDataRequest) error {
+	datagramStream := &serverDatagramStream{stream: stream}
+	if request.GetForward() {
+		stderrlog.Debugf("forwarding udp datagrams to localhost:%d", request.GetPort())
+		return DialUDP(stream.Context(), datagramStream, fmt.Sprintf("localhost:%d", request.GetPort()))
+	}
+
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", request.GetPort()))
+	if err != nil {
+		_ = stream.Send(&remote.SocketDataResponse{
+			HasErr: true,
+			LogMessage: &remote.LogMessage{
+				LogLevel: remote.LogLevel_ERROR,
+				Message:  fmt.Sprintf("failed opening listener type %s on port %d: %v", request.GetScheme(), request.GetPort(), err),
+			},
+		})
+		return fmt.Errorf("failed listening on port %d: %v", request.GetPort(), err)
+	}
+	defer conn.Close()
+
+	stderrlog.Debugf("listening for udp datagrams on ::%d", request.GetPort())
+	return ServeUDP(stream.Context(), datagramStream, conn)
+}
+
+type serverDatagramStream struct {
+	stream remote.Tunnel_InitTunnelServer
+}
+
+func (s *serverDatagramStream) Send(requestID string, data []byte, shouldClose bool) error {
+	return s.stream.Send(&remote.SocketDataResponse{
+		RequestId:   requestID,
+		Data:        data,
+		ShouldClose: shouldClose,
+	})
+}
+
+func (s *serverDatagramStream) Recv() (string, []byte, bool, error) {
+	message, err := s.stream.Recv()
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return message.GetRequestId(), message.GetData(), message.GetShouldClose(), nil
+}
@@ -0,0 +1,298 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxDatagramSize is the maximum size of a single udp datagram
+	MaxDatagramSize = 64 * 1024
+
+	// UDPSessionTimeout is the time after which an idle udp session is closed
+	UDPSessionTimeout = 2 * time.Minute
+)
+
+// DatagramStream is a tunnel stream that transports single datagrams. Each
+// datagram belongs to a session, which represents a single udp peer.
+type DatagramStream interface {
+	Send(requestID string, data []byte, shouldClose bool) error
+	Recv() (requestID string, data []byte, shouldClose bool, err error)
+}
+
+type udpSession struct {
+	addr     net.Addr
+	conn     net.Conn
+	lastSeen time.Time
+}
+
+type udpSessions struct {
+	stream DatagramStream
+
+	m        sync.Mutex
+	sendM    sync.Mutex
+	sessions map[string]*udpSession
+	byAddr   map[string]string
+}
+
+func newUDPSessions(stream DatagramStream) *udpSessions {
+	return &udpSessions{
+		stream:   stream,
+		sessions: map[string]*udpSession{},
+		byAddr:   map[string]string{},
+	}
+}
+
+func (u *udpSessions) send(requestID string, data []byte, shouldClose bool) error {
+	u.sendM.Lock()
+	defer u.sendM.Unlock()
+
+	return u.stream.Send(requestID, data, shouldClose)
+}
+
+func (u *udpSessions) get(requestID string) (*udpSession, bool) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	session, ok := u.sessions[requestID]
+	if ok {
+		session.lastSeen = time.Now()
+	}
+	return session, ok
+}
+
+func (u *udpSessions) add(requestID string, session *udpSession) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	session.lastSeen = time.Now()
+	u.sessions[requestID] = session
+	if session.addr != nil {
+		u.byAddr[session.addr.String()] = requestID
+	}
+}
+
+func (u *udpSessions) idFor(addr net.Addr) string {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	requestID, ok := u.byAddr[addr.String()]
+	if !ok {
+		requestID = uuid.New().String()
+		u.byAddr[addr.String()] = requestID
+		u.sessions[requestID] = &udpSession{addr: addr}
+	}
+
+	u.sessions[requestID].lastSeen = time.Now()
+	return requestID
+}
+
+func (u *udpSessions) remove(requestID string) bool {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	session, ok := u.sessions[requestID]
+	if !ok {
+		return false
+	}
+
+	delete(u.sessions, requestID)
+	if session.addr != nil {
+		delete(u.byAddr, session.addr.String())
+	}
+	if session.conn != nil {
+		_ = session.conn.Close()
+	}
+	return true
+}
+
+func (u *udpSessions) expire(timeout time.Duration) []string {
+	u.m.Lock()
+	expired := []string{}
+	for requestID, session := range u.sessions {
+		if time.Since(session.lastSeen) > timeout {
+			expired = append(expired, requestID)
+		}
+	}
+	u.m.Unlock()
+
+	for _, requestID := range expired {
+		u.remove(requestID)
+	}
+	return expired
+}
+
+func (u *udpSessions) closeAll() {
+	u.m.Lock()
+	requestIDs := make([]string, 0, len(u.sessions))
+	for requestID := range u.sessions {
+		requestIDs = append(requestIDs, requestID)
+	}
+	u.m.Unlock()
+
+	for _, requestID := range requestIDs {
+		u.remove(requestID)
+	}
+}
+
+// ListenUDP listens for udp datagrams on the given address and sends them over the stream.
+// Every peer address gets its own session and datagrams that are received from the stream
+// are written back to the peer of the session.
+func ListenUDP(ctx context.Context, stream DatagramStream, address string) error {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return errors.Wrapf(err, "listen on udp %s", address)
+	}
+	defer conn.Close()
+
+	return ServeUDP(ctx, stream, conn)
+}
+
+// ServeUDP is like ListenUDP, but uses the given packet conn
+func ServeUDP(ctx context.Context, stream DatagramStream, conn net.PacketConn) error {
+	sessions := newUDPSessions(stream)
+	defer sessions.closeAll()
+
+	errChan := make(chan error, 2)
+	go func() {
+		for {
+			requestID, data, shouldClose, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			session, ok := sessions.get(requestID)
+			if !ok {
+				continue
+			} else if shouldClose {
+				sessions.remove(requestID)
+				continue
+			}
+
+			if len(data) > 0 {
+				_, err = conn.WriteTo(data, session.addr)
+				if err != nil {
+					sessions.remove(requestID)
+					_ = sessions.send(requestID, nil, true)
+				}
+			}
+		}
+	}()
+	go func() {
+		buf := make([]byte, MaxDatagramSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				errChan <- errors.Wrap(err, "read udp")
+				return
+			}
+
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			err = sessions.send(sessions.idFor(addr), data, false)
+			if err != nil {
+				errChan <- err
+				return
+			}
+		}
+	}()
+
+	return waitUDP(ctx, sessions, errChan)
+}
+
+// DialUDP receives datagrams from the stream and sends them to the given address. Every
+// session of the stream gets its own udp connection and datagrams that are received on
+// that connection are sent back over the stream.
+func DialUDP(ctx context.Context, stream DatagramStream, address string) error {
+	sessions := newUDPSessions(stream)
+	defer sessions.closeAll()
+
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			requestID, data, shouldClose, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			session, ok := sessions.get(requestID)
+			if shouldClose {
+				sessions.remove(requestID)
+				continue
+			} else if !ok {
+				conn, err := net.Dial("udp", address)
+				if err != nil {
+					_ = sessions.send(requestID, nil, true)
+					continue
+				}
+
+				session = &udpSession{conn: conn}
+				sessions.add(requestID, session)
+				go readUDPConn(sessions, requestID, conn)
+			}
+
+			if len(data) > 0 {
+				_, err = session.conn.Write(data)
+				if err != nil && sessions.remove(requestID) {
+					_ = sessions.send(requestID, nil, true)
+				}
+			}
+		}
+	}()
+
+	return waitUDP(ctx, sessions, errChan)
+}
+
+func readUDPConn(sessions *udpSessions, requestID string, conn net.Conn) {
+	buf := make([]byte, MaxDatagramSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			// if the session still exists, the connection was not closed by us
+			if sessions.remove(requestID) {
+				_ = sessions.send(requestID, nil, true)
+			}
+			return
+		}
+
+		if _, ok := sessions.get(requestID); !ok {
+			return
+		}
+
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		err = sessions.send(requestID, data, false)
+		if err != nil {
+			sessions.remove(requestID)
+			return
+		}
+	}
+}
+
+func waitUDP(ctx context.Context, sessions *udpSessions, errChan <-chan error) error {
+	ticker := time.NewTicker(UDPSessionTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errChan:
+			return err
+		case <-ticker.C:
+			for _, requestID := range sessions.expire(UDPSessionTimeout) {
+				err := sessions.send(requestID, nil, true)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type datagram struct {
+	requestID   string
+	data        []byte
+	shouldClose bool
+}
+
+type pipeStream struct {
+	in  chan datagram
+	out chan datagram
+}
+
+func (p *pipeStream) Send(requestID string, data []byte, shouldClose bool) error {
+	p.out <- datagram{requestID: requestID, data: data, shouldClose: shouldClose}
+	return nil
+}
+
+func (p *pipeStream) Recv() (string, []byte, bool, error) {
+	d, ok := <-p.in
+	if !ok {
+		return "", nil, false, io.EOF
+	}
+
+	return d.requestID, d.data, d.shouldClose, nil
+}
+
+func TestUDPTunnel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// echo server the tunnel dials to
+	echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer echo.Close()
+	go func() {
+		buf := make([]byte, MaxDatagramSize)
+		for {
+			n, addr, err := echo.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			_, _ = echo.WriteTo(append([]byte("echo "), buf[:n]...), addr)
+		}
+	}()
+
+	// listener the tunnel receives datagrams on
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+
+	a, b := make(chan datagram, 10), make(chan datagram, 10)
+	go func() {
+		_ = ServeUDP(ctx, &pipeStream{in: a, out: b}, listener)
+	}()
+	go func() {
+		_ = DialUDP(ctx, &pipeStream{in: b, out: a}, echo.LocalAddr().String())
+	}()
+
+	clients := []net.Conn{}
+	for i := 0; i < 2; i++ {
+		client, err := net.Dial("udp", listener.LocalAddr().String())
+		assert.NilError(t, err)
+		defer client.Close()
+		clients = append(clients, client)
+	}
+
+	// every client gets its own session, datagrams are not merged
+	for i, client := range clients {
+		for _, message := range []string{"hello", "world"} {
+			_, err = client.Write([]byte(message))
+			assert.NilError(t, err)
+
+			buf := make([]byte, MaxDatagramSize)
+			_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+			n, err := client.Read(buf)
+			assert.NilError(t, err, "client %d", i)
+			assert.Equal(t, string(buf[:n]), "echo "+message)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Auto lets the sync choose the best codec both sides support
+	Auto = "auto"
+	// Gzip is the default codec and understood by every helper version
+	Gzip = "gzip"
+	// Zstd compresses similar to gzip but is a lot faster
+	Zstd = "zstd"
+	// LZ4 is the fastest codec with a lower compression ratio
+	LZ4 = "lz4"
+	// None sends the tar stream uncompressed
+	None = "none"
+)
+
+// preferred is the order in which codecs are chosen during auto negotiation.
+// None is never chosen automatically.
+var preferred = []string{Zstd, LZ4, Gzip}
+
+// Supported returns the codecs this binary is able to read and write
+func Supported() []string {
+	return []string{Zstd, LZ4, Gzip, None}
+}
+
+// IsSupported checks if the given codec is known
+func IsSupported(codec string) bool {
+	if codec == "" || codec == Auto {
+		return true
+	}
+
+	for _, c := range Supported() {
+		if c == codec {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Negotiate returns the codec to use for the given configured codec and the codecs
+// the remote side supports. Gzip is always assumed to be supported, because older
+// helpers only understand gzip and do not report their codecs. If no codec is
+// configured gzip is used, the other codecs are only chosen if configured or with auto.
+func Negotiate(codec string, remote []string) string {
+	supported := map[string]bool{Gzip: true}
+	for _, c := range remote {
+		supported[c] = true
+	}
+
+	if codec == "" {
+		return Gzip
+	} else if codec != Auto {
+		if supported[codec] {
+			return codec
+		}
+
+		return Gzip
+	}
+
+	for _, c := range preferred {
+		if supported[c] {
+			return c
+		}
+	}
+
+	return Gzip
+}
+
+// NewWriter returns a writer that compresses into w with the given codec. An
+// empty codec means gzip. Closing the returned writer does not close w.
+func NewWriter(codec string, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case "", Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	case LZ4:
+		return lz4.NewWriter(w), nil
+	case None:
+		return nopWriteCloser{w}, nil
+	}
+
+	return nil, errors.Errorf("unsupported compression %s", codec)
+}
+
+// NewReader returns a reader that decompresses r with the given codec. An
+// empty codec means gzip. Closing the returned reader does not close r.
+func NewReader(codec string, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case "", Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	case LZ4:
+		return io.NopCloser(lz4.NewReader(r)), nil
+	case None:
+		return io.NopCloser(r), nil
+	}
+
+	return nil, errors.Errorf("unsupported compression %s", codec)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	testCases := []struct {
+		codec    string
+		remote   []string
+		expected string
+	}{
+		{codec: "", remote: nil, expected: Gzip},
+		{codec: "", remote: Supported(), expected: Gzip},
+		{codec: Auto, remote: nil, expected: Gzip},
+		{codec: Auto, remote: Supported(), expected: Zstd},
+		{codec: Auto, remote: []string{LZ4, Gzip}, expected: LZ4},
+		{codec: None, remote: Supported(), expected: None},
+		{codec: Zstd, remote: []string{Gzip}, expected: Gzip},
+		{codec: LZ4, remote: Supported(), expected: LZ4},
+	}
+
+	for _, testCase := range testCases {
+		codec := Negotiate(testCase.codec, testCase.remote)
+		if codec != testCase.expected {
+			t.Fatalf("negotiate %q with %v: expected %s, got %s", testCase.codec, testCase.remote, testCase.expected, codec)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("hello devspace sync "), 10000)
+	for _, codec := range append(Supported(), "") {
+		buf := &bytes.Buffer{}
+		writer, err := NewWriter(codec, buf)
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+
+		_, err = writer.Write(data)
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+		err = writer.Close()
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+
+		reader, err := NewReader(codec, buf)
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+
+		out, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		} else if !bytes.Equal(out, data) {
+			t.Fatalf("codec %s: decompressed data does not match", codec)
+		}
+		_ = reader.Close()
+	}
+
+	_, err := NewWriter("brotli", io.Discard)
+	if err == nil {
+		t.Fatal("expected error for unknown codec")
+	}
+}
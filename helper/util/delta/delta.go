@@ -0,0 +1,356 @@
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinBlockSize is the smallest block size used for signatures
+	MinBlockSize = 2 * 1024
+	// MaxBlockSize is the biggest block size used for signatures
+	MaxBlockSize = 128 * 1024
+
+	// MinFileSize is the minimum size of a file to be transferred as delta,
+	// smaller files are always sent completely
+	MinFileSize = 256 * 1024
+
+	// strongSize is the length of the truncated strong checksum of a block
+	strongSize = 16
+
+	// maxLiteralSize is the maximum size of a single literal operation
+	maxLiteralSize = 64 * 1024
+)
+
+// Signature holds the block checksums of a file
+type Signature struct {
+	BlockSize int
+	Size      int64
+	Blocks    []BlockChecksum
+}
+
+// BlockChecksum holds the rolling and strong checksum of a single block
+type BlockChecksum struct {
+	Weak   uint32
+	Strong []byte
+}
+
+// Operation is a single instruction to rebuild a file. If BlockCount is
+// greater than zero, BlockCount blocks starting at BlockIndex are copied
+// from the base file, otherwise Data is written as is.
+type Operation struct {
+	BlockIndex int64
+	BlockCount int64
+	Data       []byte
+}
+
+// BlockSizeFor returns the block size to use for a file of the given size. Like
+// rsync we use roughly the square root of the file size.
+func BlockSizeFor(size int64) int {
+	blockSize := int(math.Sqrt(float64(size)))
+	blockSize = blockSize - blockSize%1024
+	if blockSize < MinBlockSize {
+		return MinBlockSize
+	} else if blockSize > MaxBlockSize {
+		return MaxBlockSize
+	}
+
+	return blockSize
+}
+
+// NewSignature calculates the block checksums of the given reader
+func NewSignature(r io.Reader, blockSize int) (*Signature, error) {
+	if blockSize <= 0 {
+		return nil, errors.Errorf("invalid block size %d", blockSize)
+	}
+
+	signature := &Signature{BlockSize: blockSize}
+	buf := make([]byte, blockSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			signature.Size += int64(n)
+			signature.Blocks = append(signature.Blocks, BlockChecksum{
+				Weak:   weakSum(buf[:n]),
+				Strong: strongSum(buf[:n]),
+			})
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return signature, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read block")
+		}
+	}
+}
+
+// blockLen returns the length of the block at index i
+func (s *Signature) blockLen(i int) int {
+	if i == len(s.Blocks)-1 {
+		return int(s.Size - int64(i)*int64(s.BlockSize))
+	}
+
+	return s.BlockSize
+}
+
+// Diff reads the new file contents from r and calls emit with the operations
+// that are needed to rebuild it from the file the signature was created for.
+func Diff(signature *Signature, r io.Reader, emit func(op Operation) error) error {
+	d := &differ{
+		signature: signature,
+		reader:    bufio.NewReaderSize(r, 64*1024),
+		emit:      emit,
+		window:    make([]byte, signature.BlockSize),
+		index:     make(map[uint32][]int, len(signature.Blocks)),
+	}
+	for i, block := range signature.Blocks {
+		d.index[block.Weak] = append(d.index[block.Weak], i)
+	}
+
+	return d.run()
+}
+
+type differ struct {
+	signature *Signature
+	reader    *bufio.Reader
+	emit      func(op Operation) error
+
+	index map[uint32][]int
+
+	// window is a ring buffer of the bytes currently checksummed
+	window []byte
+	start  int
+	n      int
+	a, b   uint32
+
+	literal []byte
+	pending *Operation
+}
+
+func (d *differ) run() error {
+	eof, err := d.fill()
+	if err != nil {
+		return err
+	}
+
+	blockSize := d.signature.BlockSize
+	for d.n > 0 {
+		if d.n < blockSize {
+			return d.finish()
+		}
+
+		if idx, ok := d.match(); ok {
+			err = d.copyBlock(idx)
+			if err != nil {
+				return err
+			}
+
+			eof, err = d.fill()
+			if err != nil {
+				return err
+			}
+			continue
+		} else if eof {
+			return d.finish()
+		}
+
+		c, err := d.reader.ReadByte()
+		if err == io.EOF {
+			eof = true
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "read byte")
+		}
+
+		// roll the window by one byte
+		out := d.window[d.start]
+		d.literal = append(d.literal, out)
+		d.window[d.start] = c
+		d.start = (d.start + 1) % blockSize
+		d.a = (d.a - uint32(out) + uint32(c)) & 0xffff
+		d.b = (d.b - uint32(blockSize)*uint32(out) + d.a) & 0xffff
+		if len(d.literal) >= maxLiteralSize {
+			err = d.flushLiteral()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return d.flush()
+}
+
+// fill reads a new window from the reader
+func (d *differ) fill() (bool, error) {
+	n, err := io.ReadFull(d.reader, d.window)
+	d.start = 0
+	d.n = n
+	d.a, d.b = weakParts(d.window[:n])
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "read window")
+	}
+
+	return false, nil
+}
+
+// match checks if the current full window matches a block of the signature
+func (d *differ) match() (int, bool) {
+	candidates, ok := d.index[d.a|d.b<<16]
+	if !ok {
+		return 0, false
+	}
+
+	var strong []byte
+	for _, idx := range candidates {
+		if d.signature.blockLen(idx) != d.n {
+			continue
+		}
+		if strong == nil {
+			strong = strongSum(d.window[d.start:], d.window[:d.start])
+		}
+		if bytes.Equal(strong, d.signature.Blocks[idx].Strong) {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+// finish handles the remaining bytes in the window at the end of the file, which
+// could still match the last (shorter) block of the signature
+func (d *differ) finish() error {
+	rest := append(append([]byte{}, d.window[d.start:d.n]...), d.window[:d.start]...)
+
+	last := len(d.signature.Blocks) - 1
+	if last >= 0 {
+		lastLen := d.signature.blockLen(last)
+		if lastLen < d.signature.BlockSize && lastLen <= len(rest) {
+			tail := rest[len(rest)-lastLen:]
+			if weakSum(tail) == d.signature.Blocks[last].Weak && bytes.Equal(strongSum(tail), d.signature.Blocks[last].Strong) {
+				d.literal = append(d.literal, rest[:len(rest)-lastLen]...)
+				err := d.copyBlock(last)
+				if err != nil {
+					return err
+				}
+
+				return d.flush()
+			}
+		}
+	}
+
+	d.literal = append(d.literal, rest...)
+	return d.flush()
+}
+
+func (d *differ) copyBlock(idx int) error {
+	if len(d.literal) > 0 {
+		err := d.flushLiteral()
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.pending != nil && d.pending.BlockIndex+d.pending.BlockCount == int64(idx) {
+		d.pending.BlockCount++
+		return nil
+	}
+
+	err := d.flushPending()
+	if err != nil {
+		return err
+	}
+
+	d.pending = &Operation{BlockIndex: int64(idx), BlockCount: 1}
+	return nil
+}
+
+func (d *differ) flushPending() error {
+	if d.pending == nil {
+		return nil
+	}
+
+	op := *d.pending
+	d.pending = nil
+	return d.emit(op)
+}
+
+func (d *differ) flushLiteral() error {
+	err := d.flushPending()
+	if err != nil {
+		return err
+	}
+
+	for len(d.literal) > 0 {
+		size := len(d.literal)
+		if size > maxLiteralSize {
+			size = maxLiteralSize
+		}
+
+		err = d.emit(Operation{Data: append([]byte{}, d.literal[:size]...)})
+		if err != nil {
+			return err
+		}
+
+		d.literal = d.literal[size:]
+	}
+
+	d.literal = d.literal[:0]
+	return nil
+}
+
+func (d *differ) flush() error {
+	if len(d.literal) > 0 {
+		return d.flushLiteral()
+	}
+
+	return d.flushPending()
+}
+
+// Apply writes the result of the given operation to w, reading copied blocks from base
+func Apply(base io.ReaderAt, baseSize int64, blockSize int, op Operation, w io.Writer) error {
+	if op.BlockCount <= 0 {
+		_, err := w.Write(op.Data)
+		return err
+	}
+
+	offset := op.BlockIndex * int64(blockSize)
+	length := op.BlockCount * int64(blockSize)
+	if offset >= baseSize || op.BlockIndex < 0 {
+		return errors.Errorf("block %d out of range", op.BlockIndex)
+	} else if offset+length > baseSize {
+		length = baseSize - offset
+	}
+
+	_, err := io.Copy(w, io.NewSectionReader(base, offset, length))
+	return err
+}
+
+func weakParts(p []byte) (uint32, uint32) {
+	var a, b uint32
+	for i, c := range p {
+		a += uint32(c)
+		b += uint32(len(p)-i) * uint32(c)
+	}
+
+	return a & 0xffff, b & 0xffff
+}
+
+func weakSum(p []byte) uint32 {
+	a, b := weakParts(p)
+	return a | b<<16
+}
+
+func strongSum(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = h.Write(p)
+	}
+
+	return h.Sum(nil)[:strongSize]
+}
@@ -0,0 +1,176 @@
+package delta
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+)
+
+type testCase struct {
+	name     string
+	base     []byte
+	target   []byte
+	maxBytes int
+}
+
+func randomBytes(r *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	_, _ = r.Read(b)
+	return b
+}
+
+func TestDiffApply(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	base := randomBytes(r, 100*1024+123)
+
+	changed := append([]byte{}, base...)
+	copy(changed[50*1024:], []byte("changed"))
+
+	inserted := append(append(append([]byte{}, base[:30*1024]...), []byte("inserted bytes")...), base[30*1024:]...)
+	appended := append(append([]byte{}, base...), randomBytes(r, 1000)...)
+	truncated := append([]byte{}, base[:60*1024]...)
+	removedHead := append([]byte{}, base[1000:]...)
+
+	testCases := []testCase{
+		{name: "identical", base: base, target: base, maxBytes: 0},
+		{name: "changed block", base: base, target: changed, maxBytes: 2 * MinBlockSize},
+		{name: "inserted bytes", base: base, target: inserted, maxBytes: 2 * MinBlockSize},
+		{name: "appended bytes", base: base, target: appended, maxBytes: 2 * MinBlockSize},
+		{name: "truncated", base: base, target: truncated, maxBytes: 0},
+		{name: "removed head", base: base, target: removedHead, maxBytes: 2 * MinBlockSize},
+		{name: "empty base", base: []byte{}, target: base, maxBytes: len(base)},
+		{name: "empty target", base: base, target: []byte{}, maxBytes: 0},
+		{name: "small files", base: []byte("hello world"), target: []byte("hello world!"), maxBytes: 12},
+		{name: "unrelated", base: base, target: randomBytes(r, 10*1024), maxBytes: 10 * 1024},
+	}
+
+	for _, testCase := range testCases {
+		signature, err := NewSignature(bytes.NewReader(testCase.base), MinBlockSize)
+		if err != nil {
+			t.Fatalf("test case %s: %v", testCase.name, err)
+		}
+
+		literalBytes := 0
+		out := &bytes.Buffer{}
+		err = Diff(signature, bytes.NewReader(testCase.target), func(op Operation) error {
+			literalBytes += len(op.Data)
+			return Apply(bytes.NewReader(testCase.base), int64(len(testCase.base)), signature.BlockSize, op, out)
+		})
+		if err != nil {
+			t.Fatalf("test case %s: %v", testCase.name, err)
+		}
+
+		if !bytes.Equal(out.Bytes(), testCase.target) {
+			t.Fatalf("test case %s: rebuilt file does not match target (%d != %d bytes)", testCase.name, out.Len(), len(testCase.target))
+		}
+		if literalBytes > testCase.maxBytes {
+			t.Fatalf("test case %s: expected at most %d literal bytes, got %d", testCase.name, testCase.maxBytes, literalBytes)
+		}
+	}
+}
+
+func TestBlockSizeFor(t *testing.T) {
+	if BlockSizeFor(0) != MinBlockSize {
+		t.Fatalf("expected min block size for empty files")
+	}
+	if BlockSizeFor(200*1024*1024) != 14*1024 {
+		t.Fatalf("expected 14KB block size for 200MB files, got %d", BlockSizeFor(200*1024*1024))
+	}
+	if BlockSizeFor(1<<40) != MaxBlockSize {
+		t.Fatalf("expected max block size for huge files")
+	}
+}
+
+func TestReceiveKeepsTargetOnFailure(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	base := randomBytes(r, 20*1024)
+	changed := append([]byte{}, base...)
+	copy(changed[10*1024:], []byte("changed"))
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "file")
+	if err := os.WriteFile(target, base, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := NewSignature(bytes.NewReader(base), MinBlockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := []*remote.DeltaChunk{}
+	err = Send(&remote.DeltaChunk{Path: "/file"}, signature, bytes.NewReader(changed), func(chunk *remote.DeltaChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receive := func(chunks []*remote.DeltaChunk) error {
+		return Receive(func() (*remote.DeltaChunk, error) {
+			if len(chunks) == 0 {
+				return nil, io.EOF
+			}
+
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return chunk, nil
+		}, func(header *remote.DeltaChunk) (string, error) {
+			return target, nil
+		}, func(header *remote.DeltaChunk) error {
+			return nil
+		})
+	}
+	assertTarget := func(expected []byte) {
+		data, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(data, expected) {
+			t.Fatal("unexpected target contents")
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(entries) != 1 {
+			t.Fatalf("expected temporary files to be removed, got %d entries", len(entries))
+		}
+	}
+
+	// a broken stream leaves the target untouched
+	last := chunks[len(chunks)-1]
+	withoutChecksum := &remote.DeltaChunk{Path: last.Path, BlockSize: last.BlockSize, Operations: last.Operations}
+	err = receive(append(append([]*remote.DeltaChunk{}, chunks[:len(chunks)-1]...), withoutChecksum))
+	if err == nil || !strings.Contains(err.Error(), "unexpected end") {
+		t.Fatalf("expected unexpected end error, got %v", err)
+	}
+	assertTarget(base)
+
+	// a checksum mismatch leaves the target untouched
+	withWrongChecksum := &remote.DeltaChunk{Path: last.Path, BlockSize: last.BlockSize, Operations: last.Operations, Checksum: []byte("wrong")}
+	err = receive(append(append([]*remote.DeltaChunk{}, chunks[:len(chunks)-1]...), withWrongChecksum))
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	assertTarget(base)
+
+	err = receive(chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTarget(changed)
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	} else if stat.Mode().Perm() != 0640 {
+		t.Fatalf("expected permissions to be kept, got %v", stat.Mode().Perm())
+	}
+}
@@ -0,0 +1,269 @@
+package delta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/pkg/errors"
+)
+
+// maxChunkSize is the approximate maximum payload of a single delta chunk
+const maxChunkSize = 64 * 1024
+
+// ToRemote converts the signature into its grpc representation
+func ToRemote(path string, signature *Signature) *remote.FileSignature {
+	blocks := make([]*remote.BlockChecksum, 0, len(signature.Blocks))
+	for _, block := range signature.Blocks {
+		blocks = append(blocks, &remote.BlockChecksum{
+			Weak:   block.Weak,
+			Strong: block.Strong,
+		})
+	}
+
+	return &remote.FileSignature{
+		Path:      path,
+		Exists:    true,
+		Size:      signature.Size,
+		BlockSize: int32(signature.BlockSize),
+		Blocks:    blocks,
+	}
+}
+
+// FromRemote converts the grpc representation of a signature
+func FromRemote(signature *remote.FileSignature) *Signature {
+	blocks := make([]BlockChecksum, 0, len(signature.Blocks))
+	for _, block := range signature.Blocks {
+		blocks = append(blocks, BlockChecksum{
+			Weak:   block.Weak,
+			Strong: block.Strong,
+		})
+	}
+
+	return &Signature{
+		BlockSize: int(signature.BlockSize),
+		Size:      signature.Size,
+		Blocks:    blocks,
+	}
+}
+
+// FileSignature calculates the signature of the file at the given path
+func FileSignature(path string) (*Signature, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignature(f, BlockSizeFor(stat.Size()))
+}
+
+// Send diffs the contents of r against the signature and sends the resulting operations
+// as delta chunks. The header is sent with the first chunk and should contain the path
+// and file metadata, the last chunk carries the checksum of the complete file.
+func Send(header *remote.DeltaChunk, signature *Signature, r io.Reader, send func(chunk *remote.DeltaChunk) error) error {
+	checksum := sha256.New()
+	header.BlockSize = int32(signature.BlockSize)
+
+	chunk := header
+	chunkSize := 0
+	err := Diff(signature, io.TeeReader(r, checksum), func(op Operation) error {
+		chunk.Operations = append(chunk.Operations, &remote.DeltaOperation{
+			BlockIndex: op.BlockIndex,
+			BlockCount: op.BlockCount,
+			Data:       op.Data,
+		})
+
+		chunkSize += len(op.Data) + 16
+		if chunkSize < maxChunkSize {
+			return nil
+		}
+
+		err := send(chunk)
+		if err != nil {
+			return err
+		}
+
+		chunk = &remote.DeltaChunk{Path: header.Path}
+		chunkSize = 0
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "diff")
+	}
+
+	chunk.Checksum = checksum.Sum(nil)
+	return send(chunk)
+}
+
+// Receive reads delta chunks until the stream ends and rebuilds the files they describe.
+// For every new file open is called with the header chunk and should return the absolute
+// path to write to. After a file was completely written and verified, done is called.
+func Receive(recv func() (*remote.DeltaChunk, error), open func(header *remote.DeltaChunk) (string, error), done func(header *remote.DeltaChunk) error) error {
+	var (
+		patcher *Patcher
+		header  *remote.DeltaChunk
+	)
+	defer func() {
+		if patcher != nil {
+			_ = patcher.Close()
+		}
+	}()
+
+	for {
+		chunk, err := recv()
+		if err == io.EOF {
+			if patcher != nil {
+				return errors.Errorf("unexpected end of delta stream for %s", header.Path)
+			}
+
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if patcher == nil {
+			header = chunk
+			target, err := open(header)
+			if err != nil {
+				return err
+			}
+
+			patcher, err = NewPatcher(target, int(header.BlockSize))
+			if err != nil {
+				return errors.Wrapf(err, "patch %s", header.Path)
+			}
+		} else if chunk.Path != header.Path {
+			return errors.Errorf("unexpected delta chunk for %s while patching %s", chunk.Path, header.Path)
+		}
+
+		err = patcher.Write(chunk.Operations)
+		if err != nil {
+			return errors.Wrapf(err, "patch %s", header.Path)
+		}
+
+		if chunk.Checksum != nil {
+			err = patcher.Commit(chunk.Checksum)
+			_ = patcher.Close()
+			patcher = nil
+			if err != nil {
+				return errors.Wrapf(err, "patch %s", header.Path)
+			}
+
+			err = done(header)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Patcher rebuilds a file from its previous contents. The new contents are written
+// to a temporary file next to the target, which only replaces the target after the
+// checksum was verified, so a failed transfer never leaves a partially written file.
+type Patcher struct {
+	target    string
+	blockSize int
+
+	base     *os.File
+	baseSize int64
+
+	out  *os.File
+	hash hash.Hash
+}
+
+// NewPatcher opens the file at target as base and creates the temporary file the
+// new contents are written to
+func NewPatcher(target string, blockSize int) (*Patcher, error) {
+	if blockSize <= 0 {
+		return nil, errors.Errorf("invalid block size %d", blockSize)
+	}
+
+	base, err := os.Open(target)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := base.Stat()
+	if err != nil {
+		_ = base.Close()
+		return nil, err
+	}
+
+	out, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".devspace-delta-")
+	if err != nil {
+		_ = base.Close()
+		return nil, errors.Wrap(err, "create temp file")
+	}
+
+	// keep the permissions of the original file
+	_ = out.Chmod(stat.Mode().Perm())
+	return &Patcher{
+		target:    target,
+		blockSize: blockSize,
+		base:      base,
+		baseSize:  stat.Size(),
+		out:       out,
+		hash:      sha256.New(),
+	}, nil
+}
+
+// Write applies the given operations
+func (p *Patcher) Write(operations []*remote.DeltaOperation) error {
+	w := io.MultiWriter(p.out, p.hash)
+	for _, op := range operations {
+		err := Apply(p.base, p.baseSize, p.blockSize, Operation{
+			BlockIndex: op.BlockIndex,
+			BlockCount: op.BlockCount,
+			Data:       op.Data,
+		}, w)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Commit checks that the written contents match the given checksum and replaces
+// the target with them
+func (p *Patcher) Commit(checksum []byte) error {
+	if !bytes.Equal(p.hash.Sum(nil), checksum) {
+		return errors.New("checksum mismatch")
+	}
+
+	err := p.out.Close()
+	if err != nil {
+		return err
+	}
+
+	_ = p.base.Close()
+	err = os.Rename(p.out.Name(), p.target)
+	if err != nil {
+		return errors.Wrap(err, "replace file")
+	}
+
+	p.out = nil
+	return nil
+}
+
+// Close closes the base and removes the temporary file if the patcher was not committed
+func (p *Patcher) Close() error {
+	_ = p.base.Close()
+	if p.out != nil {
+		_ = p.out.Close()
+		_ = os.Remove(p.out.Name())
+		p.out = nil
+	}
+
+	return nil
+}
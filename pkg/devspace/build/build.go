@@ -7,8 +7,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
 
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/build/types"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/util/randutil"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
@@ -24,6 +27,7 @@ type imageNameAndTag struct {
 	imageTag        string
 	imageTags       []string
 	imageConfig     latest.Image
+	shareBuild      bool
 }
 
 // Options describe how images should be build
@@ -155,6 +159,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 	}
 
 	imagesToBuild := 0
+	sharedBuilds := 0
 	for key, imageConf := range conf.Images {
 		ctx := ctx.WithLogger(ctx.Log().WithPrefix("build:" + key + " "))
 		if len(images) > 0 && !stringutil.Contains(images, key) {
@@ -169,6 +174,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		resolvedImage := imageCache.ResolveImage()
 		imageTags := tags[imageConfigName]
 		builder := builders[imageConfigName]
+		shareBuild := !options.SkipPush && helper.CanShareImageBuilds(ctx, &cImageConf)
 
 		// Execute before images build hook
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
@@ -239,6 +245,10 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 			imageCache.ImageName = imageName
 			imageCache.Tag = imageTags[0]
 			ctx.Config().LocalCache().SetImageCache(imageConfigName, imageCache)
+			if shareBuild {
+				shareImageBuild(ctx, imageCache)
+				sharedBuilds++
+			}
 
 			// Track built images
 			builtImages[imageConfigName] = types.ImageNameTag{
@@ -260,7 +270,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		} else {
 			// wait until we are below the MaxConcurrency
 			if options.MaxConcurrentBuilds > 0 && imagesToBuild >= options.MaxConcurrentBuilds {
-				err = c.waitForBuild(ctx, errChan, cacheChan, builtImages)
+				err = c.waitForBuild(ctx, errChan, cacheChan, builtImages, &sharedBuilds)
 				if err != nil {
 					return err
 				}
@@ -291,6 +301,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 					imageTag:        imageTags[0],
 					imageTags:       imageTags,
 					imageConfig:     cImageConf,
+					shareBuild:      shareBuild,
 				}
 			}(ctx)
 		}
@@ -299,7 +310,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 	// wait for the builds to finish
 	if !options.Sequential {
 		for imagesToBuild > 0 {
-			err := c.waitForBuild(ctx, errChan, cacheChan, builtImages)
+			err := c.waitForBuild(ctx, errChan, cacheChan, builtImages, &sharedBuilds)
 			if err != nil {
 				return err
 			}
@@ -338,10 +349,31 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 			return err
 		}
 	}
+	if sharedBuilds > 0 {
+		err := ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+		if err != nil {
+			ctx.Log().Warnf("Error saving image builds to remote cache: %v", err)
+		}
+	}
 	return nil
 }
 
-func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan error, cacheChan <-chan imageNameAndTag, builtImages map[string]types.ImageNameTag) error {
+// shareImageBuild remembers the tag of a built image in the remote cache, so that other machines
+// can reuse the image instead of building it again from the same inputs
+func shareImageBuild(ctx devspacecontext.Context, imageCache localcache.ImageCache) {
+	// latest is overwritten by every build, so it cannot be reused
+	if imageCache.BuildDigest == "" || imageCache.Tag == "latest" {
+		return
+	}
+
+	ctx.Config().RemoteCache().SetImageBuild(remotecache.ImageBuildCache{
+		ImageName: imageCache.ImageName,
+		Digest:    imageCache.BuildDigest,
+		Tag:       imageCache.Tag,
+	})
+}
+
+func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan error, cacheChan <-chan imageNameAndTag, builtImages map[string]types.ImageNameTag, sharedBuilds *int) error {
 	select {
 	case err := <-errChan:
 		return err
@@ -360,6 +392,10 @@ func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan er
 		imageCache.ImageName = done.imageName
 		imageCache.Tag = done.imageTag
 		ctx.Config().LocalCache().SetImageCache(done.imageConfigName, imageCache)
+		if done.shareBuild {
+			shareImageBuild(ctx, imageCache)
+			*sharedBuilds++
+		}
 
 		// Track built images
 		builtImages[done.imageConfigName] = types.ImageNameTag{
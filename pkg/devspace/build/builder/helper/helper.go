@@ -1,10 +1,17 @@
 package helper
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/restart"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
@@ -219,7 +226,31 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		}
 	}
 
+	// Check if any other machine has already built an image with the same inputs
+	reused := false
 	if forceRebuild || mustRebuild {
+		imageCache.BuildDigest = ""
+	}
+	if (forceRebuild || mustRebuild) && b.ImageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges && !imageCache.IsLocalRegistryImage() {
+		buildDigest, err := b.BuildDigest(imageConfigHash, entrypointHash)
+		if err != nil {
+			return false, err
+		}
+
+		imageCache.BuildDigest = buildDigest
+		if !forceRebuild && CanShareImageBuilds(ctx, b.ImageConf) {
+			imageBuild, ok := ctx.Config().RemoteCache().GetImageBuild(b.ImageName, buildDigest)
+			if ok && imageBuild.Tag != "" && b.reusableImageTag(ctx, imageBuild.Tag) {
+				ctx.Log().Infof("Reuse image %s:%s, because it was already built from the same inputs", b.ImageName, imageBuild.Tag)
+				imageCache.ImageName = b.ImageName
+				imageCache.Tag = imageBuild.Tag
+				mustRebuild = false
+				reused = true
+			}
+		}
+	}
+
+	if forceRebuild || mustRebuild || reused {
 		imageCache.DockerfileHash = dockerfileHash
 		imageCache.ImageConfigHash = imageConfigHash
 		imageCache.EntrypointHash = entrypointHash
@@ -229,6 +260,77 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 	return mustRebuild, nil
 }
 
+// imageTagExists checks if the tag of the image exists in its registry
+var imageTagExists = func(ctx context.Context, imageName, tag string) (bool, error) {
+	ref, err := name.ParseReference(imageName + ":" + tag)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = remote.Head(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reusableImageTag checks if an image tag that was built on another machine can be reused, which
+// is not the case if it was deleted from the registry in the meantime or the registry can't be reached
+func (b *BuildHelper) reusableImageTag(ctx devspacecontext.Context, tag string) bool {
+	exists, err := imageTagExists(ctx.Context(), b.ImageName, tag)
+	if err != nil {
+		ctx.Log().Debugf("Error checking if image %s:%s exists: %v", b.ImageName, tag, err)
+		return false
+	} else if !exists {
+		ctx.Log().Infof("Rebuild image %s, because %s was built from the same inputs, but doesn't exist in the registry anymore", b.ImageName, tag)
+		return false
+	}
+
+	return true
+}
+
+// BuildDigest calculates a digest of the build context contents, the dockerfile and the image config that is
+// the same on every machine. Builds with the same digest produce the same image.
+func (b *BuildHelper) BuildDigest(imageConfigHash, entrypointHash string) (string, error) {
+	contextDir, relDockerfile, err := build.GetContextFromLocalDir(b.ContextPath, b.DockerfilePath)
+	if err != nil {
+		return "", errors.Wrap(err, "get context from local dir")
+	}
+
+	excludes, err := ReadDockerignore(contextDir, archive.CanonicalTarNameForPath(relDockerfile))
+	if err != nil {
+		return "", errors.Errorf("Error reading .dockerignore: %v", err)
+	}
+
+	contextDigest, err := hash.DirectoryContentExcludes(contextDir, excludes)
+	if err != nil {
+		return "", errors.Errorf("Error hashing %s: %v", contextDir, err)
+	}
+
+	dockerfileDigest, err := hash.File(b.DockerfilePath)
+	if err != nil {
+		return "", errors.Wrap(err, "hash dockerfile")
+	}
+
+	return hash.String(strings.Join([]string{contextDigest, dockerfileDigest, imageConfigHash, entrypointHash}, ";")), nil
+}
+
+// CanShareImageBuilds checks if image builds can be shared with other machines through the remote cache, which
+// is only the case if the image is pushed to a registry that is reachable from the cluster.
+func CanShareImageBuilds(ctx devspacecontext.Context, imageConf *latest.Image) bool {
+	return imageConf.Custom == nil &&
+		!imageConf.SkipPush &&
+		ctx.KubeClient() != nil &&
+		ctx.Config().RemoteCache() != nil &&
+		!kubectl.IsLocalKubernetes(ctx.KubeClient())
+}
+
 func (b *BuildHelper) IsImageAvailableLocally(ctx devspacecontext.Context, dockerClient dockerclient.Client) (bool, error) {
 	// Hack to check if docker is present in the system
 	// if docker is not present then skip the image availability check
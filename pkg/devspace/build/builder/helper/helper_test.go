@@ -2,14 +2,18 @@ package helper
 
 import (
 	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/docker"
@@ -219,6 +223,72 @@ func TestShouldRebuild(t *testing.T) {
 	assert.Equal(t, false, cache.Images["ImageConf"].EntrypointHash == "", "EntrypointHash not set")
 }*/
 
+func TestShouldRebuildReusesImageBuilds(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\nCOPY . /app\n"), 0666))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0666))
+
+	existingTags := map[string]bool{}
+	defer func(original func(ctx context.Context, imageName, tag string) (bool, error)) {
+		imageTagExists = original
+	}(imageTagExists)
+	imageTagExists = func(ctx context.Context, imageName, tag string) (bool, error) {
+		return existingTags[imageName+":"+tag], nil
+	}
+
+	helper := &BuildHelper{
+		ImageConf:      &latest.Image{Name: "app", Image: "registry.example.com/app"},
+		DockerfilePath: filepath.Join(dir, "Dockerfile"),
+		ContextPath:    dir,
+		ImageName:      "registry.example.com/app",
+	}
+	localCache := &localcache.LocalCache{Images: map[string]localcache.ImageCache{}}
+	remoteCache := &remotecache.RemoteCache{}
+	kubeClient := &kubectltesting.Client{Client: fake.NewSimpleClientset(), Context: "remote"}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(config.NewConfig(nil, nil, latest.NewRaw(), localCache, remoteCache, nil, "")).
+		WithKubeClient(kubeClient)
+
+	// nothing was built yet, so the build digest is remembered for the build
+	shouldRebuild, err := helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, shouldRebuild)
+	buildDigest := localCache.Images["app"].BuildDigest
+	assert.Assert(t, buildDigest != "")
+
+	// another machine built the same inputs, but the tag was deleted from the registry
+	remoteCache.SetImageBuild(remotecache.ImageBuildCache{ImageName: "registry.example.com/app", Digest: buildDigest, Tag: "abc"})
+	localCache.Images["app"] = localcache.ImageCache{}
+	shouldRebuild, err = helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, shouldRebuild)
+
+	// the tag exists and is reused
+	existingTags["registry.example.com/app:abc"] = true
+	localCache.Images["app"] = localcache.ImageCache{}
+	shouldRebuild, err = helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, !shouldRebuild)
+	assert.Equal(t, localCache.Images["app"].Tag, "abc")
+	assert.Equal(t, localCache.Images["app"].ImageName, "registry.example.com/app")
+	assert.Equal(t, localCache.Images["app"].BuildDigest, buildDigest)
+
+	// force rebuild never reuses a tag
+	localCache.Images["app"] = localcache.ImageCache{}
+	shouldRebuild, err = helper.ShouldRebuild(ctx, true)
+	assert.NilError(t, err)
+	assert.Assert(t, shouldRebuild)
+	assert.Equal(t, localCache.Images["app"].Tag, "")
+
+	// changed inputs produce another digest
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0666))
+	localCache.Images["app"] = localcache.ImageCache{}
+	shouldRebuild, err = helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, shouldRebuild)
+	assert.Assert(t, localCache.Images["app"].BuildDigest != buildDigest)
+}
+
 func hasBuildKit() bool {
 	cmd := exec.Command("docker", "buildx")
 	err := cmd.Run()
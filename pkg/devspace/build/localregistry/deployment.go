@@ -2,6 +2,7 @@ package localregistry
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -10,9 +11,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
-	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 )
 
 const BuildKitContainer = "buildkitd"
@@ -28,19 +29,16 @@ func (r *LocalRegistry) ensureDeployment(ctx devspacecontext.Context) (*appsv1.D
 	}
 
 	// Create if it does not exist
-	var existing *appsv1.Deployment
 	desired := r.getDeployment()
 	kubeClient := ctx.KubeClient()
 	err = wait.PollImmediateWithContext(ctx.Context(), time.Second, 30*time.Second, func(ctx context.Context) (bool, error) {
-		var err error
-
-		existing, err = kubeClient.KubeClient().AppsV1().Deployments(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+		_, err := kubeClient.KubeClient().AppsV1().Deployments(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
 		if err == nil {
 			return true, nil
 		}
 
 		if kerrors.IsNotFound(err) {
-			existing, err = kubeClient.KubeClient().AppsV1().Deployments(r.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+			_, err = kubeClient.KubeClient().AppsV1().Deployments(r.Namespace).Create(ctx, desired, metav1.CreateOptions{})
 			if err == nil {
 				return true, nil
 			}
@@ -58,17 +56,21 @@ func (r *LocalRegistry) ensureDeployment(ctx devspacecontext.Context) (*appsv1.D
 		return nil, err
 	}
 
-	// Use server side apply if it does exist
-	applyConfiguration, err := appsapplyv1.ExtractDeployment(existing, ApplyFieldManager)
+	// Use server side apply with the desired deployment if it does exist, so that
+	// changes such as new environment variables reach existing registries
+	desired.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"}
+	patch, err := json.Marshal(desired)
 	if err != nil {
 		return nil, err
 	}
-	return ctx.KubeClient().KubeClient().AppsV1().Deployments(r.Namespace).Apply(
+	return ctx.KubeClient().KubeClient().AppsV1().Deployments(r.Namespace).Patch(
 		ctx.Context(),
-		applyConfiguration,
-		metav1.ApplyOptions{
+		r.Name,
+		types.ApplyPatchType,
+		patch,
+		metav1.PatchOptions{
 			FieldManager: ApplyFieldManager,
-			Force:        true,
+			Force:        ptr.Bool(true),
 		},
 	)
 }
@@ -191,6 +193,13 @@ func getRegistryContainers(registryImage, buildKitImage, volume string, port int
 		{
 			Name:  "registry",
 			Image: registryImage,
+			Env: []corev1.EnvVar{
+				{
+					// allows deleting manifests for devspace cleanup local-registry
+					Name:  "REGISTRY_STORAGE_DELETE_ENABLED",
+					Value: "true",
+				},
+			},
 			LivenessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
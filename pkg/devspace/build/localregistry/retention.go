@@ -0,0 +1,254 @@
+package localregistry
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GarbageCollectCommand is executed in the registry container after manifests were deleted. It
+// only deletes the blobs of deleted manifests, untagged manifests are kept, because they could be
+// the platform manifests of an image index or images that were pushed by digest.
+var GarbageCollectCommand = []string{"registry", "garbage-collect", "/etc/docker/registry/config.yml"}
+
+// RetentionOptions describe which images are kept in the local registry
+type RetentionOptions struct {
+	// Keep is the amount of most recent images that are kept per repository
+	Keep int
+
+	// OlderThan only deletes images that were created before this duration
+	OlderThan time.Duration
+}
+
+// Image is a single manifest within a repository of the local registry
+type Image struct {
+	Repository string
+	Digest     string
+	Tags       []string
+	Created    time.Time
+
+	// Manifests are the digests of the manifests an image index references
+	Manifests []string
+}
+
+// Cleanup deletes all images from the local registry that are not within the retention
+// and runs the registry garbage collection afterwards to free up the storage. The registry
+// must already exist, it will not be created. The garbage collection runs while the registry
+// serves, so nothing should be pushed to it during the cleanup.
+func Cleanup(ctx devspacecontext.Context, options Options, retention RetentionOptions) ([]*Image, error) {
+	r := newLocalRegistry(options)
+	service, err := ctx.KubeClient().KubeClient().CoreV1().Services(r.Namespace).Get(ctx.Context(), r.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "get local registry service")
+	}
+	r.servicePort = GetServicePort(service)
+	if r.servicePort == nil || r.servicePort.NodePort == 0 {
+		return nil, errors.Errorf("local registry service %s has no node port", r.Name)
+	}
+	r.host = fmt.Sprintf("localhost:%d", r.servicePort.NodePort)
+
+	registryPod, err := r.SelectRegistryPod(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "select registry pod")
+	}
+
+	isRegistryAvailable, err := r.ping(ctx.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "ping local registry")
+	} else if !isRegistryAvailable {
+		ctx.Log().Debug("Starting local registry port forwarding")
+		if err := r.startPortForwarding(ctx, registryPod); err != nil {
+			return nil, errors.Wrap(err, "start port forwarding")
+		}
+		if err := r.waitForRegistry(ctx.Context()); err != nil {
+			return nil, errors.Wrap(err, "wait for registry")
+		}
+	}
+
+	images, err := r.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		if image.Created.IsZero() {
+			ctx.Log().Infof("Skip image %s (%s), because its creation time is unknown", image.Repository+":"+strings.Join(image.Tags, ","), image.Digest)
+		}
+	}
+
+	expired := ExpiredImages(images, retention, time.Now())
+	for _, image := range expired {
+		ref, err := name.NewDigest(r.host + "/" + image.Repository + "@" + image.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Log().Infof("Delete image %s (%s)", image.Repository+":"+strings.Join(image.Tags, ","), image.Digest)
+		err = remote.Delete(ref, remote.WithContext(ctx.Context()))
+		if err != nil {
+			if isUnsupported(err) {
+				return nil, errors.Errorf("the local registry does not allow deleting images, please delete the registry with 'devspace cleanup local-registry' and let devspace recreate it")
+			}
+
+			return nil, errors.Wrapf(err, "delete image %s", ref.String())
+		}
+	}
+	if len(expired) == 0 {
+		return expired, nil
+	}
+
+	// the registry keeps serving during the garbage collection, so layers of images that are
+	// pushed in the meantime could be deleted
+	ctx.Log().Warn("Run local registry garbage collection, images pushed while it runs may be corrupted...")
+	out, err := ctx.KubeClient().ExecBufferedCombined(ctx.Context(), registryPod, "registry", GarbageCollectCommand, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "garbage collect: %s", string(out))
+	}
+	ctx.Log().Debugf("Garbage collection output: %s", string(out))
+	return expired, nil
+}
+
+// ExpiredImages returns the images that are not within the retention. Images are grouped by
+// repository and sorted by creation time, the newest images of a repository are kept. Images
+// without creation time, such as image indexes, are never expired and neither are the manifests
+// they reference.
+func ExpiredImages(images []*Image, retention RetentionOptions, now time.Time) []*Image {
+	referenced := map[string]bool{}
+	for _, image := range images {
+		for _, manifest := range image.Manifests {
+			referenced[image.Repository+"@"+manifest] = true
+		}
+	}
+
+	byRepository := map[string][]*Image{}
+	repositories := []string{}
+	for _, image := range images {
+		if image.Created.IsZero() || referenced[image.Repository+"@"+image.Digest] {
+			continue
+		}
+		if _, ok := byRepository[image.Repository]; !ok {
+			repositories = append(repositories, image.Repository)
+		}
+
+		byRepository[image.Repository] = append(byRepository[image.Repository], image)
+	}
+	sort.Strings(repositories)
+
+	expired := []*Image{}
+	for _, repository := range repositories {
+		repositoryImages := byRepository[repository]
+		sort.SliceStable(repositoryImages, func(i, j int) bool {
+			return repositoryImages[i].Created.After(repositoryImages[j].Created)
+		})
+
+		for i, image := range repositoryImages {
+			if i < retention.Keep {
+				continue
+			} else if retention.OlderThan > 0 && now.Sub(image.Created) < retention.OlderThan {
+				continue
+			}
+
+			expired = append(expired, image)
+		}
+	}
+
+	return expired
+}
+
+func (r *LocalRegistry) listImages(ctx devspacecontext.Context) ([]*Image, error) {
+	registry, err := name.NewRegistry(r.host)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := remote.Catalog(ctx.Context(), registry)
+	if err != nil {
+		return nil, errors.Wrap(err, "list repositories")
+	}
+
+	images := []*Image{}
+	for _, repositoryName := range repositories {
+		repository, err := name.NewRepository(r.host + "/" + repositoryName)
+		if err != nil {
+			return nil, err
+		}
+
+		tags, err := remote.List(repository, remote.WithContext(ctx.Context()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "list tags of %s", repositoryName)
+		}
+
+		// multiple tags can point to the same manifest
+		byDigest := map[string]*Image{}
+		for _, tag := range tags {
+			descriptor, err := remote.Get(repository.Tag(tag), remote.WithContext(ctx.Context()))
+			if err != nil {
+				return nil, errors.Wrapf(err, "get manifest of %s:%s", repositoryName, tag)
+			}
+
+			digest := descriptor.Digest.String()
+			if image, ok := byDigest[digest]; ok {
+				image.Tags = append(image.Tags, tag)
+				continue
+			}
+
+			image := &Image{
+				Repository: repositoryName,
+				Digest:     digest,
+				Tags:       []string{tag},
+			}
+
+			// image indexes have no config, so they have no creation time and are skipped
+			if descriptor.MediaType.IsIndex() {
+				index, err := descriptor.ImageIndex()
+				if err == nil {
+					manifest, err := index.IndexManifest()
+					if err == nil {
+						for _, child := range manifest.Manifests {
+							image.Manifests = append(image.Manifests, child.Digest.String())
+						}
+					}
+				}
+			} else {
+				img, err := descriptor.Image()
+				if err == nil {
+					configFile, err := img.ConfigFile()
+					if err == nil {
+						image.Created = configFile.Created.Time
+					}
+				}
+			}
+
+			byDigest[digest] = image
+			images = append(images, image)
+		}
+	}
+
+	return images, nil
+}
+
+func isUnsupported(err error) bool {
+	transportErr := &transport.Error{}
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+
+	if transportErr.StatusCode == http.StatusMethodNotAllowed {
+		return true
+	}
+	for _, diagnostic := range transportErr.Errors {
+		if diagnostic.Code == transport.UnsupportedErrorCode {
+			return true
+		}
+	}
+
+	return false
+}
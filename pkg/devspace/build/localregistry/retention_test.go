@@ -0,0 +1,90 @@
+package localregistry
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type expiredImagesTestCase struct {
+	name      string
+	images    []*Image
+	retention RetentionOptions
+	expected  []string
+}
+
+func TestExpiredImages(t *testing.T) {
+	now := time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC)
+	days := func(d int) time.Time {
+		return now.Add(-time.Duration(d) * 24 * time.Hour)
+	}
+	images := func() []*Image {
+		return []*Image{
+			{Repository: "app", Digest: "a1", Created: days(10)},
+			{Repository: "app", Digest: "a2", Created: days(1)},
+			{Repository: "app", Digest: "a3", Created: days(8)},
+			{Repository: "app", Digest: "a4", Created: days(3)},
+			{Repository: "api", Digest: "b1", Created: days(20)},
+			{Repository: "api", Digest: "index"},
+		}
+	}
+
+	testCases := []expiredImagesTestCase{
+		{
+			name:      "Keep newest",
+			images:    images(),
+			retention: RetentionOptions{Keep: 2},
+			expected:  []string{"a3", "a1"},
+		},
+		{
+			name:      "Older than",
+			images:    images(),
+			retention: RetentionOptions{OlderThan: 7 * 24 * time.Hour},
+			expected:  []string{"b1", "a3", "a1"},
+		},
+		{
+			name:      "Keep and older than",
+			images:    images(),
+			retention: RetentionOptions{Keep: 1, OlderThan: 2 * 24 * time.Hour},
+			expected:  []string{"a4", "a3", "a1"},
+		},
+		{
+			name:      "Keep none",
+			images:    images(),
+			retention: RetentionOptions{},
+			expected:  []string{"b1", "a2", "a4", "a3", "a1"},
+		},
+		{
+			name:      "Keep more than available",
+			images:    images(),
+			retention: RetentionOptions{Keep: 5},
+			expected:  []string{},
+		},
+		{
+			name: "Keep index and its manifests",
+			images: append(images(), []*Image{
+				{Repository: "web", Digest: "c1", Created: days(30)},
+				{Repository: "web", Digest: "c2", Created: days(30)},
+				{Repository: "web", Digest: "c3", Created: days(30)},
+				{Repository: "web", Digest: "multiarch", Manifests: []string{"c1", "c2"}},
+			}...),
+			retention: RetentionOptions{},
+			expected:  []string{"b1", "a2", "a4", "a3", "a1", "c3"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		digests := []string{}
+		for _, image := range ExpiredImages(testCase.images, testCase.retention, now) {
+			digests = append(digests, image.Digest)
+		}
+
+		assert.DeepEqual(t, digests, testCase.expected)
+	}
+
+	// untagged manifests, such as the platform manifests of indexes, are not garbage collected
+	for _, arg := range GarbageCollectCommand {
+		assert.Assert(t, arg != "--delete-untagged")
+	}
+}
@@ -2,16 +2,18 @@ package localregistry
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
-	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 )
 
 func (r *LocalRegistry) ensureStatefulset(ctx devspacecontext.Context) (*appsv1.StatefulSet, error) {
@@ -54,17 +56,23 @@ func (r *LocalRegistry) ensureStatefulset(ctx devspacecontext.Context) (*appsv1.
 		return nil, err
 	}
 
-	// Use server side apply if it does exist
-	applyConfiguration, err := appsapplyv1.ExtractStatefulSet(existing, ApplyFieldManager)
+	// Use server side apply with the desired statefulset if it does exist, so that changes such as
+	// new environment variables reach existing registries. The volume claim templates can't be
+	// changed, so the existing ones are kept.
+	desired.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "StatefulSet"}
+	desired.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+	patch, err := json.Marshal(desired)
 	if err != nil {
 		return nil, err
 	}
-	return ctx.KubeClient().KubeClient().AppsV1().StatefulSets(r.Namespace).Apply(
+	return ctx.KubeClient().KubeClient().AppsV1().StatefulSets(r.Namespace).Patch(
 		ctx.Context(),
-		applyConfiguration,
-		metav1.ApplyOptions{
+		r.Name,
+		types.ApplyPatchType,
+		patch,
+		metav1.PatchOptions{
 			FieldManager: ApplyFieldManager,
-			Force:        true,
+			Force:        ptr.Bool(true),
 		},
 	)
 }
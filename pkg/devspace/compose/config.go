@@ -0,0 +1,85 @@
+package compose
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	composetypes "github.com/compose-spec/compose-go/types"
+)
+
+// DefaultConfigTarget is the directory compose mounts configs into if no target is specified
+const DefaultConfigTarget = "/"
+
+func (cb *configBuilder) AddConfig(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error {
+	configNames := []string{}
+	for configName, config := range dockerCompose.Configs {
+		if config.External.External {
+			continue
+		}
+
+		configNames = append(configNames, configName)
+	}
+	sort.Strings(configNames)
+
+	cb.configCommands = nil
+	for _, configName := range configNames {
+		commands, err := configMapCommands(configName, cb.workingDir, dockerCompose.Configs[configName], dockerCompose.Name)
+		if err != nil {
+			return err
+		}
+
+		cb.configCommands = append(cb.configCommands, commands)
+	}
+
+	cb.setPipelines()
+	return nil
+}
+
+func configMapCommands(name string, cwd string, config composetypes.ConfigObjConfig, projectName string) (resourceCommands, error) {
+	file, err := filepath.Rel(cwd, filepath.Join(cwd, config.File))
+	if err != nil {
+		return resourceCommands{}, err
+	}
+
+	configMapName := configMapName(name, config, projectName)
+	return resourceCommands{
+		create: fmt.Sprintf(`kubectl create configmap %s --namespace=${devspace.namespace} --dry-run=client --from-file=%s=%s -o yaml | kubectl apply -f -`, configMapName, name, filepath.ToSlash(file)),
+		delete: fmt.Sprintf(`kubectl delete configmap %s --namespace=${devspace.namespace} --ignore-not-found`, configMapName),
+	}, nil
+}
+
+// configMapName returns the name of the config map that holds the compose config. External configs
+// keep their name, unless it was generated by the compose loader.
+func configMapName(name string, config composetypes.ConfigObjConfig, projectName string) string {
+	if config.External.External && config.Name != "" && config.Name != projectName+"_"+name {
+		return config.Name
+	}
+
+	return formatName(name)
+}
+
+func createConfigVolume(config composetypes.ServiceConfigObjConfig, project *composetypes.Project) interface{} {
+	return map[string]interface{}{
+		"name": formatName(config.Source),
+		"configMap": map[string]interface{}{
+			"name": configMapName(config.Source, project.Configs[config.Source], project.Name),
+		},
+	}
+}
+
+func createConfigVolumeMount(config composetypes.ServiceConfigObjConfig) interface{} {
+	target := config.Target
+	if target == "" {
+		target = DefaultConfigTarget + config.Source
+	}
+
+	return map[string]interface{}{
+		"containerPath": target,
+		"volume": map[string]interface{}{
+			"name":     formatName(config.Source),
+			"subPath":  config.Source,
+			"readOnly": true,
+		},
+	}
+}
@@ -14,6 +14,8 @@ type ConfigBuilder interface {
 	AddDev(service composetypes.ServiceConfig) error
 	AddImage(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error
 	AddSecret(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error
+	AddConfig(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error
+	AddProfiles(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error
 	Config() *latest.Config
 	SetName(name string)
 }
@@ -22,6 +24,9 @@ type configBuilder struct {
 	config     *latest.Config
 	log        log.Logger
 	workingDir string
+
+	secretCommands []resourceCommands
+	configCommands []resourceCommands
 }
 
 func NewConfigBuilder(workingDir string, log log.Logger) ConfigBuilder {
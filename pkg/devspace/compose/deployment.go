@@ -15,7 +15,7 @@ import (
 func (cb *configBuilder) AddDeployment(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error {
 	values := map[string]interface{}{}
 
-	volumes, volumeMounts, _ := volumesConfig(service, dockerCompose.Volumes, cb.log)
+	volumes, volumeMounts, _ := volumesConfig(service, dockerCompose.Volumes, dockerCompose, cb.log)
 	if len(volumes) > 0 {
 		values["volumes"] = volumes
 	}
@@ -9,6 +9,7 @@ import (
 
 	composetypes "github.com/compose-spec/compose-go/types"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
 )
 
 func (cb *configBuilder) AddDev(service composetypes.ServiceConfig) error {
@@ -39,6 +40,10 @@ func (cb *configBuilder) AddDev(service composetypes.ServiceConfig) error {
 			portMapping.BindAddress = port.HostIP
 		}
 
+		if strings.EqualFold(port.Protocol, string(latest.PortProtocolUDP)) {
+			portMapping.Protocol = latest.PortProtocolUDP
+		}
+
 		devPorts = append(devPorts, portMapping)
 	}
 
@@ -65,12 +70,53 @@ func (cb *configBuilder) AddDev(service composetypes.ServiceConfig) error {
 		}
 	}
 
+	develop, err := serviceDevelopConfig(service)
+	if err != nil {
+		return err
+	}
+
+	restartContainer := false
+	if develop != nil {
+		for _, rule := range develop.Watch {
+			switch rule.Action {
+			case WatchActionSync, WatchActionSyncRestart:
+				if rule.Target == "" {
+					cb.log.Warnf("Watch rule for %s has no target", rule.Path)
+					continue
+				}
+
+				sync := &latest.SyncConfig{
+					Path:         strings.Join([]string{rule.Path, rule.Target}, ":"),
+					ExcludePaths: rule.Ignore,
+				}
+				if rule.Action == WatchActionSyncRestart {
+					sync.OnUpload = &latest.SyncOnUpload{
+						RestartContainer: true,
+					}
+					restartContainer = true
+				}
+
+				syncConfigs = append(syncConfigs, sync)
+			case WatchActionRebuild:
+				// rebuilds are covered by the rebuild strategy of the image
+			default:
+				cb.log.Warnf("Watch action %s is not supported", rule.Action)
+			}
+		}
+	}
+
 	if len(devPorts) > 0 || len(syncConfigs) > 0 {
 		dev = &latest.DevPod{
 			LabelSelector: labelSelector(service.Name),
 		}
 	}
 
+	if restartContainer {
+		dev.RestartHelper = &latest.RestartHelper{
+			Inject: ptr.Bool(true),
+		}
+	}
+
 	if len(devPorts) > 0 {
 		dev.Ports = devPorts
 	}
@@ -0,0 +1,77 @@
+package compose
+
+import (
+	"path/filepath"
+	"strings"
+
+	composetypes "github.com/compose-spec/compose-go/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+)
+
+const (
+	WatchActionSync        = "sync"
+	WatchActionSyncRestart = "sync+restart"
+	WatchActionRebuild     = "rebuild"
+)
+
+// developConfig is the develop section of a compose service
+type developConfig struct {
+	Watch []watchRule `yaml:"watch,omitempty"`
+}
+
+// watchRule is a develop.watch rule of a compose service
+type watchRule struct {
+	Path   string   `yaml:"path"`
+	Action string   `yaml:"action"`
+	Target string   `yaml:"target,omitempty"`
+	Ignore []string `yaml:"ignore,omitempty"`
+}
+
+func serviceDevelopConfig(service composetypes.ServiceConfig) (*developConfig, error) {
+	develop, ok := service.Extensions[DevelopExtension]
+	if !ok {
+		return nil, nil
+	}
+
+	config := &developConfig{}
+	err := util.Convert(develop, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// rebuildStrategy maps the rebuild watch rules to a rebuild strategy. DevSpace rebuilds images on
+// changes within the build context, so files that are only synced should not trigger a rebuild and
+// rebuild rules outside of the build context can only be covered by always rebuilding.
+func rebuildStrategy(develop *developConfig, workingDir, contextDir string) latest.RebuildStrategy {
+	if develop == nil || len(develop.Watch) == 0 {
+		return ""
+	}
+
+	rebuild := false
+	for _, rule := range develop.Watch {
+		if rule.Action != WatchActionRebuild {
+			continue
+		}
+
+		rebuild = true
+		path := rule.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workingDir, path)
+		}
+
+		relPath, err := filepath.Rel(contextDir, path)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return latest.RebuildStrategyAlways
+		}
+	}
+
+	if !rebuild {
+		return latest.RebuildStrategyIgnoreContextChanges
+	}
+
+	return ""
+}
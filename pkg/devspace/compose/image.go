@@ -56,6 +56,12 @@ func (cb *configBuilder) AddImage(dockerCompose *composetypes.Project, service c
 		image.Entrypoint = service.Entrypoint
 	}
 
+	develop, err := serviceDevelopConfig(service)
+	if err != nil {
+		return err
+	}
+	image.RebuildStrategy = rebuildStrategy(develop, dockerCompose.WorkingDir, contextDir)
+
 	if cb.config.Images == nil {
 		cb.config.Images = map[string]*latest.Image{}
 	}
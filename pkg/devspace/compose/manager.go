@@ -20,6 +20,7 @@ var (
 	DockerIgnorePath           = ".dockerignore"
 	DefaultVolumeSize          = "5Gi"
 	UploadVolumesContainerName = "upload-volumes"
+	DevelopExtension           = "x-develop"
 )
 
 func GetDockerComposePath() string {
@@ -38,6 +39,11 @@ func LoadDockerComposeProject(path string) (*composetypes.Project, error) {
 		return nil, err
 	}
 
+	composeFile, err = moveDevelopSections(composeFile)
+	if err != nil {
+		return nil, err
+	}
+
 	project, err := composeloader.Load(composetypes.ConfigDetails{
 		ConfigFiles: []composetypes.ConfigFile{
 			{
@@ -110,22 +116,34 @@ func (cm *composeManager) Load(log log.Logger) error {
 
 		builder.SetName(configName)
 
-		err := builder.AddImage(cm.project, service)
-		if err != nil {
-			return err
-		}
+		if len(service.Profiles) > 0 {
+			err := builder.AddProfiles(cm.project, service)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := builder.AddImage(cm.project, service)
+			if err != nil {
+				return err
+			}
 
-		err = builder.AddDeployment(cm.project, service)
-		if err != nil {
-			return err
+			err = builder.AddDeployment(cm.project, service)
+			if err != nil {
+				return err
+			}
+
+			err = builder.AddDev(service)
+			if err != nil {
+				return err
+			}
 		}
 
-		err = builder.AddDev(service)
+		err := builder.AddSecret(cm.project, service)
 		if err != nil {
 			return err
 		}
 
-		err = builder.AddSecret(cm.project, service)
+		err = builder.AddConfig(cm.project, service)
 		if err != nil {
 			return err
 		}
@@ -191,6 +209,41 @@ func (cm *composeManager) Save() error {
 	return nil
 }
 
+// moveDevelopSections moves the develop sections of the services into the x-develop extension,
+// because the compose loader does not support them yet
+func moveDevelopSections(composeFile []byte) ([]byte, error) {
+	composeMap := map[string]interface{}{}
+	err := yaml.Unmarshal(composeFile, &composeMap)
+	if err != nil {
+		return nil, err
+	}
+
+	services, ok := composeMap["services"].(map[string]interface{})
+	if !ok {
+		return composeFile, nil
+	}
+
+	moved := false
+	for _, service := range services {
+		serviceMap, ok := service.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		develop, ok := serviceMap["develop"]
+		if ok {
+			serviceMap[DevelopExtension] = develop
+			delete(serviceMap, "develop")
+			moved = true
+		}
+	}
+	if !moved {
+		return composeFile, nil
+	}
+
+	return yaml.Marshal(composeMap)
+}
+
 func calculateDependentsMap(dockerCompose *composetypes.Project) (map[string][]string, error) {
 	tree := map[string][]string{}
 	err := dockerCompose.WithServices(nil, func(service composetypes.ServiceConfig) error {
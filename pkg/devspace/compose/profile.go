@@ -0,0 +1,82 @@
+package compose
+
+import (
+	composetypes "github.com/compose-spec/compose-go/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+)
+
+// AddProfiles adds the image, deployment and dev configuration of a service that is only enabled
+// for certain compose profiles to the DevSpace profiles with the same names
+func (cb *configBuilder) AddProfiles(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error {
+	serviceBuilder := NewConfigBuilder(cb.workingDir, cb.log)
+	err := serviceBuilder.AddImage(dockerCompose, service)
+	if err != nil {
+		return err
+	}
+
+	err = serviceBuilder.AddDeployment(dockerCompose, service)
+	if err != nil {
+		return err
+	}
+
+	err = serviceBuilder.AddDev(service)
+	if err != nil {
+		return err
+	}
+
+	for _, profileName := range service.Profiles {
+		profile := cb.profile(profileName)
+
+		err = mergeProfileSection(&profile.Merge.Images, serviceBuilder.Config().Images)
+		if err != nil {
+			return err
+		}
+
+		err = mergeProfileSection(&profile.Merge.Deployments, serviceBuilder.Config().Deployments)
+		if err != nil {
+			return err
+		}
+
+		err = mergeProfileSection(&profile.Merge.Dev, serviceBuilder.Config().Dev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cb *configBuilder) profile(name string) *latest.ProfileConfig {
+	for _, profile := range cb.config.Profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+
+	profile := &latest.ProfileConfig{
+		Name:  name,
+		Merge: &latest.ProfileConfigStructure{},
+	}
+	cb.config.Profiles = append(cb.config.Profiles, profile)
+	return profile
+}
+
+func mergeProfileSection(section **map[string]interface{}, values interface{}) error {
+	converted := map[string]interface{}{}
+	err := util.Convert(values, &converted)
+	if err != nil {
+		return err
+	} else if len(converted) == 0 {
+		return nil
+	}
+
+	if *section == nil {
+		*section = &map[string]interface{}{}
+	}
+	for key, value := range converted {
+		(**section)[key] = value
+	}
+
+	return nil
+}
@@ -3,46 +3,75 @@ package compose
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	composetypes "github.com/compose-spec/compose-go/types"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 )
 
+// resourceCommands creates a resource in the dev pipeline and deletes it in the purge pipeline
+type resourceCommands struct {
+	create string
+	delete string
+}
+
 func (cb *configBuilder) AddSecret(dockerCompose *composetypes.Project, service composetypes.ServiceConfig) error {
-	var pipelines map[string]*latest.Pipeline
-	for secretName, secret := range dockerCompose.Secrets {
-		if pipelines == nil {
-			pipelines = map[string]*latest.Pipeline{}
-		}
+	secretNames := []string{}
+	for secretName := range dockerCompose.Secrets {
+		secretNames = append(secretNames, secretName)
+	}
+	sort.Strings(secretNames)
 
-		devSecretStep, err := createSecretPipeline(secretName, cb.workingDir, secret)
+	cb.secretCommands = nil
+	for _, secretName := range secretNames {
+		commands, err := secretCommands(secretName, cb.workingDir, dockerCompose.Secrets[secretName])
 		if err != nil {
 			return err
 		}
 
-		pipelines["dev"] = devSecretStep
-		pipelines["purge"] = deleteSecretPipeline(secretName)
+		cb.secretCommands = append(cb.secretCommands, commands)
 	}
 
-	cb.config.Pipelines = pipelines
+	cb.setPipelines()
 	return nil
 }
 
-func createSecretPipeline(name string, cwd string, secret composetypes.SecretConfig) (*latest.Pipeline, error) {
+func secretCommands(name string, cwd string, secret composetypes.SecretConfig) (resourceCommands, error) {
 	file, err := filepath.Rel(cwd, filepath.Join(cwd, secret.File))
 	if err != nil {
-		return nil, err
+		return resourceCommands{}, err
 	}
 
-	return &latest.Pipeline{
-		Run: fmt.Sprintf(`kubectl create secret generic %s --namespace=${devspace.namespace} --dry-run=client --from-file=%s=%s -o yaml | kubectl apply -f -
-run_default_pipeline dev`, name, name, filepath.ToSlash(file)),
+	return resourceCommands{
+		create: fmt.Sprintf(`kubectl create secret generic %s --namespace=${devspace.namespace} --dry-run=client --from-file=%s=%s -o yaml | kubectl apply -f -`, name, name, filepath.ToSlash(file)),
+		delete: fmt.Sprintf(`kubectl delete secret %s --namespace=${devspace.namespace} --ignore-not-found`, name),
 	}, nil
 }
 
-func deleteSecretPipeline(name string) *latest.Pipeline {
-	return &latest.Pipeline{
-		Run: fmt.Sprintf(`run_default_pipeline purge
-kubectl delete secret %s --namespace=${devspace.namespace} --ignore-not-found`, name),
+// setPipelines creates the secrets and config maps before the default dev pipeline
+// and deletes them after the default purge pipeline
+func (cb *configBuilder) setPipelines() {
+	commands := append(append([]resourceCommands{}, cb.secretCommands...), cb.configCommands...)
+	if len(commands) == 0 {
+		cb.config.Pipelines = nil
+		return
+	}
+
+	createCommands := []string{}
+	deleteCommands := []string{"run_default_pipeline purge"}
+	for _, command := range commands {
+		createCommands = append(createCommands, command.create)
+		deleteCommands = append(deleteCommands, command.delete)
+	}
+	createCommands = append(createCommands, "run_default_pipeline dev")
+
+	cb.config.Pipelines = map[string]*latest.Pipeline{
+		"dev": {
+			Run: strings.Join(createCommands, "\n"),
+		},
+		"purge": {
+			Run: strings.Join(deleteCommands, "\n"),
+		},
 	}
 }
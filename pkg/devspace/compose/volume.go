@@ -31,6 +31,7 @@ func GetServiceSyncPaths(
 func volumesConfig(
 	service composetypes.ServiceConfig,
 	composeVolumes map[string]composetypes.VolumeConfig,
+	project *composetypes.Project,
 	log log.Logger,
 ) (volumes []interface{}, volumeMounts []interface{}, bindVolumeMounts []interface{}) {
 	for _, secret := range service.Secrets {
@@ -41,6 +42,18 @@ func volumesConfig(
 		volumeMounts = append(volumeMounts, volumeMount)
 	}
 
+	configVolumes := map[string]bool{}
+	for _, config := range service.Configs {
+		if !configVolumes[config.Source] {
+			volume := createConfigVolume(config, project)
+			volumes = append(volumes, volume)
+			configVolumes[config.Source] = true
+		}
+
+		volumeMount := createConfigVolumeMount(config)
+		volumeMounts = append(volumeMounts, volumeMount)
+	}
+
 	var volumeVolumes []composetypes.ServiceVolumeConfig
 	var bindVolumes []composetypes.ServiceVolumeConfig
 	var tmpfsVolumes []composetypes.ServiceVolumeConfig
@@ -6,6 +6,9 @@ const DefaultConfigsPath = "devspace-configs.yaml"
 // DefaultConfigPath is the default config path to use
 const DefaultConfigPath = "devspace.yaml"
 
+// DefaultLockPath is the default path of the lock file for git and remote sources
+const DefaultLockPath = "devspace.lock"
+
 // DefaultVarsPath is the default vars path to use
 const DefaultVarsPath = "devspace-vars.yaml"
 
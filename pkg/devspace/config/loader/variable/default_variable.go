@@ -13,11 +13,12 @@ import (
 )
 
 // NewDefaultVariable creates a new variable for the sources default, all or input
-func NewDefaultVariable(name string, workingDirectory string, localCache localcache.Cache, log log.Logger) Variable {
+func NewDefaultVariable(name string, workingDirectory string, localCache localcache.Cache, secretFiles *SecretFiles, log log.Logger) Variable {
 	return &defaultVariable{
 		name:             name,
 		workingDirectory: workingDirectory,
 		localCache:       localCache,
+		secretFiles:      secretFiles,
 		log:              log,
 	}
 }
@@ -26,12 +27,15 @@ type defaultVariable struct {
 	name             string
 	workingDirectory string
 	localCache       localcache.Cache
+	secretFiles      *SecretFiles
 	log              log.Logger
 }
 
 func (d *defaultVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
 	if definition.Command != "" || len(definition.Commands) > 0 {
 		return NewCommandVariable(d.name, d.workingDirectory).Load(ctx, definition)
+	} else if definition.File != "" {
+		return NewSecretVariable(d.name, d.workingDirectory, d.secretFiles).Load(ctx, definition)
 	}
 
 	// Check environment
@@ -32,6 +32,7 @@ func NewResolver(localCache localcache.Cache, predefinedVariableOptions *Predefi
 
 	return &resolver{
 		memoryCache: memoryCache,
+		secretFiles: NewSecretFiles(),
 		localCache:  localCache,
 		options:     predefinedVariableOptions,
 		log:         log,
@@ -56,6 +57,7 @@ func MergeVarsWithFlags(vars map[string]interface{}, flags []string) error {
 type resolver struct {
 	vars        map[string]*latest.Variable
 	memoryCache map[string]interface{}
+	secretFiles *SecretFiles
 
 	localCache localcache.Cache
 	options    *PredefinedVariableOptions
@@ -422,6 +424,14 @@ func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[st
 		return "", nil
 	})
 
+	// check secret
+	for _, str := range []string{definition.File, definition.Path, definition.IdentityFile} {
+		_, _ = varspkg.ParseString(str, func(v string) (interface{}, error) {
+			varsUsed[v] = true
+			return "", nil
+		})
+	}
+
 	// check args
 	for _, arg := range definition.Args {
 		_, _ = varspkg.ParseString(arg, func(v string) (interface{}, error) {
@@ -512,6 +522,20 @@ func (r *resolver) fillVariableDefinition(ctx context.Context, definition *lates
 		}
 	}
 
+	// resolve secret
+	definition.File, err = r.resolveDefinitionStringToString(ctx, definition.File, definition)
+	if err != nil {
+		return err
+	}
+	definition.Path, err = r.resolveDefinitionStringToString(ctx, definition.Path, definition)
+	if err != nil {
+		return err
+	}
+	definition.IdentityFile, err = r.resolveDefinitionStringToString(ctx, definition.IdentityFile, definition)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -574,12 +598,14 @@ func (r *resolver) fillVariable(ctx context.Context, name string, definition *la
 	case latest.VariableSourceEnv:
 		return NewEnvVariable(name).Load(ctx, definition)
 	case latest.VariableSourceDefault, latest.VariableSourceInput, latest.VariableSourceAll:
-		return NewDefaultVariable(name, filepath.Dir(r.options.ConfigPath), r.localCache, r.log).Load(ctx, definition)
+		return NewDefaultVariable(name, filepath.Dir(r.options.ConfigPath), r.localCache, r.secretFiles, r.log).Load(ctx, definition)
 	case latest.VariableSourceNone:
 		return NewNoneVariable(name).Load(ctx, definition)
 	case latest.VariableSourceCommand:
 		return NewCommandVariable(name, filepath.Dir(r.options.ConfigPath)).Load(ctx, definition)
+	case latest.VariableSourceSecret:
+		return NewSecretVariable(name, filepath.Dir(r.options.ConfigPath), r.secretFiles).Load(ctx, definition)
 	default:
-		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env' or 'none'", name)
+		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env', 'command', 'secret' or 'none'", definition.Source)
 	}
 }
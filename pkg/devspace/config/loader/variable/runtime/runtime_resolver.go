@@ -88,6 +88,9 @@ func (r *runtimeResolver) fillVariables(haystack interface{}, config config.Conf
 			return val, err
 		})
 		return shouldRebuild, t, err
+	case bool, int, nil:
+		// expressions can resolve to plain values
+		return false, t, nil
 	}
 
 	return false, nil, fmt.Errorf("unrecognized haystack type: %#v", haystack)
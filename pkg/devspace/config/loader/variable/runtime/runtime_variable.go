@@ -18,6 +18,7 @@ var Locations = []string{
 	"/images/*/build/custom/appendArgs/**",
 	"/deployments/*/helm/values/**",
 	"/deployments/*/kubectl/inlineManifest/**",
+	"/hooks/*/if",
 	"/hooks/*/command",
 	"/hooks/*/args/*",
 	"/hooks/*/container/imageSelector",
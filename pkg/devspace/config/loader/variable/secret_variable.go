@@ -0,0 +1,119 @@
+package variable
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// SecretFiles caches the decrypted secret files by path, so that a file is only decrypted
+// once while the config is loaded, even if several variables are loaded from it
+type SecretFiles struct {
+	m     sync.Mutex
+	files map[string]map[string]interface{}
+}
+
+// NewSecretFiles creates a new empty cache for decrypted secret files
+func NewSecretFiles() *SecretFiles {
+	return &SecretFiles{
+		files: map[string]map[string]interface{}{},
+	}
+}
+
+// NewSecretVariable creates a new variable that is loaded from a sops or age encrypted file.
+// Decrypted files are cached in secretFiles, which can be nil to always decrypt the file.
+func NewSecretVariable(name, workingDirectory string, secretFiles *SecretFiles) Variable {
+	return &secretVariable{
+		name:             name,
+		workingDirectory: workingDirectory,
+		secretFiles:      secretFiles,
+	}
+}
+
+type secretVariable struct {
+	name             string
+	workingDirectory string
+	secretFiles      *SecretFiles
+}
+
+func (s *secretVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
+	if definition.File == "" {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no file is specified", s.name, latest.VariableSourceSecret)
+	}
+
+	doc, err := s.decrypt(definition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fill variable %s", s.name)
+	}
+
+	path := definition.Path
+	if path == "" {
+		path = s.name
+	}
+
+	value, ok := secrets.Lookup(doc, path)
+	if !ok {
+		if definition.Default != nil {
+			return definition.Default, nil
+		}
+
+		return nil, errors.Errorf("couldn't set variable '%s', because '%s' was not found in %s", s.name, path, definition.File)
+	}
+
+	return value, nil
+}
+
+func (s *secretVariable) decrypt(definition *latest.Variable) (map[string]interface{}, error) {
+	file, err := s.resolvePath(definition.File)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.secretFiles != nil {
+		s.secretFiles.m.Lock()
+		defer s.secretFiles.m.Unlock()
+
+		if doc, ok := s.secretFiles.files[file]; ok {
+			return doc, nil
+		}
+	}
+
+	identityFile := definition.IdentityFile
+	if identityFile != "" {
+		identityFile, err = s.resolvePath(identityFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identities, err := secrets.LoadIdentities(identityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := secrets.DecryptFile(file, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.secretFiles != nil {
+		s.secretFiles.files[file] = doc
+	}
+	return doc, nil
+}
+
+func (s *secretVariable) resolvePath(path string) (string, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return "", err
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(s.workingDirectory, path)
+	}
+
+	return path, nil
+}
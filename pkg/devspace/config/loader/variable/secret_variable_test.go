@@ -0,0 +1,87 @@
+package variable
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"gotest.tools/assert"
+)
+
+func TestSecretVariable(t *testing.T) {
+	dir := t.TempDir()
+	identity, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "keys.txt"), []byte(identity.String()+"\n"), 0600)
+	assert.NilError(t, err)
+
+	encrypted, err := secrets.Encrypt([]byte("DB_USER: admin\ndatabase:\n  password: secret\n  port: 5432\n"), []age.Recipient{identity.Recipient()})
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "secrets.yaml"), encrypted, 0600)
+	assert.NilError(t, err)
+
+	testCases := map[string]struct {
+		name          string
+		definition    *latest.Variable
+		expectedValue interface{}
+		expectedErr   string
+	}{
+		"name as path": {
+			name:          "DB_USER",
+			definition:    &latest.Variable{File: "secrets.yaml", IdentityFile: "keys.txt"},
+			expectedValue: "admin",
+		},
+		"nested path": {
+			name:          "DB_PASSWORD",
+			definition:    &latest.Variable{File: "secrets.yaml", Path: "database.password", IdentityFile: "keys.txt"},
+			expectedValue: "secret",
+		},
+		"integer": {
+			name:          "DB_PORT",
+			definition:    &latest.Variable{File: "secrets.yaml", Path: "database.port", IdentityFile: "keys.txt"},
+			expectedValue: 5432,
+		},
+		"default": {
+			name:          "DB_HOST",
+			definition:    &latest.Variable{File: "secrets.yaml", IdentityFile: "keys.txt", Default: "localhost"},
+			expectedValue: "localhost",
+		},
+		"missing": {
+			name:        "DB_HOST",
+			definition:  &latest.Variable{File: "secrets.yaml", IdentityFile: "keys.txt"},
+			expectedErr: "'DB_HOST' was not found in secrets.yaml",
+		},
+		"no file": {
+			name:        "DB_HOST",
+			definition:  &latest.Variable{Source: latest.VariableSourceSecret},
+			expectedErr: "no file is specified",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		value, err := NewSecretVariable(testCase.name, dir, nil).Load(context.Background(), testCase.definition)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, testName)
+			continue
+		}
+
+		assert.NilError(t, err, testName)
+		assert.Equal(t, value, testCase.expectedValue, testName)
+	}
+
+	// the file is only decrypted once while the config is loaded
+	secretFiles := NewSecretFiles()
+	value, err := NewSecretVariable("DB_USER", dir, secretFiles).Load(context.Background(), &latest.Variable{File: "secrets.yaml", IdentityFile: "keys.txt"})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "admin")
+
+	err = os.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte("not encrypted"), 0600)
+	assert.NilError(t, err)
+	value, err = NewSecretVariable("DB_PASSWORD", dir, secretFiles).Load(context.Background(), &latest.Variable{File: "secrets.yaml", Path: "database.password", IdentityFile: "keys.txt"})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "secret")
+}
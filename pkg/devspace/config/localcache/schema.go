@@ -66,6 +66,10 @@ type ImageCache struct {
 
 	CustomFilesHash string `yaml:"customFilesHash,omitempty"`
 
+	// BuildDigest is the machine independent digest of the build context, dockerfile and image config
+	// of the last build
+	BuildDigest string `yaml:"buildDigest,omitempty"`
+
 	ImageName              string `yaml:"imageName,omitempty"`
 	LocalRegistryImageName string `yaml:"localRegistryImageName,omitempty"`
 	Tag                    string `yaml:"tag,omitempty"`
@@ -1,9 +1,12 @@
 package remotecache
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -31,6 +34,17 @@ type Cache interface {
 	ListDevPods() []DevPodCache
 	SetDevPod(devPodName string, devPodCache DevPodCache)
 
+	GetImageBuild(imageName, digest string) (ImageBuildCache, bool)
+	SetImageBuild(imageBuild ImageBuildCache)
+
+	ListSSHKeys() []SSHKeyCache
+	SetSSHKey(sshKey SSHKeyCache)
+	DeleteSSHKey(user string) bool
+
+	ListSleepingWorkloads() []SleepingWorkloadCache
+	SetSleepingWorkload(workload SleepingWorkloadCache)
+	DeleteSleepingWorkload(kind, namespace, name string)
+
 	GetData(key string) (string, bool)
 	SetData(key, value string)
 
@@ -51,6 +65,15 @@ type RemoteCache struct {
 	DevPods     []DevPodCache     `yaml:"devPods,omitempty"`
 	Deployments []DeploymentCache `yaml:"deployments,omitempty"`
 
+	// ImageBuilds are the images that were built and pushed by any machine
+	ImageBuilds []ImageBuildCache `yaml:"imageBuilds,omitempty"`
+
+	// SSHKeys are the public keys of other users that were granted ssh access to the dev containers
+	SSHKeys []SSHKeyCache `yaml:"sshKeys,omitempty"`
+
+	// SleepingWorkloads are the workloads that were scaled down because of inactivity
+	SleepingWorkloads []SleepingWorkloadCache `yaml:"sleepingWorkloads,omitempty"`
+
 	// Data is arbitrary key value cache
 	Data map[string]string `yaml:"data,omitempty"`
 
@@ -62,6 +85,54 @@ type RemoteCache struct {
 	accessMutex     sync.Mutex `yaml:"-" json:"-"`
 }
 
+// MaxImageBuilds is the amount of builds that are remembered per image
+const MaxImageBuilds = 10
+
+// ImageBuildCache maps a build digest to an image tag that was pushed for it
+type ImageBuildCache struct {
+	// ImageName is the name of the image without the tag
+	ImageName string `yaml:"imageName,omitempty"`
+
+	// Digest is the digest of the build context, dockerfile and image config
+	Digest string `yaml:"digest,omitempty"`
+
+	// Tag is the tag that was pushed for the digest
+	Tag string `yaml:"tag,omitempty"`
+}
+
+// SSHKeyCache is a public key of a user that was granted ssh access
+type SSHKeyCache struct {
+	// User is the name of the user the key belongs to
+	User string `yaml:"user,omitempty"`
+
+	// PublicKey is the public key in the authorized keys format
+	PublicKey string `yaml:"publicKey,omitempty"`
+
+	// Fingerprint is the SHA256 fingerprint of the public key
+	Fingerprint string `yaml:"fingerprint,omitempty"`
+
+	// Granted is when the access was granted
+	Granted time.Time `yaml:"granted"`
+
+	// GrantedBy is the name of the user that has granted the access
+	GrantedBy string `yaml:"grantedBy,omitempty"`
+}
+
+// SleepingWorkloadCache is a workload that was scaled to zero because of inactivity
+type SleepingWorkloadCache struct {
+	// Kind is the kind of the workload, either Deployment or StatefulSet
+	Kind string `yaml:"kind,omitempty"`
+
+	// Namespace is the namespace of the workload
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Name is the name of the workload
+	Name string `yaml:"name,omitempty"`
+
+	// Replicas are the replicas of the workload before it was scaled down
+	Replicas int32 `yaml:"replicas,omitempty"`
+}
+
 type DevPodCache struct {
 	// Name is the name of the dev pod
 	Name string `yaml:"name,omitempty"`
@@ -75,6 +146,9 @@ type DevPodCache struct {
 	// Deployment is the deployment that was created by DevSpace
 	Deployment string `yaml:"deployment,omitempty"`
 
+	// TargetAPIVersion is the api version of the original parent, only set for custom targets
+	TargetAPIVersion string `yaml:"parentAPIVersion,omitempty"`
+
 	// TargetKind is the kind of the original parent
 	TargetKind string `yaml:"parentKind,omitempty"`
 
@@ -97,6 +171,116 @@ type DeploymentCache struct {
 
 	// Kubectl holds the kubectl cache
 	Kubectl *KubectlCache `yaml:"kubectlCache,omitempty"`
+
+	// History holds the last deploys of this deployment, the newest first
+	History []DeploymentRecord `yaml:"history,omitempty"`
+}
+
+// MaxDeploymentHistory is the amount of deploy records that are kept per deployment
+const MaxDeploymentHistory = 10
+
+// MaxHistoryManifestsSize is the amount of bytes the stored manifests of all deploy records may
+// use, so that the cache secret stays below the size limit of 1 MiB
+const MaxHistoryManifestsSize = 512 * 1024
+
+// DeploymentRecord is a single deploy of a deployment
+type DeploymentRecord struct {
+	// Revision is the number of the deploy, which is increased with every deploy
+	Revision int `yaml:"revision"`
+
+	// Time is when the deployment was deployed
+	Time time.Time `yaml:"time"`
+
+	// User is the name of the user that has deployed
+	User string `yaml:"user,omitempty"`
+
+	// GitCommit is the commit of the project that was deployed
+	GitCommit string `yaml:"gitCommit,omitempty"`
+
+	// ImageTags are the tags of the images that were used, keyed by image name
+	ImageTags map[string]string `yaml:"imageTags,omitempty"`
+
+	// DeploymentConfigHash is the hash of the deployment config
+	DeploymentConfigHash string `yaml:"deploymentConfigHash,omitempty"`
+
+	// ValuesHash is the hash of the helm values or kubectl manifests
+	ValuesHash string `yaml:"valuesHash,omitempty"`
+
+	// RollbackOf is the revision this deploy has rolled back to
+	RollbackOf int `yaml:"rollbackOf,omitempty"`
+
+	// HelmRevision is the helm release revision of the deploy
+	HelmRevision string `yaml:"helmRevision,omitempty"`
+
+	// Manifests are the gzipped and base64 encoded manifests that were applied by kubectl
+	Manifests string `yaml:"manifests,omitempty"`
+}
+
+// SetManifests compresses and stores the given rendered manifests
+func (r *DeploymentRecord) SetManifests(manifests string) error {
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	_, err := writer.Write([]byte(manifests))
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	r.Manifests = base64.StdEncoding.EncodeToString(buf.Bytes())
+	return nil
+}
+
+// GetManifests returns the decompressed rendered manifests
+func (r *DeploymentRecord) GetManifests() (string, error) {
+	if r.Manifests == "" {
+		return "", nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(r.Manifests)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(decoded))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// AddHistory adds the record as the newest deploy and assigns it the next revision
+func (d *DeploymentCache) AddHistory(record DeploymentRecord) {
+	record.Revision = 1
+	if len(d.History) > 0 {
+		record.Revision = d.History[0].Revision + 1
+	}
+
+	d.History = append([]DeploymentRecord{record}, d.History...)
+	if len(d.History) > MaxDeploymentHistory {
+		d.History = d.History[:MaxDeploymentHistory]
+	}
+}
+
+// GetHistory returns the deploy record with the given revision
+func (d *DeploymentCache) GetHistory(revision int) (DeploymentRecord, bool) {
+	for _, record := range d.History {
+		if record.Revision == revision {
+			return record, true
+		}
+	}
+
+	return DeploymentRecord{}, false
 }
 
 type HelmCache struct {
@@ -121,6 +305,54 @@ type KubectlObject struct {
 	Namespace  string `yaml:"namespace"`
 }
 
+// trimHistory removes the oldest deploy records with stored manifests until the manifests of all
+// deployments fit into MaxHistoryManifestsSize. The newest record of a deployment is kept for the
+// revision numbering, but its manifests are removed if they don't fit on their own.
+func (l *RemoteCache) trimHistory() {
+	size := 0
+	for _, deployment := range l.Deployments {
+		for _, record := range deployment.History {
+			size += len(record.Manifests)
+		}
+	}
+
+	for size > MaxHistoryManifestsSize {
+		deploymentIndex, recordIndex := l.oldestManifests(true)
+		if deploymentIndex == -1 {
+			deploymentIndex, recordIndex = l.oldestManifests(false)
+			if deploymentIndex == -1 {
+				return
+			}
+
+			record := &l.Deployments[deploymentIndex].History[recordIndex]
+			size -= len(record.Manifests)
+			record.Manifests = ""
+			continue
+		}
+
+		history := l.Deployments[deploymentIndex].History
+		size -= len(history[recordIndex].Manifests)
+		l.Deployments[deploymentIndex].History = append(history[:recordIndex], history[recordIndex+1:]...)
+	}
+}
+
+// oldestManifests returns the indexes of the oldest deploy record with stored manifests
+func (l *RemoteCache) oldestManifests(skipNewest bool) (int, int) {
+	deploymentIndex, recordIndex := -1, -1
+	for i, deployment := range l.Deployments {
+		for j, record := range deployment.History {
+			if record.Manifests == "" || (skipNewest && j == 0) {
+				continue
+			}
+			if deploymentIndex == -1 || record.Time.Before(l.Deployments[deploymentIndex].History[recordIndex].Time) {
+				deploymentIndex, recordIndex = i, j
+			}
+		}
+	}
+
+	return deploymentIndex, recordIndex
+}
+
 func (l *RemoteCache) ListDevPods() []DevPodCache {
 	l.accessMutex.Lock()
 	defer l.accessMutex.Unlock()
@@ -221,6 +453,117 @@ func (l *RemoteCache) SetDeployment(deploymentName string, deploymentCache Deplo
 	l.Deployments = append(l.Deployments, deploymentCache)
 }
 
+func (l *RemoteCache) GetImageBuild(imageName, digest string) (ImageBuildCache, bool) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	for _, imageBuild := range l.ImageBuilds {
+		if imageBuild.ImageName == imageName && imageBuild.Digest == digest {
+			return imageBuild, true
+		}
+	}
+	return ImageBuildCache{}, false
+}
+
+// SetImageBuild adds the image build as the newest build of the image and only keeps
+// the last MaxImageBuilds builds per image
+func (l *RemoteCache) SetImageBuild(imageBuild ImageBuildCache) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	newArr := []ImageBuildCache{imageBuild}
+	count := 1
+	for _, b := range l.ImageBuilds {
+		if b.ImageName == imageBuild.ImageName {
+			if b.Digest == imageBuild.Digest || count >= MaxImageBuilds {
+				continue
+			}
+
+			count++
+		}
+		newArr = append(newArr, b)
+	}
+	l.ImageBuilds = newArr
+}
+
+func (l *RemoteCache) ListSSHKeys() []SSHKeyCache {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	retArr := []SSHKeyCache{}
+	retArr = append(retArr, l.SSHKeys...)
+	return retArr
+}
+
+// SetSSHKey adds the ssh key or replaces the key of the same user
+func (l *RemoteCache) SetSSHKey(sshKey SSHKeyCache) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	for i, k := range l.SSHKeys {
+		if k.User == sshKey.User {
+			l.SSHKeys[i] = sshKey
+			return
+		}
+	}
+	l.SSHKeys = append(l.SSHKeys, sshKey)
+}
+
+// DeleteSSHKey removes the ssh key of the user and returns true if it existed
+func (l *RemoteCache) DeleteSSHKey(user string) bool {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	found := false
+	newArr := []SSHKeyCache{}
+	for _, k := range l.SSHKeys {
+		if k.User == user {
+			found = true
+			continue
+		}
+		newArr = append(newArr, k)
+	}
+	l.SSHKeys = newArr
+	return found
+}
+
+func (l *RemoteCache) ListSleepingWorkloads() []SleepingWorkloadCache {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	retArr := []SleepingWorkloadCache{}
+	retArr = append(retArr, l.SleepingWorkloads...)
+	return retArr
+}
+
+// SetSleepingWorkload adds the workload or replaces the workload with the same kind, namespace and name
+func (l *RemoteCache) SetSleepingWorkload(workload SleepingWorkloadCache) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	for i, w := range l.SleepingWorkloads {
+		if w.Kind == workload.Kind && w.Namespace == workload.Namespace && w.Name == workload.Name {
+			l.SleepingWorkloads[i] = workload
+			return
+		}
+	}
+	l.SleepingWorkloads = append(l.SleepingWorkloads, workload)
+}
+
+func (l *RemoteCache) DeleteSleepingWorkload(kind, namespace, name string) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	newArr := []SleepingWorkloadCache{}
+	for _, w := range l.SleepingWorkloads {
+		if w.Kind == kind && w.Namespace == namespace && w.Name == name {
+			continue
+		}
+		newArr = append(newArr, w)
+	}
+	l.SleepingWorkloads = newArr
+}
+
 func (l *RemoteCache) GetData(key string) (string, bool) {
 	l.accessMutex.Lock()
 	defer l.accessMutex.Unlock()
@@ -272,6 +615,7 @@ func (l *RemoteCache) Save(ctx context.Context, client kubectl.Client) error {
 	l.accessMutex.Lock()
 	defer l.accessMutex.Unlock()
 
+	l.trimHistory()
 	data, err := yaml.Marshal(l)
 	if err != nil {
 		return err
@@ -323,7 +667,7 @@ func (l *RemoteCache) Save(ctx context.Context, client kubectl.Client) error {
 			}
 
 			// don't do anything if its empty
-			if len(l.Vars) == 0 && len(l.Data) == 0 && len(l.DevPods) == 0 && len(l.Deployments) == 0 {
+			if len(l.Vars) == 0 && len(l.Data) == 0 && len(l.DevPods) == 0 && len(l.Deployments) == 0 && len(l.ImageBuilds) == 0 && len(l.SSHKeys) == 0 && len(l.SleepingWorkloads) == 0 {
 				l.raw = data
 				return true, nil
 			}
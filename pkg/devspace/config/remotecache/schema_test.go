@@ -0,0 +1,101 @@
+package remotecache
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/assert"
+)
+
+func newTestRecord(t *testing.T, deployTime time.Time, size int) DeploymentRecord {
+	// random manifests don't compress, so the stored size is close to the limit
+	manifests := make([]byte, size)
+	_, err := rand.Read(manifests)
+	assert.NilError(t, err)
+
+	record := DeploymentRecord{Time: deployTime}
+	assert.NilError(t, record.SetManifests(string(manifests)))
+	return record
+}
+
+func TestTrimHistory(t *testing.T) {
+	cache := NewCache("test", "test")
+	start := time.Now()
+	for i, name := range []string{"api", "web"} {
+		deployment := DeploymentCache{Name: name}
+		for j := 0; j < MaxDeploymentHistory; j++ {
+			deployment.AddHistory(newTestRecord(t, start.Add(time.Duration(j*2+i)*time.Minute), 48*1024))
+		}
+		cache.SetDeployment(name, deployment)
+	}
+
+	cache.trimHistory()
+
+	size := 0
+	for _, deployment := range cache.Deployments {
+		assert.Equal(t, deployment.History[0].Revision, MaxDeploymentHistory)
+		for _, record := range deployment.History {
+			size += len(record.Manifests)
+		}
+	}
+	assert.Assert(t, size <= MaxHistoryManifestsSize)
+
+	// the oldest records of both deployments were removed
+	api, _ := cache.GetDeployment("api")
+	web, _ := cache.GetDeployment("web")
+	assert.Assert(t, len(api.History) < MaxDeploymentHistory)
+	assert.Assert(t, len(web.History) < MaxDeploymentHistory)
+	assert.Assert(t, len(api.History)-len(web.History) <= 1)
+	_, ok := api.GetHistory(1)
+	assert.Assert(t, !ok)
+
+	data, err := yaml.Marshal(cache)
+	assert.NilError(t, err)
+	assert.Assert(t, len(data) < 1024*1024)
+}
+
+func TestTrimHistoryKeepsNewestRecord(t *testing.T) {
+	cache := NewCache("test", "test")
+	deployment := DeploymentCache{Name: "api"}
+	deployment.AddHistory(newTestRecord(t, time.Now().Add(-time.Minute), 1024))
+	deployment.AddHistory(newTestRecord(t, time.Now(), MaxHistoryManifestsSize))
+	cache.SetDeployment("api", deployment)
+
+	cache.trimHistory()
+
+	deployment, _ = cache.GetDeployment("api")
+	assert.Equal(t, len(deployment.History), 1)
+	assert.Equal(t, deployment.History[0].Revision, 2)
+	assert.Equal(t, deployment.History[0].Manifests, "")
+}
+
+func TestSetImageBuild(t *testing.T) {
+	cache := NewCache("test", "test")
+	cache.SetImageBuild(ImageBuildCache{ImageName: "db", Digest: "db", Tag: "db"})
+	for i := 0; i < MaxImageBuilds+2; i++ {
+		cache.SetImageBuild(ImageBuildCache{ImageName: "app", Digest: fmt.Sprintf("digest-%d", i), Tag: fmt.Sprintf("tag-%d", i)})
+	}
+
+	// only the newest builds of each image are kept
+	assert.Equal(t, len(cache.ImageBuilds), MaxImageBuilds+1)
+	_, ok := cache.GetImageBuild("app", "digest-1")
+	assert.Assert(t, !ok)
+	imageBuild, ok := cache.GetImageBuild("app", "digest-2")
+	assert.Assert(t, ok)
+	assert.Equal(t, imageBuild.Tag, "tag-2")
+	_, ok = cache.GetImageBuild("db", "db")
+	assert.Assert(t, ok)
+
+	// building the same digest again replaces the old tag and makes it the newest build
+	cache.SetImageBuild(ImageBuildCache{ImageName: "app", Digest: "digest-2", Tag: "rebuilt"})
+	cache.SetImageBuild(ImageBuildCache{ImageName: "app", Digest: "digest-12", Tag: "tag-12"})
+	assert.Equal(t, len(cache.ImageBuilds), MaxImageBuilds+1)
+	imageBuild, ok = cache.GetImageBuild("app", "digest-2")
+	assert.Assert(t, ok)
+	assert.Equal(t, imageBuild.Tag, "rebuilt")
+	_, ok = cache.GetImageBuild("app", "digest-3")
+	assert.Assert(t, !ok)
+}
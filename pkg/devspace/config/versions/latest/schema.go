@@ -141,7 +141,16 @@ type Pipeline struct {
 	Name string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"enum=dev,enum=deploy,enum=build,enum=purge,enum=.*"`
 
 	// Run is the actual shell command that should be executed during this pipeline
-	Run string `yaml:"run,omitempty" json:"run,omitempty" jsonschema:"required"`
+	Run string `yaml:"run,omitempty" json:"run,omitempty"`
+
+	// Steps are named shell commands that are executed after run. Steps are run in parallel
+	// unless they depend on other steps via needs. If a step fails, all steps that need it
+	// are cancelled.
+	Steps []PipelineStep `yaml:"steps,omitempty" json:"steps,omitempty"`
+
+	// Finally is a shell command that is always executed after run and steps have finished,
+	// even if they have failed or the pipeline was interrupted. It can be used to clean up.
+	Finally string `yaml:"finally,omitempty" json:"finally,omitempty"`
 
 	// Flags are extra flags that can be used for running the pipeline via
 	// devspace run-pipeline.
@@ -152,6 +161,18 @@ type Pipeline struct {
 	ContinueOnError bool `yaml:"continueOnError,omitempty" json:"continueOnError,omitempty"`
 }
 
+// PipelineStep is a named step of a pipeline
+type PipelineStep struct {
+	// Name of the step
+	Name string `yaml:"name" json:"name" jsonschema:"required"`
+
+	// Run is the shell command that should be executed during this step
+	Run string `yaml:"run" json:"run" jsonschema:"required"`
+
+	// Needs are the names of the steps that need to succeed before this step is started
+	Needs []string `yaml:"needs,omitempty" json:"needs,omitempty"`
+}
+
 // PipelineFlag defines an extra pipeline flag
 type PipelineFlag struct {
 	// Name is the name of the flag
@@ -652,6 +673,29 @@ type DeploymentConfig struct {
 
 	// Namespace where to deploy this deployment
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// PostRender defines changes that are applied to the rendered manifests of this deployment
+	// before they are deployed. For helm deployments these are applied as helm post renderer.
+	PostRender *PostRenderConfig `yaml:"postRender,omitempty" json:"postRender,omitempty"`
+}
+
+// PostRenderConfig defines how the rendered manifests of a deployment are changed before they are deployed.
+// Patches are applied first, then the kustomize overlay and then the command.
+type PostRenderConfig struct {
+	// Patches are changes that are applied in order to the rendered manifests
+	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty"`
+
+	// Kustomize is the path to a kustomize overlay directory. The rendered manifests are
+	// added as resource to the kustomization before it is built.
+	Kustomize string `yaml:"kustomize,omitempty" json:"kustomize,omitempty"`
+
+	// Command is an external post renderer that receives the rendered manifests on stdin and
+	// prints the changed manifests to stdout, the same way helm post renderers work. If args is
+	// omitted, command is parsed as a shell command.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are optional args that will be used for the command
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -894,8 +938,30 @@ type KubectlConfig struct {
 
 	// Patches are additional changes to the pod spec that should be applied
 	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty" jsonschema_extras:"group=modifications"`
+
+	// ServerSideApply applies the manifests with server-side apply from within DevSpace instead of
+	// running `kubectl apply`. On redeploy, the live objects are compared with the last applied
+	// manifests to detect changes that were made in the cluster.
+	ServerSideApply bool `yaml:"serverSideApply,omitempty" json:"serverSideApply,omitempty" jsonschema_extras:"group=serverSideApply,group_name=Server-Side Apply"`
+	// FieldManager is the field manager that is used for server-side apply. Defaults to devspace
+	FieldManager string `yaml:"fieldManager,omitempty" json:"fieldManager,omitempty" jsonschema_extras:"group=serverSideApply"`
+	// OnDrift defines what to do if the live objects were changed since the last deploy. Can be either
+	// overwrite, which applies the manifests anyways, or abort. Defaults to overwrite
+	OnDrift DriftPolicy `yaml:"onDrift,omitempty" json:"onDrift,omitempty" jsonschema:"enum=overwrite,enum=abort" jsonschema_extras:"group=serverSideApply"`
+	// Prune deletes objects that were deployed previously but are not part of the manifests anymore.
+	// Defaults to true if serverSideApply is enabled
+	Prune *bool `yaml:"prune,omitempty" json:"prune,omitempty" jsonschema_extras:"group=serverSideApply"`
 }
 
+// DriftPolicy is the policy what to do if deployed objects were changed in the cluster
+type DriftPolicy string
+
+// List of values that onDrift can take
+const (
+	DriftPolicyOverwrite DriftPolicy = "overwrite"
+	DriftPolicyAbort     DriftPolicy = "abort"
+)
+
 // DevPod holds configurations for selecting a pod and starting dev services for that pod
 type DevPod struct {
 	// Name of the dev configuration
@@ -906,6 +972,9 @@ type DevPod struct {
 	LabelSelector map[string]string `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty" jsonschema_extras:"group=selector"`
 	// Namespace where to select the pod
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" jsonschema_extras:"group=selector"`
+	// Target references a workload of any kind that embeds a pod template, such as an Argo Rollout,
+	// that should be replaced instead of searching deployments, statefulsets etc. for the selector
+	Target *DevPodTarget `yaml:"target,omitempty" json:"target,omitempty" jsonschema_extras:"group=selector"`
 
 	// DevContainer can either be defined inline if the pod only has a single container or
 	// containers can be used to define configurations for multiple containers in the same
@@ -928,6 +997,24 @@ type DevPod struct {
 	Containers map[string]*DevContainer `yaml:"containers,omitempty" json:"containers,omitempty" jsonschema_extras:"group=selector"`
 }
 
+// DevPodTarget references a custom workload that should be replaced by the dev pod
+type DevPodTarget struct {
+	// APIVersion is the api version of the workload, e.g. argoproj.io/v1alpha1
+	APIVersion string `yaml:"apiVersion" json:"apiVersion"`
+	// Kind is the kind of the workload, e.g. Rollout
+	Kind string `yaml:"kind" json:"kind"`
+	// Name is the name of the workload
+	Name string `yaml:"name" json:"name"`
+
+	// PodTemplatePath is the JSONPath to the pod template within the workload. Defaults to .spec.template.
+	// Ignored for apps/v1 and batch/v1 workloads
+	PodTemplatePath string `yaml:"podTemplatePath,omitempty" json:"podTemplatePath,omitempty"`
+	// ReplicasPath is the JSONPath to the replicas field within the workload, which is used to scale
+	// the workload down while the dev pod is running. Defaults to .spec.replicas. Ignored for apps/v1
+	// and batch/v1 workloads
+	ReplicasPath string `yaml:"replicasPath,omitempty" json:"replicasPath,omitempty"`
+}
+
 // DevContainer holds options for dev services that should
 // get started within a certain container of the selected pod
 type DevContainer struct {
@@ -1002,6 +1089,28 @@ type ProxyCommand struct {
 
 	// Env are extra environment variables to set for the command
 	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Policy restricts how the command can be invoked from within the container
+	Policy *ProxyCommandPolicy `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// ProxyCommandPolicy restricts the arguments a proxy command can be invoked with
+type ProxyCommandPolicy struct {
+	// AllowedArgs are regular expressions that are matched against the space separated arguments
+	// of the command. Arguments that are empty or contain whitespace, quotes or backslashes are
+	// single quoted like in a shell, e.g. 'a b'. If specified, one of them has to match all
+	// arguments, otherwise the command is denied. For example `(status|diff|log)( .*)?` only
+	// allows read only git commands.
+	AllowedArgs []string `yaml:"allowedArgs,omitempty" json:"allowedArgs,omitempty"`
+
+	// DeniedFlags are flags the command is not allowed to be invoked with, such as `--exec`. A flag
+	// is also denied if it is specified with a value, such as `--exec=value`, if a long flag is
+	// abbreviated, such as `--ex`, and if a short flag is combined with other short flags or its
+	// value, such as `-vc` or `-cvalue`.
+	DeniedFlags []string `yaml:"deniedFlags,omitempty" json:"deniedFlags,omitempty"`
+
+	// Confirm asks for confirmation the first time the command is invoked from within the container
+	Confirm bool `yaml:"confirm,omitempty" json:"confirm,omitempty"`
 }
 
 type SSH struct {
@@ -1102,8 +1211,22 @@ type PortMapping struct {
 	// BindAddress is the address DevSpace should listen on. Optional and defaults
 	// to localhost.
 	BindAddress string `yaml:"bindAddress,omitempty" json:"bindAddress,omitempty"`
+
+	// Protocol is the protocol of the port, either tcp or udp. Defaults to tcp.
+	// UDP datagrams are tunneled through the DevSpace helper, which will be
+	// injected into the container.
+	Protocol PortProtocol `yaml:"protocol,omitempty" json:"protocol,omitempty" jsonschema:"enum=tcp,enum=udp"`
 }
 
+// PortProtocol is the protocol of a port mapping
+type PortProtocol string
+
+// List of values that protocol can take
+const (
+	PortProtocolTCP PortProtocol = "tcp"
+	PortProtocolUDP PortProtocol = "udp"
+)
+
 // OpenConfig defines what to open after services have been started
 type OpenConfig struct {
 	// URL is the url to open in the browser after it is available
@@ -1147,6 +1270,16 @@ type SyncConfig struct {
 	// InitialSyncCompareBy defines if the sync should only compare by the given type. Either mtime or size are possible
 	InitialSyncCompareBy InitialSyncCompareBy `yaml:"initialSyncCompareBy,omitempty" json:"initialSyncCompareBy,omitempty" jsonschema_extras:"group=initial_sync"`
 
+	// ConflictStrategy defines what happens if a file was changed locally and in the container since
+	// it was last synced. newest keeps the version that was changed last, local and remote always keep
+	// the local or container version and keepBoth keeps the newest version and stores the other one
+	// next to it as file.conflict-<side>-<timestamp>. Defaults to newest
+	ConflictStrategy SyncConflictStrategy `yaml:"conflictStrategy,omitempty" json:"conflictStrategy,omitempty" jsonschema:"enum=newest,enum=local,enum=remote,enum=keepBoth"`
+
+	// Compression defines the codec used to compress the files transferred by the sync. Defaults to gzip,
+	// auto chooses the fastest codec the sync helper in the container supports.
+	Compression SyncCompression `yaml:"compression,omitempty" json:"compression,omitempty" jsonschema:"enum=gzip,enum=auto,enum=zstd,enum=lz4,enum=none"`
+
 	// DisableDownload will disable downloading completely
 	DisableDownload bool `yaml:"disableDownload,omitempty" json:"disableDownload,omitempty" jsonschema_extras:"group=one_direction,group_name=One-Directional Sync"`
 	// DisableUpload will disable uploading completely
@@ -1269,6 +1402,29 @@ const (
 	InitialSyncCompareBySize  InitialSyncCompareBy = "size"
 )
 
+// SyncConflictStrategy is the type of how a file that was changed on both sides is resolved
+type SyncConflictStrategy string
+
+// List of values that conflict strategy can take
+const (
+	SyncConflictStrategyNewest   SyncConflictStrategy = "newest"
+	SyncConflictStrategyLocal    SyncConflictStrategy = "local"
+	SyncConflictStrategyRemote   SyncConflictStrategy = "remote"
+	SyncConflictStrategyKeepBoth SyncConflictStrategy = "keepBoth"
+)
+
+// SyncCompression is the type of codec used to compress the sync tar stream
+type SyncCompression string
+
+// List of values that compression can take
+const (
+	SyncCompressionAuto SyncCompression = "auto"
+	SyncCompressionGzip SyncCompression = "gzip"
+	SyncCompressionZstd SyncCompression = "zstd"
+	SyncCompressionLZ4  SyncCompression = "lz4"
+	SyncCompressionNone SyncCompression = "none"
+)
+
 // BandwidthLimits defines the struct for specifying the sync bandwidth limits
 type BandwidthLimits struct {
 	// Download is the download limit in kilo bytes per second
@@ -1396,6 +1552,16 @@ type HookConfig struct {
 	// All supported golang OS types are supported and multiple can be combined with ','.
 	OperatingSystem string `yaml:"os,omitempty" json:"os,omitempty"`
 
+	// If is an expression that is evaluated right before the hook would be executed. The hook
+	// is only executed if the expression succeeds or prints true, e.g. [ "${DEVSPACE_NAMESPACE}" = "prod" ].
+	// Runtime variables, such as the output of previous hooks, can be used within the expression.
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
+
+	// If output is true, the standard output of the hook is not printed, but captured into the
+	// runtime variable ${runtime.hooks.NAME.output}, which can be used by later hooks, deployments
+	// and pipelines. Requires name and command.
+	Output bool `yaml:"output,omitempty" json:"output,omitempty"`
+
 	// If Upload is specified, DevSpace will upload certain local files or folders into a
 	// remote container.
 	Upload *HookSyncConfig `yaml:"upload,omitempty" json:"upload,omitempty"`
@@ -1574,11 +1740,23 @@ type Variable struct {
 	// system.
 	Commands []VariableCommand `yaml:"commands,omitempty" json:"commands,omitempty" jsonschema_extras:"group=execution"`
 
+	// File is the path to a sops or age encrypted yaml or json file the variable value should be read from.
+	// The path is relative to the devspace.yaml.
+	File string `yaml:"file,omitempty" json:"file,omitempty" jsonschema_extras:"group=secret,group_name=Value From Encrypted File"`
+
+	// Path is the dot separated path of the value within the decrypted file, e.g. database.password.
+	// Defaults to the name of the variable.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" jsonschema_extras:"group=secret"`
+
+	// IdentityFile is the path to the age identity file that is used to decrypt the file. Defaults to
+	// the SOPS_AGE_KEY or SOPS_AGE_KEY_FILE environment variables or the sops default location.
+	IdentityFile string `yaml:"identityFile,omitempty" json:"identityFile,omitempty" jsonschema_extras:"group=secret"`
+
 	// AlwaysResolve makes sure this variable will always be resolved and not only if it is used somewhere. Defaults to false.
 	AlwaysResolve *bool `yaml:"alwaysResolve,omitempty" json:"alwaysResolve,omitempty"`
 
 	// Source defines where the variable should be taken from
-	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=none"`
+	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=secret,enum=none"`
 }
 
 func (v *Variable) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -1632,6 +1810,7 @@ const (
 	VariableSourceEnv     VariableSource = "env"
 	VariableSourceInput   VariableSource = "input"
 	VariableSourceCommand VariableSource = "command"
+	VariableSourceSecret  VariableSource = "secret"
 	VariableSourceNone    VariableSource = "none"
 )
 
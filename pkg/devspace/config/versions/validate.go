@@ -3,6 +3,7 @@ package versions
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"unicode"
 
@@ -28,6 +29,39 @@ func ValidInitialSyncStrategy(strategy latest.InitialSyncStrategy) bool {
 		strategy == latest.InitialSyncStrategyPreferNewest
 }
 
+// ValidSyncConflictStrategy checks if the sync conflict strategy is valid
+func ValidSyncConflictStrategy(strategy latest.SyncConflictStrategy) bool {
+	return strategy == "" ||
+		strategy == latest.SyncConflictStrategyNewest ||
+		strategy == latest.SyncConflictStrategyLocal ||
+		strategy == latest.SyncConflictStrategyRemote ||
+		strategy == latest.SyncConflictStrategyKeepBoth
+}
+
+// ValidDriftPolicy checks if the kubectl drift policy is valid
+func ValidDriftPolicy(policy latest.DriftPolicy) bool {
+	return policy == "" ||
+		policy == latest.DriftPolicyOverwrite ||
+		policy == latest.DriftPolicyAbort
+}
+
+// ValidSyncCompression checks if the sync compression is valid
+func ValidSyncCompression(compression latest.SyncCompression) bool {
+	return compression == "" ||
+		compression == latest.SyncCompressionAuto ||
+		compression == latest.SyncCompressionGzip ||
+		compression == latest.SyncCompressionZstd ||
+		compression == latest.SyncCompressionLZ4 ||
+		compression == latest.SyncCompressionNone
+}
+
+// ValidPortProtocol checks if the port protocol is valid
+func ValidPortProtocol(protocol latest.PortProtocol) bool {
+	return protocol == "" ||
+		protocol == latest.PortProtocolTCP ||
+		protocol == latest.PortProtocolUDP
+}
+
 // ValidContainerArch checks if the target container arch is valid
 func ValidContainerArch(arch latest.ContainerArchitecture) bool {
 	return arch == "" ||
@@ -157,10 +191,72 @@ func validateRequire(config *latest.Config) error {
 }
 
 func validatePipelines(config *latest.Config) error {
-	for name := range config.Pipelines {
+	for name, pipeline := range config.Pipelines {
 		if encoding.IsUnsafeName(name) {
 			return fmt.Errorf("pipelines.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String())
 		}
+		if pipeline == nil {
+			continue
+		}
+
+		err := validatePipelineSteps(name, pipeline.Steps)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePipelineSteps(name string, steps []latest.PipelineStep) error {
+	needs := map[string][]string{}
+	for index, step := range steps {
+		if step.Name == "" {
+			return errors.Errorf("pipelines.%s.steps[%d].name is required", name, index)
+		} else if step.Run == "" {
+			return errors.Errorf("pipelines.%s.steps[%d].run is required", name, index)
+		} else if _, ok := needs[step.Name]; ok {
+			return errors.Errorf("pipelines.%s.steps[%d]: step %s is defined multiple times", name, index, step.Name)
+		}
+
+		needs[step.Name] = step.Needs
+	}
+
+	for index, step := range steps {
+		for _, need := range step.Needs {
+			if _, ok := needs[need]; !ok {
+				return errors.Errorf("pipelines.%s.steps[%d].needs: step %s doesn't exist", name, index, need)
+			} else if need == step.Name {
+				return errors.Errorf("pipelines.%s.steps[%d].needs: step %s cannot need itself", name, index, need)
+			}
+		}
+	}
+
+	// check for cycles
+	visited := map[string]int{}
+	var visit func(step string, path []string) error
+	visit = func(step string, path []string) error {
+		if visited[step] == 2 {
+			return nil
+		} else if visited[step] == 1 {
+			return errors.Errorf("pipelines.%s.steps: cyclic needs %s", name, strings.Join(append(path, step), " -> "))
+		}
+
+		visited[step] = 1
+		for _, need := range needs[step] {
+			err := visit(need, append(path, step))
+			if err != nil {
+				return err
+			}
+		}
+		visited[step] = 2
+		return nil
+	}
+	for _, step := range steps {
+		err := visit(step.Name, nil)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -222,6 +318,9 @@ func validateHooks(config *latest.Config) error {
 		if enabled > 1 {
 			return errors.Errorf("you can only use one of hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].upload and hooks[%d].download per hook", index, index, index, index, index)
 		}
+		if hookConfig.Output && (hookConfig.Name == "" || hookConfig.Command == "") {
+			return errors.Errorf("hooks[%d].name and hooks[%d].command are required if hooks[%d].output is used", index, index, index)
+		}
 		if hookConfig.Upload != nil && hookConfig.Container == nil {
 			return errors.Errorf("hooks[%d].container is required if hooks[%d].upload is used", index, index)
 		}
@@ -267,21 +366,22 @@ func validateDeployments(config *latest.Config) error {
 		if deployConfig.Kubectl != nil && deployConfig.Helm != nil {
 			return errors.Errorf("deployments[%s].kubectl and deployments[%s].helm cannot be used together", index, index)
 		}
+		if deployConfig.Kubectl != nil && !ValidDriftPolicy(deployConfig.Kubectl.OnDrift) {
+			return errors.Errorf("deployments[%s].kubectl.onDrift is not valid '%s'", index, deployConfig.Kubectl.OnDrift)
+		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Patches != nil {
-			for patch := range deployConfig.Kubectl.Patches {
-				if deployConfig.Kubectl.Patches[patch].Target.Name == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].target.name is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Operation == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].op is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Path == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].path is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Operation != "remove" &&
-					deployConfig.Kubectl.Patches[patch].Value == nil {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].value is required", index, patch)
-				}
+			err := validatePatchTargets(deployConfig.Kubectl.Patches, fmt.Sprintf("deployments[%s].kubectl.patches", index))
+			if err != nil {
+				return err
+			}
+		}
+		if deployConfig.PostRender != nil {
+			err := validatePatchTargets(deployConfig.PostRender.Patches, fmt.Sprintf("deployments[%s].postRender.patches", index))
+			if err != nil {
+				return err
+			}
+			if deployConfig.PostRender.Command == "" && len(deployConfig.PostRender.Args) > 0 {
+				return errors.Errorf("deployments[%s].postRender.args are defined but deployments[%s].postRender.command is not defined", index, index)
 			}
 		}
 	}
@@ -289,6 +389,26 @@ func validateDeployments(config *latest.Config) error {
 	return nil
 }
 
+func validatePatchTargets(patches []*latest.PatchTarget, path string) error {
+	for patch := range patches {
+		if patches[patch].Target.Name == "" {
+			return errors.Errorf("%s[%d].target.name is required", path, patch)
+		}
+		if patches[patch].Operation == "" {
+			return errors.Errorf("%s[%d].op is required", path, patch)
+		}
+		if patches[patch].Path == "" {
+			return errors.Errorf("%s[%d].path is required", path, patch)
+		}
+		if patches[patch].Operation != "remove" &&
+			patches[patch].Value == nil {
+			return errors.Errorf("%s[%d].value is required", path, patch)
+		}
+	}
+
+	return nil
+}
+
 func ValidateComponentConfig(deployConfig *latest.DeploymentConfig, overwriteValues map[string]interface{}) error {
 	if deployConfig.Helm != nil && deployConfig.Helm.Chart == nil {
 		b, err := yaml.Marshal(overwriteValues)
@@ -383,6 +503,17 @@ func validateDev(config *latest.Config) error {
 		if definedSelectors > 1 {
 			return errors.Errorf("dev.%s: image selector and label selector cannot be used together", devPodName)
 		}
+		if devPod.Target != nil && (devPod.Target.APIVersion == "" || devPod.Target.Kind == "" || devPod.Target.Name == "") {
+			return errors.Errorf("dev.%s.target: apiVersion, kind and name are required", devPodName)
+		}
+
+		for index, port := range devPod.Ports {
+			if port.Port == "" {
+				return errors.Errorf("dev.%s.ports[%d].port is required", devPodName, index)
+			} else if !ValidPortProtocol(port.Protocol) {
+				return errors.Errorf("dev.%s.ports[%d].protocol is invalid: %s, must be tcp or udp", devPodName, index, port.Protocol)
+			}
+		}
 
 		err := validateDevContainer(fmt.Sprintf("dev.%s", devPodName), &devPod.DevContainer, devPod, false)
 		if err != nil {
@@ -421,6 +552,12 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 		if !ValidInitialSyncStrategy(sync.InitialSync) {
 			return errors.Errorf("%s.sync[%d].initialSync is not valid '%s'", path, index, sync.InitialSync)
 		}
+		if !ValidSyncCompression(sync.Compression) {
+			return errors.Errorf("%s.sync[%d].compression is not valid '%s'", path, index, sync.Compression)
+		}
+		if !ValidSyncConflictStrategy(sync.ConflictStrategy) {
+			return errors.Errorf("%s.sync[%d].conflictStrategy is not valid '%s'", path, index, sync.ConflictStrategy)
+		}
 		if sync.OnUpload != nil {
 			for j, e := range sync.OnUpload.Exec {
 				if e.Command == "" {
@@ -447,6 +584,8 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 	for index, port := range devContainer.ReversePorts {
 		if port.Port == "" {
 			return errors.Errorf("%s.reversePorts[%d].port is required", path, index)
+		} else if !ValidPortProtocol(port.Protocol) {
+			return errors.Errorf("%s.reversePorts[%d].protocol is invalid: %s, must be tcp or udp", path, index, port.Protocol)
 		}
 	}
 	for j, p := range devContainer.PersistPaths {
@@ -454,6 +593,23 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 			return errors.Errorf("%s.persistPaths[%d].path is required", path, j)
 		}
 	}
+	for j, proxyCommand := range devContainer.ProxyCommands {
+		if proxyCommand.Policy == nil {
+			continue
+		}
+
+		for k, allowedArgs := range proxyCommand.Policy.AllowedArgs {
+			_, err := regexp.Compile(allowedArgs)
+			if err != nil {
+				return errors.Errorf("%s.proxyCommands[%d].policy.allowedArgs[%d] is not a valid regular expression: %v", path, j, k, err)
+			}
+		}
+		for k, deniedFlag := range proxyCommand.Policy.DeniedFlags {
+			if !strings.HasPrefix(deniedFlag, "-") {
+				return errors.Errorf("%s.proxyCommands[%d].policy.deniedFlags[%d] must start with a '-'", path, j, k)
+			}
+		}
+	}
 
 	return nil
 }
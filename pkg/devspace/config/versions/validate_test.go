@@ -67,6 +67,21 @@ func TestValidateHooks(t *testing.T) {
 
 	err = validateHooks(config)
 	assert.Error(t, err, "hooks[0].container.containerName is defined but hooks[0].container.labelSelector is not defined")
+
+	config = &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events: []string{
+					"before:deploy",
+				},
+				Command: "git rev-parse HEAD",
+				Output:  true,
+			},
+		},
+	}
+
+	err = validateHooks(config)
+	assert.Error(t, err, "hooks[0].name and hooks[0].command are required if hooks[0].output is used")
 }
 
 func TestValidateDev(t *testing.T) {
@@ -173,3 +188,27 @@ func TestValidateDev(t *testing.T) {
 	err = validateDev(config)
 	assert.Error(t, err, "dev.somename.reversePorts will be overwritten by dev.somename.containers[test], please specify dev.somename.containers[test].reversePorts instead")
 }
+
+func TestValidatePipelineSteps(t *testing.T) {
+	steps := []latest.PipelineStep{
+		{Name: "build", Run: "build_images --all"},
+		{Name: "lint", Run: "echo lint"},
+		{Name: "deploy", Run: "create_deployments --all", Needs: []string{"build", "lint"}},
+	}
+	err := validatePipelineSteps("dev", steps)
+	assert.NilError(t, err)
+
+	steps = []latest.PipelineStep{
+		{Name: "build", Run: "build_images --all", Needs: []string{"missing"}},
+	}
+	err = validatePipelineSteps("dev", steps)
+	assert.Error(t, err, "pipelines.dev.steps[0].needs: step missing doesn't exist")
+
+	steps = []latest.PipelineStep{
+		{Name: "a", Run: "echo a", Needs: []string{"c"}},
+		{Name: "b", Run: "echo b", Needs: []string{"a"}},
+		{Name: "c", Run: "echo c", Needs: []string{"b"}},
+	}
+	err = validatePipelineSteps("dev", steps)
+	assert.Error(t, err, "pipelines.dev.steps: cyclic needs a -> c -> b -> a")
+}
@@ -18,6 +18,8 @@ const (
 	devContextKey
 	flagsKey
 	commandFlagsKey
+	pipelineKey
+	dependencyNameKey
 )
 
 // WithFlagsMap creates a new context with the given flags
@@ -106,6 +108,28 @@ func IsDependencyFrom(ctx context.Context) (bool, bool) {
 	return isDependency, ok
 }
 
+// WithPipeline returns a copy of parent in which the name of the running pipeline is set
+func WithPipeline(parent context.Context, pipeline string) context.Context {
+	return WithValue(parent, pipelineKey, pipeline)
+}
+
+// PipelineFrom returns the name of the running pipeline
+func PipelineFrom(ctx context.Context) (string, bool) {
+	pipeline, ok := ctx.Value(pipelineKey).(string)
+	return pipeline, ok
+}
+
+// WithDependencyName returns a copy of parent in which the name of the running dependency is set
+func WithDependencyName(parent context.Context, name string) context.Context {
+	return WithValue(parent, dependencyNameKey, name)
+}
+
+// DependencyNameFrom returns the name of the running dependency
+func DependencyNameFrom(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(dependencyNameKey).(string)
+	return name, ok
+}
+
 func mergeFlags(maps ...map[string]string) map[string]string {
 	merged := map[string]string{}
 	for _, m := range maps {
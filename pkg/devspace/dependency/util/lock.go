@@ -0,0 +1,235 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const lockHeader = "# This file is generated by devspace. Run 'devspace update dependencies --lock' to update it.\n"
+
+type locksKey struct{}
+
+// Locks are the lock files that are used while downloading dependencies, imports and charts.
+// Pass them to the download with WithLocks.
+type Locks struct {
+	// update ignores the locked versions of git and remote sources and records the
+	// currently resolved versions in the lock files instead
+	update bool
+
+	locks map[string]*Lock
+}
+
+// NewLocks creates new locks. If update is true, the lock files are rewritten with the
+// resolved versions on Save.
+func NewLocks(update bool) *Locks {
+	return &Locks{
+		update: update,
+		locks:  map[string]*Lock{},
+	}
+}
+
+// WithLocks returns a copy of parent in which the locks are used to download sources
+func WithLocks(parent context.Context, locks *Locks) context.Context {
+	return context.WithValue(parent, locksKey{}, locks)
+}
+
+// locksFrom returns the locks of the context or new locks that only verify sources
+func locksFrom(ctx context.Context) *Locks {
+	locks, ok := ctx.Value(locksKey{}).(*Locks)
+	if !ok {
+		return NewLocks(false)
+	}
+
+	return locks
+}
+
+// Lock is the lock file that pins the git and remote sources referenced by a devspace.yaml
+type Lock struct {
+	Sources map[string]*LockedSource `yaml:"sources,omitempty"`
+
+	path    string
+	update  bool
+	changed bool
+
+	// readOnly is true for lock files of downloaded dependencies, which are only verified
+	readOnly bool
+}
+
+// LockedSource is the resolved version of a single source
+type LockedSource struct {
+	// Commit is the resolved commit sha of a git source
+	Commit string `yaml:"commit,omitempty"`
+
+	// Hash is the sha256 hash of a remote file
+	Hash string `yaml:"hash,omitempty"`
+}
+
+// get returns the lock file in the given directory
+func (l *Locks) get(workingDirectory string) (*Lock, error) {
+	path, err := filepath.Abs(filepath.Join(workingDirectory, constants.DefaultLockPath))
+	if err != nil {
+		return nil, err
+	}
+	if lock, ok := l.locks[path]; ok {
+		return lock, nil
+	}
+
+	lock := &Lock{
+		Sources:  map[string]*LockedSource{},
+		path:     path,
+		readOnly: strings.HasPrefix(path, DependencyFolderPath+string(filepath.Separator)),
+	}
+	if l.update && !lock.readOnly {
+		// start with an empty lock, so sources that are not used anymore are removed
+		lock.update = true
+		lock.changed = true
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		} else if err == nil {
+			err = yaml.Unmarshal(data, lock)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse %s", path)
+			}
+			if lock.Sources == nil {
+				lock.Sources = map[string]*LockedSource{}
+			}
+		}
+	}
+
+	l.locks[path] = lock
+	return lock, nil
+}
+
+// Save writes the updated lock files. The lock file of the given directory is removed if no
+// sources were recorded.
+func (l *Locks) Save(workingDirectory string) error {
+	downloadMutex.Lock()
+	defer downloadMutex.Unlock()
+
+	if !l.update {
+		return nil
+	}
+
+	_, err := l.get(workingDirectory)
+	if err != nil {
+		return err
+	}
+
+	for _, lock := range l.locks {
+		err := lock.save()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// locked returns the locked version of the source or nil if the source is not
+// locked or the lock is updated
+func (l *Lock) locked(key string) *LockedSource {
+	if l.update {
+		return nil
+	}
+
+	return l.Sources[key]
+}
+
+// verify checks the resolved source against the lock file. If the lock is updated,
+// the resolved source is recorded instead.
+func (l *Lock) verify(key string, resolved *LockedSource) error {
+	if l.update {
+		l.Sources[key] = resolved
+		return nil
+	}
+
+	locked := l.locked(key)
+	if locked == nil {
+		return nil
+	} else if locked.Commit != "" {
+		if locked.Commit != resolved.Commit {
+			return errors.Errorf("%s is at commit %s, but %s expects commit %s. Run 'devspace update dependencies --lock' to update the lock file", key, resolved.Commit, l.path, locked.Commit)
+		}
+	} else if locked.Hash != resolved.Hash {
+		return errors.Errorf("contents of %s do not match %s (expected %s, got %s). Run 'devspace update dependencies --lock' to update the lock file", key, l.path, locked.Hash, resolved.Hash)
+	}
+
+	return nil
+}
+
+func (l *Lock) save() error {
+	if !l.changed || l.readOnly {
+		return nil
+	}
+	if len(l.Sources) == 0 {
+		err := os.Remove(l.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		l.changed = false
+		return nil
+	}
+
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(l.path, append([]byte(lockHeader), data...), 0666)
+	if err != nil {
+		return errors.Wrapf(err, "write %s", l.path)
+	}
+
+	l.changed = false
+	return nil
+}
+
+// resolveGitSource returns the checked out commit. The commit pins the contents of all
+// tracked files, independent of how they are checked out.
+func resolveGitSource(ctx context.Context, repo *git.GitCLIRepository) (*LockedSource, error) {
+	commit, err := git.GetHash(ctx, repo.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockedSource{
+		Commit: commit,
+	}, nil
+}
+
+// resolveFileSource returns the hash of a downloaded file
+func resolveFileSource(path string) (*LockedSource, error) {
+	hash := sha256.New()
+	err := hashFile(hash, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockedSource{
+		Hash: "sha256:" + hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func hashFile(writer io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(writer, file)
+	return err
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func setupLockTest(t *testing.T) string {
+	dependencyFolderPath := DependencyFolderPath
+	DependencyFolderPath = t.TempDir()
+	t.Cleanup(func() {
+		DependencyFolderPath = dependencyFolderPath
+	})
+
+	return t.TempDir()
+}
+
+// updateLock downloads the source with updated locks and saves the lock file
+func updateLock(t *testing.T, projectDir string, source *latest.SourceConfig) string {
+	locks := NewLocks(true)
+	configPath, err := DownloadDependency(WithLocks(context.Background(), locks), projectDir, source, log.Discard)
+	assert.NilError(t, err)
+	assert.NilError(t, locks.Save(projectDir))
+	return configPath
+}
+
+func TestLockURLSource(t *testing.T) {
+	projectDir := setupLockTest(t)
+
+	content := "version: v2beta1\nname: remote\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	// downloads don't write the lock file
+	source := &latest.SourceConfig{Path: server.URL + "/devspace.yaml"}
+	_, err := DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.NilError(t, err)
+	_, err = os.Stat(filepath.Join(projectDir, constants.DefaultLockPath))
+	assert.Assert(t, os.IsNotExist(err))
+
+	updateLock(t, projectDir, source)
+	lockFile, err := os.ReadFile(filepath.Join(projectDir, constants.DefaultLockPath))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(lockFile), source.Path))
+
+	// the remote file changed, so the download does not match the lock anymore
+	content = "version: v2beta1\nname: changed\n"
+	_, err = DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.ErrorContains(t, err, "devspace update dependencies --lock")
+
+	// the cached file is verified offline as well
+	source.DisablePull = true
+	_, err = DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.ErrorContains(t, err, "do not match")
+
+	// updating the lock records the new hash
+	source.DisablePull = false
+	updateLock(t, projectDir, source)
+	_, err = DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.NilError(t, err)
+}
+
+func TestLockGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	projectDir := setupLockTest(t)
+	repoDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repoDir, "-c", "user.name=test", "-c", "user.email=test@test.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		assert.NilError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(content string) string {
+		assert.NilError(t, os.WriteFile(filepath.Join(repoDir, "devspace.yaml"), []byte(content), 0666))
+		runGit("add", "devspace.yaml")
+		runGit("commit", "-m", content)
+		return runGit("rev-parse", "HEAD")
+	}
+
+	runGit("init", "-b", "main")
+	first := commit("version: v2beta1\nname: first\n")
+
+	source := &latest.SourceConfig{Git: "file://" + repoDir, Branch: "main"}
+	updateLock(t, projectDir, source)
+
+	lock, err := NewLocks(false).get(projectDir)
+	assert.NilError(t, err)
+	assert.Equal(t, lock.Sources[sourceKey(source)].Commit, first)
+
+	// new commits on the branch are not used while the source is locked
+	second := commit("version: v2beta1\nname: second\n")
+	configPath, err := DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.NilError(t, err)
+	out, err := os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: first\n")
+
+	// updating the lock pulls and records the latest commit
+	configPath = updateLock(t, projectDir, source)
+	out, err = os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: second\n")
+
+	lock, err = NewLocks(false).get(projectDir)
+	assert.NilError(t, err)
+	assert.Equal(t, lock.Sources[sourceKey(source)].Commit, second)
+
+	// sources without lock entry are pulled
+	assert.NilError(t, os.Remove(filepath.Join(projectDir, constants.DefaultLockPath)))
+	commit("version: v2beta1\nname: third\n")
+	configPath, err = DownloadDependency(context.Background(), projectDir, source, log.Discard)
+	assert.NilError(t, err)
+	out, err = os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: third\n")
+}
@@ -87,6 +87,14 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 		return "", err
 	}
 
+	lock, err := locksFrom(ctx).get(workingDirectory)
+	if err != nil {
+		return "", err
+	}
+
+	key := sourceKey(source)
+	locked := lock.locked(key)
+
 	var localPath string
 
 	// Resolve git source
@@ -104,6 +112,9 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 		if err != nil {
 			if statErr == nil {
 				log.Warnf("Error creating git cli: %v", err)
+				if locked != nil {
+					log.Warnf("Cannot verify %s against %s without git", gitPath, constants.DefaultLockPath)
+				}
 				return getDependencyConfigPath(localPath, source)
 			}
 			return "", err
@@ -119,6 +130,10 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 			DisableShallow: source.DisableShallow,
 		}
 
+		if locked != nil {
+			gitCloneOptions.Commit = locked.Commit
+		}
+
 		// Git clone
 		if statErr != nil {
 			err = repo.Clone(ctx, gitCloneOptions)
@@ -144,8 +159,13 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 			log.Debugf("Cloned %s", gitPath)
 		}
 
-		// Git pull
-		if !source.DisablePull && source.Revision == "" {
+		// Checkout the locked commit or update the repository
+		if locked != nil {
+			err = checkoutCommit(ctx, repo, locked.Commit, source)
+			if err != nil {
+				return "", errors.Wrapf(err, "checkout locked commit of %s", gitPath)
+			}
+		} else if !source.DisablePull && source.Revision == "" {
 			err = repo.Pull(ctx)
 			if err != nil {
 				log.Warn(err)
@@ -154,6 +174,16 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 			log.Debugf("Pulled %s", gitPath)
 		}
 
+		// Verify the repository against the lock file
+		resolved, err := resolveGitSource(ctx, repo)
+		if err != nil {
+			return "", errors.Wrapf(err, "resolve %s", gitPath)
+		}
+		err = lock.verify(key, resolved)
+		if err != nil {
+			return "", err
+		}
+
 		// Resolve local source
 	} else if source.Path != "" {
 		if isURL(source.Path) {
@@ -199,6 +229,17 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 
 					return "", errors.Wrapf(err, "download %s", source.Path)
 				}
+				_ = out.Close()
+			}
+
+			// Verify the downloaded file against the lock file
+			resolved, err := resolveFileSource(configPath)
+			if err != nil {
+				return "", errors.Wrapf(err, "resolve %s", source.Path)
+			}
+			err = lock.verify(key, resolved)
+			if err != nil {
+				return "", err
 			}
 		} else {
 			if filepath.IsAbs(source.Path) {
@@ -236,6 +277,17 @@ func GetDependencyID(source *latest.SourceConfig) (string, error) {
 	}
 
 	// get id for git
+	if source.Git != "" {
+		return encoding.Convert(sourceKey(source)), nil
+	} else if source.Path != "" {
+		return source.Path, nil
+	}
+
+	return "", fmt.Errorf("unexpected dependency config, both source.git and source.path are missing")
+}
+
+// sourceKey returns the unencoded id of the source, which is used as key in the lock file
+func sourceKey(source *latest.SourceConfig) string {
 	if source.Git != "" {
 		id := source.Git
 		if source.Branch != "" {
@@ -246,12 +298,32 @@ func GetDependencyID(source *latest.SourceConfig) (string, error) {
 			id += "@revision:" + source.Revision
 		}
 
-		return encoding.Convert(id), nil
-	} else if source.Path != "" {
-		return source.Path, nil
+		return id
 	}
 
-	return "", fmt.Errorf("unexpected dependency config, both source.git and source.path are missing")
+	return source.Path
+}
+
+// checkoutCommit checks out the given commit and fetches it if it is not available locally
+func checkoutCommit(ctx context.Context, repo *git.GitCLIRepository, commit string, source *latest.SourceConfig) error {
+	hash, err := git.GetHash(ctx, repo.LocalPath)
+	if err == nil && hash == commit {
+		return nil
+	}
+
+	err = repo.Checkout(ctx, commit)
+	if err == nil {
+		return nil
+	} else if source.DisablePull {
+		return errors.Wrapf(err, "commit %s is not available locally and pulling is disabled", commit)
+	}
+
+	err = repo.Fetch(ctx, commit, !source.DisableShallow)
+	if err != nil {
+		return err
+	}
+
+	return repo.Checkout(ctx, commit)
 }
 
 func isURL(path string) bool {
@@ -0,0 +1,372 @@
+package dependency
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/pkg/errors"
+)
+
+// GraphFormat is the output format of a dependency graph
+type GraphFormat string
+
+const (
+	GraphFormatTree    GraphFormat = "tree"
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatMermaid GraphFormat = "mermaid"
+)
+
+// DefaultDependencyPipeline is the pipeline that is run for a dependency without pipeline
+const DefaultDependencyPipeline = "deploy"
+
+// GraphView is a printable view of the resolved dependency graph
+type GraphView struct {
+	Nodes []*GraphNode
+	Edges []*GraphEdge
+
+	// DuplicateSources holds the names of dependencies that are resolved from the same source
+	DuplicateSources [][]string
+}
+
+// GraphNode is a single dependency in the graph view
+type GraphNode struct {
+	Name      string
+	Source    string
+	Profiles  []string
+	Namespace string
+	Pipeline  string
+
+	// Parents are the names of the dependencies that depend on this dependency
+	Parents []string
+
+	root bool
+	path string
+}
+
+// GraphEdge is an edge from a dependency to one of its dependencies
+type GraphEdge struct {
+	From string
+	To   string
+
+	// Cycle holds the dependency path this edge would close. The resolver ignores
+	// these edges and the dependency is skipped when running the pipelines.
+	Cycle []string
+
+	// Conflict holds the source the parent declared, if the dependency was already
+	// resolved from a different source by another parent
+	Conflict string
+}
+
+// NewGraphView creates a graph view of the current project and its resolved dependencies
+func NewGraphView(ctx devspacecontext.Context, dependencies []types.Dependency) *GraphView {
+	view := &GraphView{}
+	rootNode := &GraphNode{
+		Name: ctx.Config().Config().Name,
+		root: true,
+		path: filepath.Dir(ctx.Config().Path()),
+	}
+	if ctx.KubeClient() != nil {
+		rootNode.Namespace = ctx.KubeClient().Namespace()
+	}
+	view.Nodes = append(view.Nodes, rootNode)
+	view.walk(rootNode, ctx.Config().Config(), dependencies, []string{rootNode.Name})
+
+	// find dependencies with the same source but different names
+	byPath := map[string][]string{}
+	paths := []string{}
+	for _, node := range view.Nodes {
+		if node.root {
+			continue
+		}
+		if _, ok := byPath[node.path]; !ok {
+			paths = append(paths, node.path)
+		}
+		byPath[node.path] = append(byPath[node.path], node.Name)
+	}
+	for _, path := range paths {
+		if len(byPath[path]) > 1 {
+			view.DuplicateSources = append(view.DuplicateSources, byPath[path])
+		}
+	}
+
+	return view
+}
+
+func (g *GraphView) walk(parentNode *GraphNode, parentConfig *latest.Config, children []types.Dependency, path []string) {
+	for _, child := range children {
+		edge := &GraphEdge{From: parentNode.Name, To: child.Name()}
+		g.Edges = append(g.Edges, edge)
+
+		// check if the parent declared a different source
+		if parentConfig != nil {
+			declared := parentConfig.Dependencies[child.Name()]
+			if declared != nil && declared.Source != nil && !sameSource(parentNode.path, declared.Source, child.Path()) {
+				edge.Conflict = formatSource(declared.Source)
+			}
+		}
+
+		// check if the edge closes a cycle
+		for i, name := range path {
+			if name == child.Name() {
+				edge.Cycle = append(append([]string{}, path[i:]...), child.Name())
+				break
+			}
+		}
+		if edge.Cycle != nil {
+			continue
+		}
+
+		node := g.Node(child.Name())
+		if node != nil {
+			node.Parents = append(node.Parents, parentNode.Name)
+			continue
+		}
+
+		node = &GraphNode{
+			Name:      child.Name(),
+			Namespace: parentNode.Namespace,
+			Pipeline:  DefaultDependencyPipeline,
+			Parents:   []string{parentNode.Name},
+			path:      child.Path(),
+		}
+		if dependencyConfig := child.DependencyConfig(); dependencyConfig != nil {
+			node.Source = formatSource(dependencyConfig.Source)
+			node.Profiles = dependencyConfig.Profiles
+			if dependencyConfig.Namespace != "" {
+				node.Namespace = dependencyConfig.Namespace
+			}
+			if dependencyConfig.Pipeline != "" {
+				node.Pipeline = dependencyConfig.Pipeline
+			}
+		}
+
+		var childConfig *latest.Config
+		if child.Config() != nil {
+			childConfig = child.Config().Config()
+		}
+
+		g.Nodes = append(g.Nodes, node)
+		g.walk(node, childConfig, child.Children(), append(path, node.Name))
+	}
+}
+
+// Node returns the node with the given name or nil if it does not exist
+func (g *GraphView) Node(name string) *GraphNode {
+	for _, node := range g.Nodes {
+		if node.Name == name {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// Print writes the graph view in the given format to out
+func (g *GraphView) Print(out io.Writer, format GraphFormat) error {
+	switch format {
+	case GraphFormatTree, "":
+		g.printTree(out)
+	case GraphFormatDOT:
+		g.printDOT(out)
+	case GraphFormatMermaid:
+		g.printMermaid(out)
+	default:
+		return errors.Errorf("unsupported graph format %s, must be one of %s, %s or %s", format, GraphFormatTree, GraphFormatDOT, GraphFormatMermaid)
+	}
+
+	return nil
+}
+
+func (g *GraphView) printTree(out io.Writer) {
+	root := g.Nodes[0]
+	_, _ = fmt.Fprintln(out, root.Name)
+	g.printTreeChildren(out, root.Name, "", map[string]bool{root.Name: true})
+
+	notes := g.notes()
+	if len(notes) > 0 {
+		_, _ = fmt.Fprintln(out)
+		for _, note := range notes {
+			_, _ = fmt.Fprintln(out, note)
+		}
+	}
+}
+
+func (g *GraphView) printTreeChildren(out io.Writer, parent, prefix string, printed map[string]bool) {
+	edges := g.edgesFrom(parent)
+	for i, edge := range edges {
+		branch, indent := "├── ", "│   "
+		if i == len(edges)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		line := edge.To
+		if edge.Cycle != nil {
+			line += " (cycle: " + strings.Join(edge.Cycle, " -> ") + ", skipped)"
+		} else if printed[edge.To] {
+			line += " (shared, see above)"
+		} else {
+			line += " " + g.Node(edge.To).describe()
+		}
+		if edge.Conflict != "" {
+			line += " (declared as " + edge.Conflict + ", using " + g.Node(edge.To).Source + ")"
+		}
+
+		_, _ = fmt.Fprintln(out, prefix+branch+line)
+		if edge.Cycle == nil && !printed[edge.To] {
+			printed[edge.To] = true
+			g.printTreeChildren(out, edge.To, prefix+indent, printed)
+		}
+	}
+}
+
+func (g *GraphView) printDOT(out io.Writer) {
+	_, _ = fmt.Fprintln(out, "digraph dependencies {")
+	_, _ = fmt.Fprintln(out, "  node [shape=box];")
+	for _, node := range g.Nodes {
+		_, _ = fmt.Fprintf(out, "  %s [label=%s];\n", dotQuote(node.Name), dotQuote(strings.Join(node.lines(), "\n")))
+	}
+	for _, edge := range g.Edges {
+		attributes := []string{}
+		if edge.Cycle != nil {
+			attributes = append(attributes, "style=dashed", "color=red", "label="+dotQuote("cycle, skipped"))
+		} else if edge.Conflict != "" {
+			attributes = append(attributes, "color=orange", "label="+dotQuote("declared as "+edge.Conflict))
+		}
+
+		if len(attributes) > 0 {
+			_, _ = fmt.Fprintf(out, "  %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attributes, ", "))
+		} else {
+			_, _ = fmt.Fprintf(out, "  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+		}
+	}
+	_, _ = fmt.Fprintln(out, "}")
+}
+
+func (g *GraphView) printMermaid(out io.Writer) {
+	ids := map[string]string{}
+	_, _ = fmt.Fprintln(out, "flowchart TD")
+	for i, node := range g.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", i)
+		_, _ = fmt.Fprintf(out, "  %s[\"%s\"]\n", ids[node.Name], mermaidEscape(strings.Join(node.lines(), "<br/>")))
+	}
+	for _, edge := range g.Edges {
+		if edge.Cycle != nil {
+			_, _ = fmt.Fprintf(out, "  %s -.->|\"cycle, skipped\"| %s\n", ids[edge.From], ids[edge.To])
+		} else if edge.Conflict != "" {
+			_, _ = fmt.Fprintf(out, "  %s -->|\"declared as %s\"| %s\n", ids[edge.From], mermaidEscape(edge.Conflict), ids[edge.To])
+		} else {
+			_, _ = fmt.Fprintf(out, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	}
+}
+
+// notes explains how cycles, shared and duplicate dependencies are resolved
+func (g *GraphView) notes() []string {
+	notes := []string{}
+	for _, edge := range g.Edges {
+		if edge.Cycle != nil {
+			notes = append(notes, fmt.Sprintf("Cycle %s: %s does not run %s again", strings.Join(edge.Cycle, " -> "), edge.From, edge.To))
+		}
+	}
+	for _, node := range g.Nodes[1:] {
+		if len(node.Parents) > 1 {
+			notes = append(notes, fmt.Sprintf("%s is required by %s: its pipeline runs once and the others wait for it", node.Name, strings.Join(node.Parents, ", ")))
+		}
+	}
+	for _, edge := range g.Edges {
+		if edge.Conflict != "" {
+			notes = append(notes, fmt.Sprintf("%s declares %s as %s, but %s from %s is used", edge.From, edge.To, edge.Conflict, g.Node(edge.To).Source, g.Node(edge.To).Parents[0]))
+		}
+	}
+	for _, names := range g.DuplicateSources {
+		notes = append(notes, fmt.Sprintf("%s use the same source %s: each of them is resolved and runs separately", strings.Join(names, ", "), g.Node(names[0]).Source))
+	}
+
+	return notes
+}
+
+func (g *GraphView) edgesFrom(name string) []*GraphEdge {
+	edges := []*GraphEdge{}
+	for _, edge := range g.Edges {
+		if edge.From == name {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// describe returns the details of the node in a single line
+func (n *GraphNode) describe() string {
+	return "[" + strings.Join(n.lines()[1:], ", ") + "]"
+}
+
+// lines returns the name and the details of the node
+func (n *GraphNode) lines() []string {
+	lines := []string{n.Name}
+	if n.root {
+		if n.Namespace != "" {
+			lines = append(lines, "namespace: "+n.Namespace)
+		}
+		return lines
+	}
+
+	lines = append(lines, "source: "+n.Source, "pipeline: "+n.Pipeline)
+	if n.Namespace != "" {
+		lines = append(lines, "namespace: "+n.Namespace)
+	}
+	if len(n.Profiles) > 0 {
+		lines = append(lines, "profiles: "+strings.Join(n.Profiles, ","))
+	}
+	return lines
+}
+
+// formatSource returns the path or git@rev of the source
+func formatSource(source *latest.SourceConfig) string {
+	if source == nil {
+		return ""
+	}
+
+	formatted := source.Path
+	if source.Git != "" {
+		formatted = source.Git
+		if source.Revision != "" {
+			formatted += "@" + source.Revision
+		} else if source.Tag != "" {
+			formatted += "@" + source.Tag
+		} else if source.Branch != "" {
+			formatted += "@" + source.Branch
+		}
+	}
+	if source.SubPath != "" {
+		formatted += "//" + source.SubPath
+	}
+
+	return formatted
+}
+
+// sameSource checks if the source declared in basePath resolves to the given dependency path
+func sameSource(basePath string, source *latest.SourceConfig, dependencyPath string) bool {
+	configPath, err := util.GetDependencyPath(basePath, source)
+	if err != nil {
+		return true
+	}
+
+	return filepath.Clean(filepath.Dir(configPath)) == filepath.Clean(dependencyPath)
+}
+
+func dotQuote(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	return "\"" + strings.ReplaceAll(value, "\n", "\\n") + "\""
+}
+
+func mermaidEscape(value string) string {
+	return strings.ReplaceAll(value, "\"", "#quot;")
+}
@@ -0,0 +1,112 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+const expectedTree = `app
+├── api [source: ./api, pipeline: deploy, namespace: api, profiles: dev]
+│   ├── db [source: ../db, pipeline: deploy, namespace: api]
+│   └── worker [source: ../worker, pipeline: deploy, namespace: api]
+│       └── api (cycle: api -> worker -> api, skipped)
+├── api-copy [source: ./api, pipeline: deploy]
+└── web [source: https://github.com/org/web.git@main, pipeline: dev]
+    └── db (shared, see above) (declared as https://github.com/org/db.git, using ../db)
+
+Cycle api -> worker -> api: worker does not run api again
+db is required by api, web: its pipeline runs once and the others wait for it
+web declares db as https://github.com/org/db.git, but ../db from api is used
+api, api-copy use the same source ./api: each of them is resolved and runs separately
+`
+
+func newTestDependency(name, path string, dependencyConfig *latest.DependencyConfig, dependencies map[string]*latest.DependencyConfig) *Dependency {
+	dependencyConfig.Name = name
+	return &Dependency{
+		name:             name,
+		absolutePath:     path,
+		dependencyConfig: dependencyConfig,
+		localConfig:      config.NewConfig(nil, nil, &latest.Config{Name: name, Dependencies: dependencies}, nil, nil, nil, filepath.Join(path, "devspace.yaml")),
+	}
+}
+
+func TestGraphView(t *testing.T) {
+	projectDir := filepath.FromSlash("/project")
+	webSource := &latest.SourceConfig{Git: "https://github.com/org/web.git", Branch: "main"}
+	webPath, err := util.GetDependencyPath(projectDir, webSource)
+	assert.NilError(t, err)
+
+	api := newTestDependency("api", filepath.Join(projectDir, "api"), &latest.DependencyConfig{
+		Source:    &latest.SourceConfig{Path: "./api"},
+		Namespace: "api",
+		Profiles:  []string{"dev"},
+	}, map[string]*latest.DependencyConfig{
+		"db":     {Source: &latest.SourceConfig{Path: "../db"}},
+		"worker": {Source: &latest.SourceConfig{Path: "../worker"}},
+	})
+	apiCopy := newTestDependency("api-copy", filepath.Join(projectDir, "api"), &latest.DependencyConfig{
+		Source: &latest.SourceConfig{Path: "./api"},
+	}, nil)
+	web := newTestDependency("web", filepath.Dir(webPath), &latest.DependencyConfig{
+		Source:   webSource,
+		Pipeline: "dev",
+	}, map[string]*latest.DependencyConfig{
+		"db": {Source: &latest.SourceConfig{Git: "https://github.com/org/db.git"}},
+	})
+	db := newTestDependency("db", filepath.Join(projectDir, "db"), &latest.DependencyConfig{
+		Source: &latest.SourceConfig{Path: "../db"},
+	}, nil)
+	worker := newTestDependency("worker", filepath.Join(projectDir, "worker"), &latest.DependencyConfig{
+		Source: &latest.SourceConfig{Path: "../worker"},
+	}, map[string]*latest.DependencyConfig{
+		"api": {Source: &latest.SourceConfig{Path: "../api"}},
+	})
+	api.children = []types.Dependency{db, worker}
+	worker.children = []types.Dependency{api}
+	web.children = []types.Dependency{db}
+
+	rootConfig := config.NewConfig(nil, nil, &latest.Config{
+		Name: "app",
+		Dependencies: map[string]*latest.DependencyConfig{
+			"api":      {Source: &latest.SourceConfig{Path: "./api"}},
+			"api-copy": {Source: &latest.SourceConfig{Path: "./api"}},
+			"web":      {Source: webSource},
+		},
+	}, nil, nil, nil, filepath.Join(projectDir, "devspace.yaml"))
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(rootConfig)
+
+	view := NewGraphView(ctx, []types.Dependency{api, apiCopy, web})
+	assert.Equal(t, len(view.Nodes), 6)
+	assert.DeepEqual(t, view.Node("db").Parents, []string{"api", "web"})
+	assert.DeepEqual(t, view.DuplicateSources, [][]string{{"api", "api-copy"}})
+
+	out := &bytes.Buffer{}
+	assert.NilError(t, view.Print(out, GraphFormatTree))
+	assert.Equal(t, out.String(), expectedTree)
+
+	out.Reset()
+	assert.NilError(t, view.Print(out, GraphFormatDOT))
+	assert.Assert(t, strings.HasPrefix(out.String(), "digraph dependencies {\n"))
+	assert.Assert(t, strings.Contains(out.String(), `"api" [label="api\nsource: ./api\npipeline: deploy\nnamespace: api\nprofiles: dev"];`))
+	assert.Assert(t, strings.Contains(out.String(), `"worker" -> "api" [style=dashed, color=red, label="cycle, skipped"];`))
+	assert.Assert(t, strings.Contains(out.String(), `"web" -> "db" [color=orange, label="declared as https://github.com/org/db.git"];`))
+
+	out.Reset()
+	assert.NilError(t, view.Print(out, GraphFormatMermaid))
+	assert.Assert(t, strings.HasPrefix(out.String(), "flowchart TD\n"))
+	assert.Assert(t, strings.Contains(out.String(), `n1["api<br/>source: ./api<br/>pipeline: deploy<br/>namespace: api<br/>profiles: dev"]`))
+	assert.Assert(t, strings.Contains(out.String(), `n3 -.->|"cycle, skipped"| n1`))
+
+	assert.ErrorContains(t, view.Print(out, "svg"), "unsupported graph format svg")
+}
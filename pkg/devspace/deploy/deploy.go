@@ -27,6 +27,9 @@ type Options struct {
 
 	Render       bool `long:"render" description:"If true, prints the rendered manifests to the stdout instead of deploying them"`
 	RenderWriter io.Writer
+
+	Plan       bool        `long:"plan" description:"If true, prints the changes a deploy would make in the cluster instead of deploying. The command exits with code 2 after the pipeline if there are changes"`
+	PlanResult *PlanResult `no-flag:"true"`
 }
 
 type PurgeOptions struct {
@@ -37,6 +40,7 @@ type PurgeOptions struct {
 type Controller interface {
 	Deploy(ctx devspacecontext.Context, deployments []string, options *Options) error
 	Purge(ctx devspacecontext.Context, deployments []string, options *PurgeOptions) error
+	Rollback(ctx devspacecontext.Context, deployments []string, options *RollbackOptions) error
 }
 
 type controller struct{}
@@ -49,6 +53,11 @@ func NewController() Controller {
 // Deploy deploys all deployments in the config
 func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, options *Options) error {
 	config := ctx.Config().Config()
+	if options.Plan {
+		// planning renders the deployments without deploying them
+		options.Render = true
+	}
+
 	event := "deploy"
 	if options.Render {
 		event = "render"
@@ -211,7 +220,9 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 	}
 
 	wasDeployed := false
-	if !options.Render {
+	if options.Plan {
+		err = plan(ctx, deployConfig, deployClient, options.RenderWriter, options.PlanResult)
+	} else if !options.Render {
 		wasDeployed, err = deployClient.Deploy(ctx, options.ForceDeploy)
 	} else {
 		err = deployClient.Render(ctx, options.RenderWriter)
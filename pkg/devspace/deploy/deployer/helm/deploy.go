@@ -8,11 +8,15 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/legacy"
 	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 
 	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
@@ -86,6 +90,13 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 		}
 	}
 
+	// Check post render kustomize overlay and scripts for changes
+	postRenderHash, err := postrender.Hash(ctx.WorkingDir(), d.DeploymentConfig.PostRender)
+	if err != nil {
+		return false, errors.Errorf("Error hashing post render files: %v", err)
+	}
+	helmOverridesHash += postRenderHash
+
 	// Check deployment config for changes
 	configStr, err := yaml.Marshal(d.DeploymentConfig)
 	if err != nil {
@@ -155,6 +166,11 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 		}
 
 		deployCache.Helm = helmCache
+		record := deployer.NewDeploymentRecord(ctx)
+		record.DeploymentConfigHash = deploymentConfigHash
+		record.ValuesHash = deployValuesHash
+		record.HelmRevision = helmCache.ReleaseRevision
+		deployCache.AddHistory(record)
 		if rootName, ok := values.RootNameFrom(ctx.Context()); ok && !stringutil.Contains(deployCache.Projects, rootName) {
 			deployCache.Projects = append(deployCache.Projects, rootName)
 		}
@@ -170,23 +186,20 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 }
 
 func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValues map[string]interface{}, out io.Writer) (*types.Release, error) {
-	var releaseName string
-	if d.DeploymentConfig.Helm.ReleaseName != "" {
-		releaseName = d.DeploymentConfig.Helm.ReleaseName
-	} else {
-		releaseName = d.DeploymentConfig.Name
-	}
-	releaseNamespace := ctx.KubeClient().Namespace()
-	if d.DeploymentConfig.Namespace != "" {
-		releaseNamespace = d.DeploymentConfig.Namespace
-	}
-
+	releaseName, releaseNamespace := d.release(ctx)
 	if out != nil {
 		str, err := d.Helm.Template(ctx, releaseName, releaseNamespace, overwriteValues, d.DeploymentConfig.Helm)
 		if err != nil {
 			return nil, err
 		}
 
+		if d.DeploymentConfig.PostRender != nil {
+			str, err = postrender.RenderManifests(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), d.DeploymentConfig.PostRender, str, ctx.Log())
+			if err != nil {
+				return nil, errors.Wrap(err, "post render")
+			}
+		}
+
 		_, _ = out.Write([]byte("\n" + str + "\n"))
 		return nil, nil
 	}
@@ -195,8 +208,25 @@ func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValu
 	valuesOut, _ := yaml.Marshal(overwriteValues)
 	ctx.Log().Debugf("Deploying chart with values:\n %v\n", string(valuesOut))
 
+	// Let helm call devspace as post renderer
+	helmConfig := d.DeploymentConfig.Helm
+	if d.DeploymentConfig.PostRender != nil {
+		postRenderArgs, postRenderFile, err := postrender.HelmArgs(ctx.WorkingDir(), ctx.Environ(), d.DeploymentConfig.PostRender)
+		if err != nil {
+			return nil, errors.Wrap(err, "post render")
+		}
+		defer os.Remove(postRenderFile)
+
+		copiedConfig := *helmConfig
+		copiedConfig.UpgradeArgs = append(postRenderArgs, helmConfig.UpgradeArgs...)
+		helmConfig = &copiedConfig
+		ctx = ctx.WithEnviron(env.NewVariableEnvProvider(ctx.Environ(), map[string]string{
+			expression.DevSpaceSkipPreloadEnv: "true",
+		}))
+	}
+
 	// Deploy chart
-	appRelease, err := d.Helm.InstallChart(ctx, releaseName, releaseNamespace, overwriteValues, d.DeploymentConfig.Helm)
+	appRelease, err := d.Helm.InstallChart(ctx, releaseName, releaseNamespace, overwriteValues, helmConfig)
 	if err != nil {
 		return nil, errors.Errorf("unable to deploy helm chart: %v", err)
 	}
@@ -211,6 +241,37 @@ func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValu
 	return appRelease, nil
 }
 
+// DeployedManifests returns the manifests of the installed helm release or an empty string
+// if the release is not installed yet
+func (d *DeployConfig) DeployedManifests(ctx devspacecontext.Context) (string, error) {
+	releaseName, releaseNamespace := d.release(ctx)
+	releases, err := d.Helm.ListReleases(ctx, releaseNamespace)
+	if err != nil {
+		return "", err
+	}
+
+	for _, release := range releases {
+		if release.Name == releaseName {
+			return d.Helm.GetManifest(ctx, releaseName, releaseNamespace)
+		}
+	}
+
+	return "", nil
+}
+
+func (d *DeployConfig) release(ctx devspacecontext.Context) (string, string) {
+	releaseName := d.DeploymentConfig.Name
+	if d.DeploymentConfig.Helm.ReleaseName != "" {
+		releaseName = d.DeploymentConfig.Helm.ReleaseName
+	}
+	releaseNamespace := ctx.KubeClient().Namespace()
+	if d.DeploymentConfig.Namespace != "" {
+		releaseNamespace = d.DeploymentConfig.Namespace
+	}
+
+	return releaseName, releaseNamespace
+}
+
 func (d *DeployConfig) getDeploymentValues(ctx devspacecontext.Context) (bool, map[string]interface{}, error) {
 	var (
 		chartPath       = d.DeploymentConfig.Helm.Chart.Name
@@ -157,9 +157,18 @@ func TestDeploy(t *testing.T) {
 			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
 		}
 
-		for _, deployment := range testCase.cache.Deployments {
+		for i := range testCase.cache.Deployments {
+			deployment := &testCase.cache.Deployments[i]
 			deployment.Helm.OverridesHash = ""
 			deployment.Helm.ChartHash = ""
+
+			// the history contains the time and user of the deploy
+			if deployed {
+				assert.Equal(t, len(deployment.History), 1, "Unexpected history in testCase %s", testCase.name)
+				assert.Equal(t, deployment.History[0].HelmRevision, deployment.Helm.ReleaseRevision, "Unexpected history in testCase %s", testCase.name)
+				assert.Equal(t, deployment.History[0].ValuesHash, deployment.Helm.ValuesHash, "Unexpected history in testCase %s", testCase.name)
+			}
+			deployment.History = nil
 		}
 		cacheAsYaml, err := yaml.Marshal(testCase.cache)
 		assert.NilError(t, err, "Error marshaling cache in testCase %s", testCase.name)
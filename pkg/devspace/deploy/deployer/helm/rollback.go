@@ -0,0 +1,44 @@
+package helm
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/helm"
+	"github.com/pkg/errors"
+)
+
+// Rollback rolls the helm release of the deployment back to the release revision
+// of the given deploy record and returns the new release revision
+func Rollback(ctx devspacecontext.Context, deploymentCache *remotecache.DeploymentCache, record remotecache.DeploymentRecord) (string, error) {
+	if deploymentCache.Helm == nil || deploymentCache.Helm.Release == "" {
+		return "", errors.Errorf("deployment %s has no helm release", deploymentCache.Name)
+	} else if record.HelmRevision == "" {
+		return "", errors.Errorf("revision %d has no helm release revision", record.Revision)
+	}
+
+	helmClient, err := helm.NewClient(ctx.Log())
+	if err != nil {
+		return "", errors.Wrap(err, "new helm client")
+	}
+
+	err = helmClient.Rollback(ctx, deploymentCache.Helm.Release, deploymentCache.Helm.ReleaseNamespace, record.HelmRevision)
+	if err != nil {
+		return "", err
+	}
+
+	releases, err := helmClient.ListReleases(ctx, deploymentCache.Helm.ReleaseNamespace)
+	if err != nil {
+		return "", err
+	}
+
+	for _, release := range releases {
+		if release.Name == deploymentCache.Helm.Release {
+			deploymentCache.Helm.ReleaseRevision = release.Revision
+			break
+		}
+	}
+
+	deploymentCache.Helm.ValuesHash = record.ValuesHash
+	deploymentCache.DeploymentConfigHash = record.DeploymentConfigHash
+	return deploymentCache.Helm.ReleaseRevision, nil
+}
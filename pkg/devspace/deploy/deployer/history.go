@@ -0,0 +1,44 @@
+package deployer
+
+import (
+	"os/user"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/git"
+)
+
+// NewDeploymentRecord creates a new deploy record with the image tags, git commit
+// and user of the current deploy
+func NewDeploymentRecord(ctx devspacecontext.Context) remotecache.DeploymentRecord {
+	record := remotecache.DeploymentRecord{
+		Time: time.Now(),
+	}
+
+	if ctx.Config() != nil && ctx.Config().Config() != nil && ctx.Config().LocalCache() != nil {
+		for imageConfigName := range ctx.Config().Config().Images {
+			imageCache, ok := ctx.Config().LocalCache().GetImageCache(imageConfigName)
+			if !ok || imageCache.Tag == "" {
+				continue
+			}
+
+			if record.ImageTags == nil {
+				record.ImageTags = map[string]string{}
+			}
+			record.ImageTags[imageConfigName] = imageCache.Tag
+		}
+	}
+
+	gitCommit, err := git.GetHash(ctx.Context(), ctx.WorkingDir())
+	if err == nil {
+		record.GitCommit = gitCommit
+	}
+
+	currentUser, err := user.Current()
+	if err == nil {
+		record.User = currentUser.Username
+	}
+
+	return record
+}
@@ -0,0 +1,254 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultFieldManager is the field manager that is used for server-side apply
+const DefaultFieldManager = "devspace"
+
+const (
+	// replicasField is the path of the replicas as reported in field manager conflicts
+	replicasField = ".spec.replicas"
+
+	// replaceReplicasAnnotation is set by podreplace on objects it scaled down
+	// (see podreplace.ReplicasAnnotation, which can't be imported here)
+	replaceReplicasAnnotation = "devspace.sh/replicas"
+)
+
+var conflictManagerRegex = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// Drift is a field of a deployed object that another field manager changed since the last deploy
+type Drift struct {
+	Object remotecache.KubectlObject
+
+	// Path is the path of the changed field, empty if the whole object was deleted
+	Path string
+
+	// Manager is the field manager that changed the field
+	Manager string
+}
+
+func (d Drift) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s %s was deleted", d.Object.Kind, d.Object.Name)
+	} else if d.Manager == "" {
+		return fmt.Sprintf("%s %s: %s was changed", d.Object.Kind, d.Object.Name, d.Path)
+	}
+
+	return fmt.Sprintf("%s %s: %s was changed by %s", d.Object.Kind, d.Object.Name, d.Path, d.Manager)
+}
+
+// serverSideApplier applies objects with server-side apply via the dynamic client
+type serverSideApplier struct {
+	*resourceClient
+
+	fieldManager string
+}
+
+func newServerSideApplier(restConfig *rest.Config, fieldManager string) (*serverSideApplier, error) {
+	resourceClient, err := newResourceClient(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+
+	return &serverSideApplier{
+		resourceClient: resourceClient,
+		fieldManager:   fieldManager,
+	}, nil
+}
+
+// Apply applies the objects with server-side apply. Custom resource definitions are applied before
+// the other objects, so that their custom resources can be deployed together with them. If force
+// is true, fields that are managed by other field managers are overwritten.
+func (s *serverSideApplier) Apply(ctx context.Context, objects []*unstructured.Unstructured, force bool) error {
+	defined := map[schema.GroupKind]bool{}
+	for _, object := range definitionsFirst(objects) {
+		client, err := s.resource(object.GetAPIVersion(), object.GetKind(), object.GetNamespace())
+		if isUnknownKind(err) && defined[object.GroupVersionKind().GroupKind()] {
+			client, err = s.waitForResource(ctx, object)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = s.apply(ctx, client, object, force, false)
+		if err != nil {
+			if kerrors.IsConflict(err) {
+				return errors.Errorf("apply %s %s: %v\nThe fields were changed in the cluster, set onDrift to overwrite to take them over", object.GetKind(), object.GetName(), err)
+			}
+
+			return errors.Wrapf(err, "apply %s %s", object.GetKind(), object.GetName())
+		}
+
+		if groupKind, ok := definedKind(object); ok {
+			defined[groupKind] = true
+		}
+	}
+
+	return nil
+}
+
+// Drift applies the objects with a server-side dry-run and returns the fields that other field
+// managers changed since the last apply. Fields that were added by defaulting or mutating webhooks
+// belong to the applier and are no drift. Previously deployed objects that are missing are returned
+// as deleted. Objects of a kind that is not known yet are new. Replicas that were scaled through the scale subresource or by replacing pods are left
+// to their manager and removed from the objects, so that the apply doesn't take them over.
+func (s *serverSideApplier) Drift(ctx context.Context, objects []*unstructured.Unstructured, previous []remotecache.KubectlObject) ([]Drift, error) {
+	drifts := []Drift{}
+	for _, object := range objects {
+		live, _, objectDrifts, err := s.DryRun(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+
+		kubeObject := toKubectlObject(object)
+		if live == nil && containsObject(previous, kubeObject) {
+			drifts = append(drifts, Drift{Object: kubeObject})
+		}
+
+		drifts = append(drifts, objectDrifts...)
+	}
+
+	return drifts, nil
+}
+
+// DryRun applies the object with a server-side dry-run and returns the live object, the object as
+// it would look like after a forced apply and the fields other field managers changed. The live
+// and applied object are nil if the object does not exist yet, which is also the case if its kind
+// is unknown, e.g. because its custom resource definition is not deployed yet.
+func (s *serverSideApplier) DryRun(ctx context.Context, object *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, []Drift, error) {
+	client, err := s.resource(object.GetAPIVersion(), object.GetKind(), object.GetNamespace())
+	if isUnknownKind(err) {
+		return nil, nil, nil, nil
+	} else if err != nil {
+		return nil, nil, nil, err
+	}
+
+	live, err := client.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, nil, nil
+		}
+
+		return nil, nil, nil, errors.Wrapf(err, "get %s %s", object.GetKind(), object.GetName())
+	}
+
+	applied, err := s.apply(ctx, client, object, false, true)
+	if err == nil {
+		return live, applied, nil, nil
+	} else if !kerrors.IsConflict(err) {
+		return nil, nil, nil, errors.Wrapf(err, "dry run apply %s %s", object.GetKind(), object.GetName())
+	}
+
+	drifts := []Drift{}
+	for _, drift := range conflictsOf(toKubectlObject(object), err) {
+		if drift.Path == replicasField && isScaled(live) {
+			unstructured.RemoveNestedField(object.Object, "spec", "replicas")
+			continue
+		}
+
+		drifts = append(drifts, drift)
+	}
+
+	applied, err = s.apply(ctx, client, object, true, true)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "dry run apply %s %s", object.GetKind(), object.GetName())
+	}
+
+	return live, applied, drifts, nil
+}
+
+func (s *serverSideApplier) apply(ctx context.Context, client dynamic.ResourceInterface, object *unstructured.Unstructured, force, dryRun bool) (*unstructured.Unstructured, error) {
+	options := metav1.ApplyOptions{
+		FieldManager: s.fieldManager,
+		Force:        force,
+	}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	return client.Apply(ctx, object.GetName(), object, options)
+}
+
+// conflictsOf returns the fields of a server-side apply conflict error
+func conflictsOf(object remotecache.KubectlObject, err error) []Drift {
+	drifts := []Drift{}
+	if status, ok := err.(kerrors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type != metav1.CauseTypeFieldManagerConflict {
+				continue
+			}
+
+			drift := Drift{Object: object, Path: cause.Field}
+			if matches := conflictManagerRegex.FindStringSubmatch(cause.Message); matches != nil {
+				drift.Manager = matches[1]
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+	if len(drifts) == 0 {
+		drifts = append(drifts, Drift{Object: object, Path: err.Error()})
+	}
+
+	return drifts
+}
+
+// isScaled checks if the replicas of the object were changed by devspace to replace its pods
+// or through the scale subresource, which is used by autoscalers and kubectl scale
+func isScaled(live *unstructured.Unstructured) bool {
+	if _, ok := live.GetAnnotations()[replaceReplicasAnnotation]; ok {
+		return true
+	}
+
+	for _, entry := range live.GetManagedFields() {
+		if entry.Subresource != "scale" || entry.FieldsV1 == nil {
+			continue
+		}
+
+		fields := map[string]map[string]interface{}{}
+		if json.Unmarshal(entry.FieldsV1.Raw, &fields) != nil {
+			continue
+		}
+		if _, ok := fields["f:spec"]["f:replicas"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toKubectlObject(object *unstructured.Unstructured) remotecache.KubectlObject {
+	return remotecache.KubectlObject{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+		Namespace:  object.GetNamespace(),
+	}
+}
+
+func formatDrifts(drifts []Drift) string {
+	lines := []string{}
+	for _, drift := range drifts {
+		lines = append(lines, "- "+drift.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
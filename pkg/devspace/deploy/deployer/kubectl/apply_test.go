@@ -0,0 +1,240 @@
+package kubectl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"gotest.tools/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/restmapper"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+const lastAppliedManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  creationTimestamp: null
+  name: backend
+  namespace: test
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: backend:v1
+        name: backend
+        resources:
+          limits:
+            cpu: 1000m
+status: {}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: test
+data:
+  key: value
+`
+
+func newTestApplier(objects ...runtime.Object) (*serverSideApplier, *fakedynamic.FakeDynamicClient) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	return &serverSideApplier{
+		resourceClient: &resourceClient{
+			client: client,
+			mapper: mapper,
+		},
+		fieldManager: DefaultFieldManager,
+	}, client
+}
+
+// newTestDiscoveryApplier returns an applier that discovers the api resources, initially
+// only custom resource definitions are served
+func newTestDiscoveryApplier(objects ...runtime.Object) (*serverSideApplier, *fakediscovery.FakeDiscovery, *fakedynamic.FakeDynamicClient) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &kubetesting.Fake{}}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "apiextensions.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition"}}},
+	}
+
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	return &serverSideApplier{
+		resourceClient: &resourceClient{
+			client: client,
+			mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		},
+		fieldManager: DefaultFieldManager,
+	}, discoveryClient, client
+}
+
+func TestDrift(t *testing.T) {
+	objects, err := postrender.ParseManifests(lastAppliedManifests)
+	assert.NilError(t, err)
+
+	// the live deployment was scaled by an autoscaler and its image was edited manually
+	live := objects[0].DeepCopy()
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: DefaultFieldManager, Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{}}}`)}},
+		{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+	})
+
+	applier, client := newTestApplier(live)
+	applied := map[string]int{}
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(kubetesting.PatchAction)
+		applied[patchAction.GetName()]++
+
+		// the first, non forced apply conflicts with the other field managers
+		if applied[patchAction.GetName()] == 1 {
+			err := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, patchAction.GetName(), nil)
+			err.ErrStatus.Details.Causes = []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl-edit" using apps/v1`, Field: `.spec.template.spec.containers[name="backend"].image`},
+				{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kube-controller-manager" using apps/v1`, Field: ".spec.replicas"},
+			}
+			return true, nil, err
+		}
+
+		obj := &unstructured.Unstructured{}
+		err := obj.UnmarshalJSON(patchAction.GetPatch())
+		return true, obj, err
+	})
+
+	drifts, err := applier.Drift(context.Background(), objects, []remotecache.KubectlObject{
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "backend", Namespace: "test"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "test"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(drifts), 2)
+	assert.Equal(t, drifts[0].String(), `Deployment backend: .spec.template.spec.containers[name="backend"].image was changed by kubectl-edit`)
+	assert.Equal(t, drifts[1].String(), "ConfigMap config was deleted")
+
+	// the replicas are left to the autoscaler
+	_, found, err := unstructured.NestedInt64(objects[0].Object, "spec", "replicas")
+	assert.NilError(t, err)
+	assert.Assert(t, !found)
+	assert.Equal(t, applied["backend"], 2)
+
+	// replicas that were not scaled are drift as well
+	live.SetManagedFields(nil)
+	objects, err = postrender.ParseManifests(lastAppliedManifests)
+	assert.NilError(t, err)
+	applier, client = newTestApplier(live)
+	applied = map[string]int{}
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(kubetesting.PatchAction)
+		applied[patchAction.GetName()]++
+		if applied[patchAction.GetName()] == 1 {
+			err := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, patchAction.GetName(), nil)
+			err.ErrStatus.Details.Causes = []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl-edit" using apps/v1`, Field: ".spec.replicas"},
+			}
+			return true, nil, err
+		}
+
+		return true, objects[0], nil
+	})
+	drifts, err = applier.Drift(context.Background(), objects[:1], nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(drifts), 1)
+	assert.Equal(t, drifts[0].String(), "Deployment backend: .spec.replicas was changed by kubectl-edit")
+}
+
+func TestServerSideApply(t *testing.T) {
+	objects, err := postrender.ParseManifests(lastAppliedManifests)
+	assert.NilError(t, err)
+
+	applier, client := newTestApplier()
+	applied := []string{}
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(kubetesting.PatchAction)
+		assert.Equal(t, patchAction.GetPatchType(), types.ApplyPatchType)
+		assert.Equal(t, patchAction.GetNamespace(), "test")
+		applied = append(applied, patchAction.GetResource().Resource+"/"+patchAction.GetName())
+
+		obj := &unstructured.Unstructured{}
+		err := obj.UnmarshalJSON(patchAction.GetPatch())
+		return true, obj, err
+	})
+
+	err = applier.Apply(context.Background(), objects, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, applied, []string{"deployments/backend", "configmaps/config"})
+
+	// conflicts tell the user how to resolve them
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "backend", nil)
+	})
+	err = applier.Apply(context.Background(), objects, false)
+	assert.Assert(t, err != nil && strings.Contains(err.Error(), "set onDrift to overwrite"))
+}
+
+const customResourceManifests = `apiVersion: example.com/v1
+kind: Backup
+metadata:
+  name: nightly
+  namespace: test
+spec:
+  schedule: "0 0 * * *"
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: backups.example.com
+spec:
+  group: example.com
+  names:
+    kind: Backup
+    plural: backups
+  scope: Namespaced
+`
+
+func TestApplyCustomResources(t *testing.T) {
+	objects, err := postrender.ParseManifests(customResourceManifests)
+	assert.NilError(t, err)
+
+	// the custom resource definition is not served before it is applied
+	applier, discoveryClient, client := newTestDiscoveryApplier()
+
+	// a dry-run of a custom resource without definition is a new object
+	drifts, err := applier.Drift(context.Background(), objects, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(drifts), 0)
+	_, err = applier.resource("example.com/v1", "Backup", "test")
+	assert.Assert(t, isUnknownKind(err))
+
+	applied := []string{}
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(kubetesting.PatchAction)
+		applied = append(applied, patchAction.GetResource().Resource+"/"+patchAction.GetName())
+		if patchAction.GetResource().Resource == "customresourcedefinitions" {
+			discoveryClient.Resources = append(discoveryClient.Resources, &metav1.APIResourceList{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{{Name: "backups", Kind: "Backup", Namespaced: true}},
+			})
+		}
+
+		obj := &unstructured.Unstructured{}
+		err := obj.UnmarshalJSON(patchAction.GetPatch())
+		return true, obj, err
+	})
+
+	// the definition is applied first and the custom resource is found after it was applied
+	err = applier.Apply(context.Background(), objects, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, applied, []string{"customresourcedefinitions/backups.example.com", "backups/nightly"})
+}
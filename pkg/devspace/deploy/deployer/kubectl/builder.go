@@ -3,13 +3,12 @@ package kubectl
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"os/exec"
-	"regexp"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
 	"github.com/loft-sh/devspace/pkg/util/constraint"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -20,7 +19,6 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/kubectl/pkg/cmd/version"
 	"mvdan.cc/sh/v3/expand"
-	jsonyaml "sigs.k8s.io/yaml"
 )
 
 // Builder is the manifest builder interface
@@ -58,7 +56,7 @@ func (k *kustomizeBuilder) Build(ctx context.Context, environ expand.Environ, di
 		return nil, err
 	}
 
-	return stringToUnstructuredArray(string(output))
+	return postrender.ParseManifests(string(output))
 }
 
 type kubectlBuilder struct {
@@ -162,38 +160,5 @@ func (k *kubectlBuilder) Build(ctx context.Context, environ expand.Environ, dir,
 		return nil, err
 	}
 
-	return stringToUnstructuredArray(string(output))
-}
-
-var diffSeparator = regexp.MustCompile(`\n---`)
-
-// stringToUnstructuredArray splits a YAML file into unstructured objects. Returns a list of all unstructured objects
-func stringToUnstructuredArray(out string) ([]*unstructured.Unstructured, error) {
-	parts := diffSeparator.Split(out, -1)
-	var objs []*unstructured.Unstructured
-	var firstErr error
-	for _, part := range parts {
-		var objMap map[string]interface{}
-		err := jsonyaml.Unmarshal([]byte(part), &objMap)
-		if err != nil {
-			if firstErr == nil {
-				firstErr = fmt.Errorf("failed to unmarshal manifest: %v", err)
-			}
-			continue
-		}
-		if len(objMap) == 0 {
-			// handles case where theres no content between `---`
-			continue
-		}
-		var obj unstructured.Unstructured
-		err = jsonyaml.Unmarshal([]byte(part), &obj)
-		if err != nil {
-			if firstErr == nil {
-				firstErr = fmt.Errorf("failed to unmarshal manifest: %v", err)
-			}
-			continue
-		}
-		objs = append(objs, &obj)
-	}
-	return objs, firstErr
+	return postrender.ParseManifests(string(output))
 }
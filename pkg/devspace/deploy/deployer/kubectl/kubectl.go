@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
-	"github.com/loft-sh/devspace/pkg/devspace/config/loader/patch"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/legacy"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
@@ -16,6 +15,8 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 	"github.com/loft-sh/utils/pkg/command"
@@ -170,29 +171,57 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	// forceDeploy = forceDeploy || deployCache.KubectlManifestsHash != manifestsHash || deployCache.DeploymentConfigHash != deploymentConfigHash
 	forceDeploy := true
 
-	ctx.Log().Info("Applying manifests with kubectl...")
-	wasDeployed := false
-	kubeObjects := []remotecache.KubectlObject{}
-
-	for _, manifest := range d.Manifests {
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
+	// resolve the runtime variables in the inline manifest
+	resolvedInlineManifest := ""
+	if d.InlineManifest != "" {
+		resolvedInlineManifest, err = runtime.NewRuntimeResolver(ctx.WorkingDir(), false).FillRuntimeVariablesAsString(ctx.Context(), d.InlineManifest, ctx.Config(), ctx.Dependencies())
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Special case for inline manifests
-	if d.InlineManifest != "" {
-		// resolve the runtime variables in the yaml
-		resolvedInlineManifest, err := runtime.NewRuntimeResolver(ctx.WorkingDir(), false).FillRuntimeVariablesAsString(ctx.Context(), d.InlineManifest, ctx.Config(), ctx.Dependencies())
+	wasDeployed := false
+	kubeObjects := []remotecache.KubectlObject{}
+	appliedManifests := []string{}
+	if d.DeploymentConfig.Kubectl.ServerSideApply {
+		kubeObjects, appliedManifests, err = d.serverSideApply(ctx, deployCache, resolvedInlineManifest)
 		if err != nil {
 			return false, err
 		}
-		// proceed with regular apply
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
-		if err != nil {
-			return false, err
+
+		wasDeployed = true
+	} else {
+		ctx.Log().Info("Applying manifests with kubectl...")
+		for _, manifest := range d.Manifests {
+			var appliedManifest string
+			wasDeployed, kubeObjects, appliedManifest, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
+			if err != nil {
+				return false, err
+			}
+
+			appliedManifests = append(appliedManifests, appliedManifest)
 		}
+
+		// Special case for inline manifests
+		if resolvedInlineManifest != "" {
+			// proceed with regular apply
+			var appliedManifest string
+			wasDeployed, kubeObjects, appliedManifest, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
+			if err != nil {
+				return false, err
+			}
+
+			appliedManifests = append(appliedManifests, appliedManifest)
+		}
+	}
+
+	// Delete the objects that are not part of the manifests anymore
+	prune := d.DeploymentConfig.Kubectl.ServerSideApply
+	if d.DeploymentConfig.Kubectl.Prune != nil {
+		prune = *d.DeploymentConfig.Kubectl.Prune
+	}
+	if prune && deployCache.Kubectl != nil {
+		pruneObjects(ctx, deployCache.Kubectl.Objects, kubeObjects)
 	}
 
 	deployCache.Kubectl = &remotecache.KubectlCache{
@@ -200,6 +229,14 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 		ManifestsHash: manifestsHash,
 	}
 	deployCache.DeploymentConfigHash = deploymentConfigHash
+	record := deployer.NewDeploymentRecord(ctx)
+	record.DeploymentConfigHash = deploymentConfigHash
+	record.ValuesHash = manifestsHash
+	err = record.SetManifests(strings.Join(appliedManifests, "\n---\n"))
+	if err != nil {
+		return false, errors.Wrap(err, "store manifests")
+	}
+	deployCache.AddHistory(record)
 	if rootName, ok := values.RootNameFrom(ctx.Context()); ok && !stringutil.Contains(deployCache.Projects, rootName) {
 		deployCache.Projects = append(deployCache.Projects, rootName)
 	}
@@ -207,10 +244,10 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	return wasDeployed, nil
 }
 
-func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, []remotecache.KubectlObject, error) {
+func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, []remotecache.KubectlObject, string, error) {
 	shouldRedeploy, replacedManifest, parsedObjects, err := d.getReplacedManifest(ctx, inline, manifest)
 	if err != nil {
-		return false, nil, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
+		return false, nil, "", errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
 	}
 	writer := ctx.Log().Writer(logrus.InfoLevel, false)
 	defer writer.Close()
@@ -223,14 +260,70 @@ func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []
 		stdErrBuffer := &bytes.Buffer{}
 		err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(replacedManifest), d.CmdPath, args...)
 		if err != nil {
-			return false, nil, errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
+			return false, nil, "", errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
 		}
 
 	} else {
 		ctx.Log().Infof("Skipping manifest %s", manifest)
 	}
 
-	return true, kubeObjects, nil
+	return true, kubeObjects, replacedManifest, nil
+}
+
+// serverSideApply renders all manifests, checks the rendered objects for drift and applies them with server-side apply
+func (d *DeployConfig) serverSideApply(ctx devspacecontext.Context, deployCache remotecache.DeploymentCache, inlineManifest string) ([]remotecache.KubectlObject, []string, error) {
+	applier, err := newServerSideApplier(ctx.KubeClient().RestConfig(), d.DeploymentConfig.Kubectl.FieldManager)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifests := append([]string{}, d.Manifests...)
+	if inlineManifest != "" {
+		manifests = append(manifests, inlineManifest)
+	}
+
+	objects := []*unstructured.Unstructured{}
+	kubeObjects := []remotecache.KubectlObject{}
+	appliedManifests := []string{}
+	for i, manifest := range manifests {
+		_, replacedManifest, parsedObjects, err := d.getReplacedManifest(ctx, i >= len(d.Manifests), manifest)
+		if err != nil {
+			return nil, nil, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
+		}
+
+		parsed, err := postrender.ParseManifests(replacedManifest)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		objects = append(objects, parsed...)
+		kubeObjects = append(kubeObjects, parsedObjects...)
+		appliedManifests = append(appliedManifests, replacedManifest)
+	}
+
+	var previous []remotecache.KubectlObject
+	if deployCache.Kubectl != nil {
+		previous = deployCache.Kubectl.Objects
+	}
+
+	drifts, err := applier.Drift(ctx.Context(), objects, previous)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "detect drift")
+	} else if len(drifts) > 0 {
+		if d.DeploymentConfig.Kubectl.OnDrift == latest.DriftPolicyAbort {
+			return nil, nil, errors.Errorf("deployment %s was changed in the cluster since the last deploy:\n%s\nSet onDrift to overwrite to deploy anyways", d.DeploymentConfig.Name, formatDrifts(drifts))
+		}
+
+		ctx.Log().Warnf("Deployment %s was changed in the cluster since the last deploy and will be overwritten:\n%s", d.DeploymentConfig.Name, formatDrifts(drifts))
+	}
+
+	ctx.Log().Info("Applying manifests with server-side apply...")
+	err = applier.Apply(ctx.Context(), objects, d.DeploymentConfig.Kubectl.OnDrift != latest.DriftPolicyAbort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return kubeObjects, appliedManifests, nil
 }
 
 func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline bool, manifest string) (bool, string, []remotecache.KubectlObject, error) {
@@ -243,35 +336,19 @@ func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline b
 			return false, "", nil, err
 		}
 	} else {
-		objects, err = stringToUnstructuredArray(manifest)
+		objects, err = postrender.ParseManifests(manifest)
 		if err != nil {
 			return false, "", nil, err
 		}
 	}
 
 	// Split output into the yamls
-	var (
-		replaceManifests = []string{}
-		shouldRedeploy   = false
-	)
-
-	kubeObjects := []remotecache.KubectlObject{}
-	for _, resource := range objects {
+	shouldRedeploy := false
+	for i, resource := range objects {
 		if resource.Object == nil {
 			continue
 		}
 
-		if resource.GetNamespace() == "" {
-			resource.SetNamespace(d.Namespace)
-		}
-
-		kubeObjects = append(kubeObjects, remotecache.KubectlObject{
-			APIVersion: resource.GetAPIVersion(),
-			Kind:       resource.GetKind(),
-			Name:       resource.GetName(),
-			Namespace:  resource.GetNamespace(),
-		})
-
 		if d.DeploymentConfig.UpdateImageTags == nil || *d.DeploymentConfig.UpdateImageTags {
 			redeploy, err := legacy.ReplaceImageNamesStringMap(resource.Object, ctx.Config(), ctx.Dependencies(), map[string]bool{"image": true})
 			if err != nil {
@@ -281,12 +358,40 @@ func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline b
 			}
 		}
 
-		resource, err := d.applyDeployPatches(ctx, resource)
+		patched, err := postrender.ApplyPatches(resource, d.DeploymentConfig.Kubectl.Patches, ctx.Log())
 		if err != nil {
 			// we're skipping a patch
 			ctx.Log().Warn(err)
+			continue
+		}
+
+		objects[i] = patched
+	}
+
+	// apply the post render config
+	objects, err = postrender.Render(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), d.DeploymentConfig.PostRender, objects, ctx.Log())
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "post render")
+	}
+
+	replaceManifests := []string{}
+	kubeObjects := []remotecache.KubectlObject{}
+	for _, resource := range objects {
+		if resource.Object == nil {
+			continue
 		}
 
+		if resource.GetNamespace() == "" {
+			resource.SetNamespace(d.Namespace)
+		}
+
+		kubeObjects = append(kubeObjects, remotecache.KubectlObject{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Name:       resource.GetName(),
+			Namespace:  resource.GetNamespace(),
+		})
+
 		replacedManifest, err := jsonyaml.Marshal(resource)
 		if err != nil {
 			return false, "", nil, errors.Wrap(err, "marshal yaml")
@@ -342,59 +447,23 @@ func (d *DeployConfig) isKustomizeInstalled(ctx context.Context, dir, path strin
 	return err == nil
 }
 
-func (d *DeployConfig) applyDeployPatches(ctx devspacecontext.Context, resource *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	out, err := jsonyaml.Marshal(resource)
-	if err != nil {
-		return resource, err
-	}
-
-	patches := patch.Patch{}
-	for idx, kubepatch := range d.DeploymentConfig.Kubectl.Patches {
-		newPatch := patch.Operation{
-			Op:   patch.Op(kubepatch.Operation),
-			Path: patch.OpPath(patch.TransformPath(kubepatch.Path)),
-		}
-
-		if kubepatch.Target.Name != resource.GetName() {
-			continue
-		}
-
-		// non-mandatory field, check only if defined
-		if kubepatch.Target.Kind != "" && resource.GetKind() != kubepatch.Target.Kind {
-			ctx.Log().Debugf("skipping patch, resource kind match: %s - %s", kubepatch.Target.Kind, resource.GetKind())
-			continue
-		}
-
-		// non-mandatory field, check only if defined
-		if kubepatch.Target.APIVersion != "" && resource.GetAPIVersion() != kubepatch.Target.APIVersion {
-			ctx.Log().Debugf("skipping patch, resource api mismatch: %s - %s", kubepatch.Target.APIVersion, resource.GetAPIVersion())
+// pruneObjects deletes the previously deployed objects that are not part of the current objects
+func pruneObjects(ctx devspacecontext.Context, previous, current []remotecache.KubectlObject) {
+	for _, resource := range previous {
+		if containsObject(current, resource) {
 			continue
 		}
 
-		if kubepatch.Value != nil {
-			value, err := patch.NewNode(&kubepatch.Value)
-			if err != nil {
-				return resource, errors.Errorf("value %d is invalid", idx)
-			}
-			newPatch.Value = value
+		ctx.Log().Infof("Pruning %s %s", resource.Kind, resource.Name)
+		_, err := ctx.KubeClient().GenericRequest(ctx.Context(), &kubectl.GenericRequestOptions{
+			Kind:       resource.Kind,
+			APIVersion: resource.APIVersion,
+			Name:       resource.Name,
+			Namespace:  resource.Namespace,
+			Method:     "delete",
+		})
+		if err != nil {
+			ctx.Log().Errorf("error deleting %s %s: %v", resource.Kind, resource.Name, err)
 		}
-
-		// TODO Maybe log here that we're indeed applying a patch?
-		ctx.Log().Debugf("applying patch: %s.%s", kubepatch.Target.Name, kubepatch.Path)
-		patches = append(patches, newPatch)
 	}
-
-	out, err = patches.Apply(out)
-	if err != nil {
-		return resource, errors.Wrap(err, "apply patches")
-	}
-
-	// transform resource back to unstructured
-	var result unstructured.Unstructured
-	err = jsonyaml.Unmarshal(out, &result)
-	if err != nil {
-		return nil, err
-	}
-
-	return &result, nil
 }
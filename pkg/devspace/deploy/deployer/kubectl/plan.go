@@ -0,0 +1,354 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Action is the operation a deploy would execute on an object
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is an object that would be created, updated or deleted by a deploy
+type Change struct {
+	Action Action
+	Object remotecache.KubectlObject
+
+	// Fields holds the changed fields of an updated object
+	Fields []FieldChange
+}
+
+// FieldChange is a field of an object that would be changed by a deploy
+type FieldChange struct {
+	Path string
+
+	// Expected is the value after the deploy and Actual the live value, either is nil
+	// if the field would be added or removed
+	Expected interface{}
+	Actual   interface{}
+}
+
+// lastAppliedAnnotation holds the configuration of the last client-side apply
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Planner compares the live objects with the objects after a server-side dry-run apply, so that
+// defaults, mutating webhooks and fields of other field managers show up on both sides
+type Planner struct {
+	applier *serverSideApplier
+
+	// clientSide is true if the objects are deployed with a client-side apply
+	clientSide bool
+}
+
+// NewPlanner creates a new planner for the current kube context that plans with the given field manager.
+// If clientSide is true, the objects are deployed with a client-side apply instead of server-side apply.
+func NewPlanner(ctx devspacecontext.Context, fieldManager string, clientSide bool) (*Planner, error) {
+	applier, err := newServerSideApplier(ctx.KubeClient().RestConfig(), fieldManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Planner{applier: applier, clientSide: clientSide}, nil
+}
+
+// Plan returns the changes a deploy of the given objects would make in the cluster. Previous
+// objects that are not part of the given objects anymore are returned as deletions. Objects
+// without a namespace are planned in the given namespace. Objects of an unknown kind are
+// returned as creations, custom resources whose definition is changed by the same deploy are
+// returned as creations or as updates without fields if the dry-run fails.
+//
+// The changed fields are the result of a forced server-side dry-run, which does not remove
+// fields that were set by a client-side apply. For client-side planners these fields are taken
+// from the last applied configuration instead. Fields that helm would remove with its three-way
+// merge don't show up.
+func (p *Planner) Plan(ctx context.Context, namespace string, objects []*unstructured.Unstructured, previous []remotecache.KubectlObject) ([]Change, error) {
+	defined := map[schema.GroupKind]bool{}
+	for _, object := range objects {
+		if object.Object == nil {
+			continue
+		}
+		if groupKind, ok := definedKind(object); ok {
+			defined[groupKind] = true
+		}
+	}
+
+	changes := []Change{}
+	current := []remotecache.KubectlObject{}
+	for _, object := range objects {
+		if object.Object == nil {
+			continue
+		}
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespace)
+		}
+
+		kubeObject := toKubectlObject(object)
+		current = append(current, kubeObject)
+
+		live, applied, _, err := p.applier.DryRun(ctx, object)
+		if err != nil {
+			if !defined[object.GroupVersionKind().GroupKind()] {
+				return nil, err
+			}
+
+			// the dry-run validates against the current definition
+			exists, err := p.exists(ctx, kubeObject)
+			if err != nil {
+				return nil, err
+			} else if exists {
+				changes = append(changes, Change{Action: ActionUpdate, Object: kubeObject})
+			} else {
+				changes = append(changes, Change{Action: ActionCreate, Object: kubeObject})
+			}
+			continue
+		} else if live == nil {
+			changes = append(changes, Change{Action: ActionCreate, Object: kubeObject})
+			continue
+		}
+
+		fields := diffFields("", withoutServerFields(applied), withoutServerFields(live))
+		if p.clientSide {
+			fields = append(fields, removedFields(object, live, fields)...)
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Action: ActionUpdate, Object: kubeObject, Fields: fields})
+		}
+	}
+
+	for _, object := range previous {
+		if object.Namespace == "" {
+			object.Namespace = namespace
+		}
+		if containsObject(current, object) {
+			continue
+		}
+
+		exists, err := p.exists(ctx, object)
+		if err != nil {
+			return nil, err
+		} else if exists {
+			changes = append(changes, Change{Action: ActionDelete, Object: object})
+		}
+	}
+
+	return changes, nil
+}
+
+// exists checks if the object exists in the cluster
+func (p *Planner) exists(ctx context.Context, object remotecache.KubectlObject) (bool, error) {
+	client, err := p.applier.resource(object.APIVersion, object.Kind, object.Namespace)
+	if isUnknownKind(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	_, err = client.Get(ctx, object.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "get %s %s", object.Kind, object.Name)
+	}
+
+	return true, nil
+}
+
+// removedFields returns the fields of the last applied configuration that are not part of the
+// object anymore and would be removed from the live object by a client-side apply. Fields that
+// are part of changes already are skipped.
+func removedFields(object, live *unstructured.Unstructured, changes []FieldChange) []FieldChange {
+	lastApplied := map[string]interface{}{}
+	if json.Unmarshal([]byte(live.GetAnnotations()[lastAppliedAnnotation]), &lastApplied) != nil {
+		return nil
+	}
+
+	changed := map[string]bool{}
+	for _, change := range changes {
+		changed[change.Path] = true
+	}
+
+	removed := []FieldChange{}
+	for _, field := range missingFields("", lastApplied, object.Object, live.Object) {
+		if !changed[field.Path] {
+			removed = append(removed, field)
+		}
+	}
+
+	return removed
+}
+
+// missingFields returns the fields that exist in lastApplied and live but not in expected
+// with their live value
+func missingFields(path string, lastApplied, expected, live map[string]interface{}) []FieldChange {
+	keys := make([]string, 0, len(lastApplied))
+	for key := range lastApplied {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changes := []FieldChange{}
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+
+		liveValue, ok := live[key]
+		if !ok {
+			continue
+		}
+
+		expectedValue, ok := expected[key]
+		if !ok {
+			changes = append(changes, FieldChange{Path: childPath, Actual: liveValue})
+			continue
+		}
+
+		lastAppliedMap, lastAppliedOk := lastApplied[key].(map[string]interface{})
+		expectedMap, expectedOk := expectedValue.(map[string]interface{})
+		liveMap, liveOk := liveValue.(map[string]interface{})
+		if lastAppliedOk && expectedOk && liveOk {
+			changes = append(changes, missingFields(childPath, lastAppliedMap, expectedMap, liveMap)...)
+		}
+	}
+
+	return changes
+}
+
+// PrintChanges writes a colored, kubectl diff like report of the changes to out
+func PrintChanges(out io.Writer, deployment string, changes []Change) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(out, "%s: no changes\n\n", ansi.Color(deployment, "white+b"))
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "%s:\n", ansi.Color(deployment, "white+b"))
+	for _, change := range changes {
+		object := change.Object.Kind + " " + change.Object.Name
+		if change.Object.Namespace != "" {
+			object = change.Object.Kind + " " + change.Object.Namespace + "/" + change.Object.Name
+		}
+
+		switch change.Action {
+		case ActionCreate:
+			_, _ = fmt.Fprintln(out, ansi.Color("  + "+object+" will be created", "green+b"))
+		case ActionDelete:
+			_, _ = fmt.Fprintln(out, ansi.Color("  - "+object+" will be deleted", "red+b"))
+		case ActionUpdate:
+			_, _ = fmt.Fprintln(out, ansi.Color("  ~ "+object+" will be updated", "yellow+b"))
+			for _, field := range change.Fields {
+				_, _ = fmt.Fprintf(out, "      %s\n", field.Path)
+				if field.Actual != nil {
+					_, _ = fmt.Fprintln(out, ansi.Color("      - "+formatValue(field.Actual), "red"))
+				}
+				if field.Expected != nil {
+					_, _ = fmt.Fprintln(out, ansi.Color("      + "+formatValue(field.Expected), "green"))
+				}
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+}
+
+func formatValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(out)
+}
+
+// withoutServerFields returns the object without the fields the api server changes on every write
+func withoutServerFields(object *unstructured.Unstructured) map[string]interface{} {
+	object = object.DeepCopy()
+	unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(object.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(object.Object, "metadata", "generation")
+	return object.Object
+}
+
+// diffFields returns the fields that are different in expected and actual. Fields that only exist
+// in expected are returned without actual value and fields that only exist in actual without
+// expected value.
+func diffFields(path string, expected, actual interface{}) []FieldChange {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return []FieldChange{{Path: path, Expected: expected, Actual: actual}}
+		}
+
+		keys := make([]string, 0, len(e)+len(a))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := e[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		changes := []FieldChange{}
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			expectedValue, expectedOk := e[key]
+			actualValue, actualOk := a[key]
+			if !actualOk {
+				changes = append(changes, FieldChange{Path: childPath, Expected: expectedValue})
+			} else if !expectedOk {
+				changes = append(changes, FieldChange{Path: childPath, Actual: actualValue})
+			} else {
+				changes = append(changes, diffFields(childPath, expectedValue, actualValue)...)
+			}
+		}
+		return changes
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			return []FieldChange{{Path: path, Expected: expected, Actual: actual}}
+		}
+
+		changes := []FieldChange{}
+		for i := range e {
+			changes = append(changes, diffFields(path+"["+strconv.Itoa(i)+"]", e[i], a[i])...)
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		return []FieldChange{{Path: path, Expected: expected, Actual: actual}}
+	}
+
+	return nil
+}
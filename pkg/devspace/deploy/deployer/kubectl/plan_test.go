@@ -0,0 +1,210 @@
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"gotest.tools/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+const plannedManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: backend:v2
+        name: backend
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: test
+data:
+  key: value
+`
+
+func TestPlan(t *testing.T) {
+	objects, err := postrender.ParseManifests(plannedManifests)
+	assert.NilError(t, err)
+
+	// the live deployment runs the old image and has a sidecar that was injected by a webhook
+	// and defaults that are not part of the manifests
+	live := objects[0].DeepCopy()
+	live.SetNamespace("test")
+	live.SetResourceVersion("1")
+	assert.NilError(t, unstructured.SetNestedField(live.Object, int64(1), "spec", "replicas"))
+	assert.NilError(t, unstructured.SetNestedField(live.Object, "RollingUpdate", "spec", "strategy", "type"))
+	assert.NilError(t, unstructured.SetNestedSlice(live.Object, []interface{}{
+		map[string]interface{}{
+			"name":            "backend",
+			"image":           "backend:v1",
+			"imagePullPolicy": "IfNotPresent",
+		},
+		map[string]interface{}{
+			"name":  "proxy",
+			"image": "proxy:v1",
+		},
+	}, "spec", "template", "spec", "containers"))
+
+	// the dry-run applies the same defaults and webhooks, so only the changed fields differ
+	applied := live.DeepCopy()
+	applied.SetResourceVersion("2")
+	assert.NilError(t, unstructured.SetNestedField(applied.Object, int64(2), "spec", "replicas"))
+	containers, _, _ := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "containers")
+	containers[0].(map[string]interface{})["image"] = "backend:v2"
+	assert.NilError(t, unstructured.SetNestedSlice(applied.Object, containers, "spec", "template", "spec", "containers"))
+
+	removed := &unstructured.Unstructured{}
+	removed.SetAPIVersion("v1")
+	removed.SetKind("ConfigMap")
+	removed.SetName("removed")
+	removed.SetNamespace("test")
+
+	applier, client := newTestApplier(live, removed)
+	client.PrependReactor("patch", "deployments", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(kubetesting.PatchAction)
+		assert.Equal(t, patchAction.GetPatchType(), types.ApplyPatchType)
+		return true, applied, nil
+	})
+
+	planner := &Planner{applier: applier}
+	changes, err := planner.Plan(context.Background(), "test", objects, []remotecache.KubectlObject{
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "backend", Namespace: "test"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "removed", Namespace: "test"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "already-deleted", Namespace: "test"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 3)
+
+	assert.Equal(t, changes[0].Action, ActionUpdate)
+	assert.Equal(t, changes[0].Object.Namespace, "test")
+	assert.Equal(t, len(changes[0].Fields), 2)
+	assert.Equal(t, changes[0].Fields[0].Path, "spec.replicas")
+	assert.Equal(t, changes[0].Fields[1].Path, "spec.template.spec.containers[0].image")
+	assert.Equal(t, changes[0].Fields[1].Actual, "backend:v1")
+	assert.Equal(t, changes[0].Fields[1].Expected, "backend:v2")
+
+	assert.Equal(t, changes[1].Action, ActionCreate)
+	assert.Equal(t, changes[1].Object.Name, "config")
+
+	assert.Equal(t, changes[2].Action, ActionDelete)
+	assert.Equal(t, changes[2].Object.Name, "removed")
+
+	out := &bytes.Buffer{}
+	PrintChanges(out, "backend", changes)
+	assert.Assert(t, strings.Contains(out.String(), "~ Deployment test/backend will be updated"))
+	assert.Assert(t, strings.Contains(out.String(), "- backend:v1"))
+	assert.Assert(t, strings.Contains(out.String(), "+ backend:v2"))
+	assert.Assert(t, strings.Contains(out.String(), "+ ConfigMap test/config will be created"))
+	assert.Assert(t, strings.Contains(out.String(), "- ConfigMap test/removed will be deleted"))
+}
+
+func TestPlanWithoutChanges(t *testing.T) {
+	objects, err := postrender.ParseManifests(plannedManifests)
+	assert.NilError(t, err)
+
+	// the dry-run returns the live object, the plan must not report defaults as update
+	live := objects[0].DeepCopy()
+	live.SetNamespace("test")
+	assert.NilError(t, unstructured.SetNestedField(live.Object, "RollingUpdate", "spec", "strategy", "type"))
+
+	applier, client := newTestApplier(live)
+	client.PrependReactor("patch", "deployments", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		return true, live, nil
+	})
+
+	planner := &Planner{applier: applier}
+	changes, err := planner.Plan(context.Background(), "test", objects[:1], nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 0)
+}
+
+func TestPlanCustomResources(t *testing.T) {
+	objects, err := postrender.ParseManifests(customResourceManifests)
+	assert.NilError(t, err)
+
+	// neither the definition nor the kind exist yet
+	applier, discoveryClient, client := newTestDiscoveryApplier()
+	planner := &Planner{applier: applier}
+	changes, err := planner.Plan(context.Background(), "test", objects, []remotecache.KubectlObject{
+		{APIVersion: "example.com/v1", Kind: "Restore", Name: "removed", Namespace: "test"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 2)
+	assert.Equal(t, changes[0].Action, ActionCreate)
+	assert.Equal(t, changes[0].Object.Kind, "Backup")
+	assert.Equal(t, changes[1].Action, ActionCreate)
+	assert.Equal(t, changes[1].Object.Kind, "CustomResourceDefinition")
+
+	// the existing custom resource doesn't validate against the current definition
+	live := objects[0].DeepCopy()
+	applier, discoveryClient, client = newTestDiscoveryApplier(live)
+	discoveryClient.Resources = append(discoveryClient.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "backups", Kind: "Backup", Namespaced: true}},
+	})
+	client.PrependReactor("patch", "backups", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewBadRequest("spec.schedule: unknown field")
+	})
+
+	planner = &Planner{applier: applier}
+	changes, err = planner.Plan(context.Background(), "test", objects, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 2)
+	assert.Equal(t, changes[0].Action, ActionUpdate)
+	assert.Equal(t, len(changes[0].Fields), 0)
+	assert.Equal(t, changes[1].Action, ActionCreate)
+
+	// without the definition in the plan the failed dry-run is an error
+	_, err = planner.Plan(context.Background(), "test", objects[:1], nil)
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestPlanClientSideApply(t *testing.T) {
+	objects, err := postrender.ParseManifests(plannedManifests)
+	assert.NilError(t, err)
+
+	// the live deployment was paused by the last client-side apply, which was removed from the manifests
+	live := objects[0].DeepCopy()
+	live.SetNamespace("test")
+	assert.NilError(t, unstructured.SetNestedField(live.Object, true, "spec", "paused"))
+	lastApplied, err := live.MarshalJSON()
+	assert.NilError(t, err)
+	live.SetAnnotations(map[string]string{lastAppliedAnnotation: string(lastApplied)})
+
+	// the forced server-side dry-run keeps the field, since it belongs to the client-side apply
+	applier, client := newTestApplier(live)
+	client.PrependReactor("patch", "deployments", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		return true, live, nil
+	})
+
+	planner := &Planner{applier: applier}
+	changes, err := planner.Plan(context.Background(), "test", objects[:1], nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 0)
+
+	planner.clientSide = true
+	changes, err = planner.Plan(context.Background(), "test", objects[:1], nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 1)
+	assert.Equal(t, changes[0].Action, ActionUpdate)
+	assert.Equal(t, len(changes[0].Fields), 1)
+	assert.Equal(t, changes[0].Fields[0].Path, "spec.paused")
+	assert.Equal(t, changes[0].Fields[0].Actual, true)
+	assert.Equal(t, changes[0].Fields[0].Expected, nil)
+}
@@ -0,0 +1,135 @@
+package kubectl
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// customResourceDefinition is the group kind of custom resource definitions
+var customResourceDefinition = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+var (
+	resourceClientsMutex sync.Mutex
+	resourceClients      = map[*rest.Config]*resourceClient{}
+)
+
+// resourceClient maps objects to their api resources and accesses them via the dynamic client
+type resourceClient struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+// newResourceClient returns the resource client for the rest config. Clients are shared
+// between deployments, so the api resources are only discovered once and only for the
+// group versions that are actually deployed.
+func newResourceClient(restConfig *rest.Config) (*resourceClient, error) {
+	resourceClientsMutex.Lock()
+	defer resourceClientsMutex.Unlock()
+
+	if resourceClients[restConfig] != nil {
+		return resourceClients[restConfig], nil
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create discovery client")
+	}
+
+	// the deferred mapper only discovers the resources once, it is reset if a kind is unknown
+	resourceClients[restConfig] = &resourceClient{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}
+	return resourceClients[restConfig], nil
+}
+
+func (r *resourceClient) resource(apiVersion, kind, namespace string) (dynamic.ResourceInterface, error) {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse api version %s", apiVersion)
+	}
+
+	mapping, err := r.mapper.RESTMapping(groupVersion.WithKind(kind).GroupKind(), groupVersion.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind might have been defined in the meantime, e.g. by a custom resource
+		// definition that was deployed, so the resources are discovered again
+		if mapper, ok := r.mapper.(meta.ResettableRESTMapper); ok {
+			mapper.Reset()
+			mapping, err = r.mapper.RESTMapping(groupVersion.WithKind(kind).GroupKind(), groupVersion.Version)
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "find resource for %s %s", apiVersion, kind)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return r.client.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+
+	return r.client.Resource(mapping.Resource), nil
+}
+
+// waitForResource waits until the kind of the object is served by the api server, which takes
+// a moment after its custom resource definition was applied
+func (r *resourceClient) waitForResource(ctx context.Context, object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	var (
+		client dynamic.ResourceInterface
+		err    error
+	)
+	waitErr := wait.PollImmediateWithContext(ctx, time.Second, time.Minute, func(context.Context) (bool, error) {
+		client, err = r.resource(object.GetAPIVersion(), object.GetKind(), object.GetNamespace())
+		return !isUnknownKind(err), nil
+	})
+	if waitErr != nil && err == nil {
+		return nil, waitErr
+	}
+
+	return client, err
+}
+
+// isUnknownKind checks if the error was returned because the kind is not served by the api server
+func isUnknownKind(err error) bool {
+	return meta.IsNoMatchError(errors.Cause(err))
+}
+
+// definedKind returns the group kind that is defined by the object if it is a custom resource definition
+func definedKind(object *unstructured.Unstructured) (schema.GroupKind, bool) {
+	if object.GroupVersionKind().GroupKind() != customResourceDefinition {
+		return schema.GroupKind{}, false
+	}
+
+	group, _, _ := unstructured.NestedString(object.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(object.Object, "spec", "names", "kind")
+	return schema.GroupKind{Group: group, Kind: kind}, kind != ""
+}
+
+// definitionsFirst returns the objects with the custom resource definitions in front of the other
+// objects, so that custom resources can be applied together with their definition
+func definitionsFirst(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sorted := append([]*unstructured.Unstructured{}, objects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, iDefinition := definedKind(sorted[i])
+		_, jDefinition := definedKind(sorted[j])
+		return iDefinition && !jDefinition
+	})
+
+	return sorted
+}
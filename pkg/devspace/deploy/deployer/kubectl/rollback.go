@@ -0,0 +1,88 @@
+package kubectl
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"github.com/loft-sh/utils/pkg/command"
+	"github.com/loft-sh/utils/pkg/downloader"
+	"github.com/loft-sh/utils/pkg/downloader/commands"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Rollback re-applies the manifests that were stored in the given deploy record and deletes
+// the objects that were deployed afterwards but are not part of the record
+func Rollback(ctx devspacecontext.Context, deploymentCache *remotecache.DeploymentCache, record remotecache.DeploymentRecord) error {
+	manifests, err := record.GetManifests()
+	if err != nil {
+		return errors.Wrap(err, "decode manifests")
+	} else if strings.TrimSpace(manifests) == "" {
+		return errors.Errorf("revision %d has no stored manifests", record.Revision)
+	}
+
+	objects, err := postrender.ParseManifests(manifests)
+	if err != nil {
+		return err
+	}
+
+	kubeObjects := []remotecache.KubectlObject{}
+	for _, resource := range objects {
+		if resource.Object == nil {
+			continue
+		}
+
+		kubeObjects = append(kubeObjects, remotecache.KubectlObject{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Name:       resource.GetName(),
+			Namespace:  resource.GetNamespace(),
+		})
+	}
+
+	cmdPath, err := downloader.NewDownloader(commands.NewKubectlCommand(), ctx.Log(), constants.DefaultHomeDevSpaceFolder).EnsureCommand(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	d := &DeployConfig{
+		CmdPath:     cmdPath,
+		Context:     ctx.KubeClient().CurrentContext(),
+		IsInCluster: ctx.KubeClient().IsInCluster(),
+	}
+
+	writer := ctx.Log().Writer(logrus.InfoLevel, false)
+	defer writer.Close()
+
+	stdErrBuffer := &bytes.Buffer{}
+	err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(manifests), d.CmdPath, d.getCmdArgs("apply", "--force")...)
+	if err != nil {
+		return errors.Errorf("%v %v", stdErrBuffer.String(), err)
+	}
+
+	if deploymentCache.Kubectl != nil {
+		pruneObjects(ctx, deploymentCache.Kubectl.Objects, kubeObjects)
+	} else {
+		deploymentCache.Kubectl = &remotecache.KubectlCache{}
+	}
+
+	deploymentCache.Kubectl.Objects = kubeObjects
+	deploymentCache.Kubectl.ManifestsHash = record.ValuesHash
+	deploymentCache.DeploymentConfigHash = record.DeploymentConfigHash
+	return nil
+}
+
+func containsObject(objects []remotecache.KubectlObject, object remotecache.KubectlObject) bool {
+	for _, o := range objects {
+		if o == object {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,419 @@
+package postrender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/patch"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
+	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/utils/pkg/command"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"mvdan.cc/sh/v3/expand"
+	jsonyaml "sigs.k8s.io/yaml"
+)
+
+// CommandName is the name of the hidden devspace command that is used as helm post renderer
+const CommandName = "post-render"
+
+// renderedManifestsFile is the file the rendered manifests are written to within the kustomize overlay
+const renderedManifestsFile = "devspace-rendered.yaml"
+
+var kustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// Render applies the post render config to the rendered objects. Relative paths in the config
+// are resolved against dir.
+func Render(ctx context.Context, dir string, environ expand.Environ, config *latest.PostRenderConfig, objects []*unstructured.Unstructured, log log.Logger) ([]*unstructured.Unstructured, error) {
+	if config == nil {
+		return objects, nil
+	}
+
+	var err error
+	if len(config.Patches) > 0 {
+		for i, object := range objects {
+			objects[i], err = ApplyPatches(object, config.Patches, log)
+			if err != nil {
+				return nil, errors.Wrapf(err, "patch %s %s", object.GetKind(), object.GetName())
+			}
+		}
+	}
+
+	if config.Kustomize == "" && config.Command == "" {
+		return objects, nil
+	}
+
+	manifests, err := MarshalManifests(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Kustomize != "" {
+		overlay := config.Kustomize
+		if !filepath.IsAbs(overlay) {
+			overlay = filepath.Join(dir, overlay)
+		}
+
+		manifests, err = buildKustomizeOverlay(ctx, dir, environ, overlay, manifests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "build kustomize overlay %s", config.Kustomize)
+		}
+	}
+
+	if config.Command != "" {
+		manifests, err = runCommand(ctx, dir, environ, config, manifests)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ParseManifests(manifests)
+}
+
+// RenderManifests applies the post render config to the given yaml manifests
+func RenderManifests(ctx context.Context, dir string, environ expand.Environ, config *latest.PostRenderConfig, manifests string, log log.Logger) (string, error) {
+	objects, err := ParseManifests(manifests)
+	if err != nil {
+		return "", err
+	}
+
+	objects, err = Render(ctx, dir, environ, config, objects, log)
+	if err != nil {
+		return "", err
+	}
+
+	return MarshalManifests(objects)
+}
+
+// ApplyPatches applies all patches whose target matches the resource
+func ApplyPatches(resource *unstructured.Unstructured, patches []*latest.PatchTarget, log log.Logger) (*unstructured.Unstructured, error) {
+	out, err := jsonyaml.Marshal(resource)
+	if err != nil {
+		return resource, err
+	}
+
+	operations := patch.Patch{}
+	for idx, kubepatch := range patches {
+		newPatch := patch.Operation{
+			Op:   patch.Op(kubepatch.Operation),
+			Path: patch.OpPath(patch.TransformPath(kubepatch.Path)),
+		}
+
+		if kubepatch.Target.Name != resource.GetName() {
+			continue
+		}
+
+		// non-mandatory field, check only if defined
+		if kubepatch.Target.Kind != "" && resource.GetKind() != kubepatch.Target.Kind {
+			log.Debugf("skipping patch, resource kind match: %s - %s", kubepatch.Target.Kind, resource.GetKind())
+			continue
+		}
+
+		// non-mandatory field, check only if defined
+		if kubepatch.Target.APIVersion != "" && resource.GetAPIVersion() != kubepatch.Target.APIVersion {
+			log.Debugf("skipping patch, resource api mismatch: %s - %s", kubepatch.Target.APIVersion, resource.GetAPIVersion())
+			continue
+		}
+
+		if kubepatch.Value != nil {
+			value, err := patch.NewNode(&kubepatch.Value)
+			if err != nil {
+				return resource, errors.Errorf("value %d is invalid", idx)
+			}
+			newPatch.Value = value
+		}
+
+		log.Debugf("applying patch: %s.%s", kubepatch.Target.Name, kubepatch.Path)
+		operations = append(operations, newPatch)
+	}
+	if len(operations) == 0 {
+		return resource, nil
+	}
+
+	out, err = operations.Apply(out)
+	if err != nil {
+		return resource, errors.Wrap(err, "apply patches")
+	}
+
+	// transform resource back to unstructured
+	var result unstructured.Unstructured
+	err = jsonyaml.Unmarshal(out, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+var documentSeparator = regexp.MustCompile(`\n---`)
+
+// ParseManifests splits a YAML file into unstructured objects. Returns a list of all unstructured objects
+func ParseManifests(out string) ([]*unstructured.Unstructured, error) {
+	parts := documentSeparator.Split(out, -1)
+	var objs []*unstructured.Unstructured
+	var firstErr error
+	for _, part := range parts {
+		var objMap map[string]interface{}
+		err := jsonyaml.Unmarshal([]byte(part), &objMap)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to unmarshal manifest: %v", err)
+			}
+			continue
+		}
+		if len(objMap) == 0 {
+			// handles case where theres no content between `---`
+			continue
+		}
+		var obj unstructured.Unstructured
+		err = jsonyaml.Unmarshal([]byte(part), &obj)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to unmarshal manifest: %v", err)
+			}
+			continue
+		}
+		objs = append(objs, &obj)
+	}
+	return objs, firstErr
+}
+
+// MarshalManifests converts the objects into a multi document yaml
+func MarshalManifests(objects []*unstructured.Unstructured) (string, error) {
+	manifests := []string{}
+	for _, object := range objects {
+		out, err := jsonyaml.Marshal(object)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		manifests = append(manifests, string(out))
+	}
+
+	return strings.Join(manifests, "\n---\n"), nil
+}
+
+// buildKustomizeOverlay copies the overlay into a temporary directory next to it, adds the rendered manifests
+// as resource to its kustomization and builds it. This makes sure the overlay directory itself is not modified,
+// while relative references such as ../base still resolve to the same directories.
+func buildKustomizeOverlay(ctx context.Context, dir string, environ expand.Environ, overlay, manifests string) (string, error) {
+	tempDir, err := os.MkdirTemp(filepath.Dir(overlay), ".devspace-post-render-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = fsutil.Copy(overlay, tempDir, true)
+	if err != nil {
+		return "", errors.Wrap(err, "copy overlay")
+	}
+
+	kustomizationPath := ""
+	for _, name := range kustomizationFiles {
+		_, err := os.Stat(filepath.Join(tempDir, name))
+		if err == nil {
+			kustomizationPath = filepath.Join(tempDir, name)
+			break
+		}
+	}
+	if kustomizationPath == "" {
+		return "", errors.Errorf("couldn't find a kustomization.yaml in %s", overlay)
+	}
+
+	out, err := os.ReadFile(kustomizationPath)
+	if err != nil {
+		return "", err
+	}
+
+	kustomization := map[string]interface{}{}
+	err = yaml.Unmarshal(out, &kustomization)
+	if err != nil {
+		return "", errors.Wrap(err, "parse kustomization")
+	}
+
+	resources, _ := kustomization["resources"].([]interface{})
+	kustomization["resources"] = append([]interface{}{renderedManifestsFile}, resources...)
+	out, err = yaml.Marshal(kustomization)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(kustomizationPath, out, 0666)
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(filepath.Join(tempDir, renderedManifestsFile), []byte(manifests), 0666)
+	if err != nil {
+		return "", err
+	}
+
+	kustomizePath, args := "kustomize", []string{"build", tempDir}
+	if _, err := exec.LookPath(kustomizePath); err != nil {
+		kubectlPath, err := exec.LookPath("kubectl")
+		if err != nil {
+			return "", errors.New("neither kustomize nor kubectl was found in the PATH")
+		}
+
+		kustomizePath, args = kubectlPath, []string{"kustomize", tempDir}
+	}
+
+	output, err := command.Output(ctx, dir, environ, kustomizePath, args...)
+	if err != nil {
+		exitError, ok := err.(*exec.ExitError)
+		if ok {
+			return "", errors.New(string(exitError.Stderr))
+		}
+
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+func runCommand(ctx context.Context, dir string, environ expand.Environ, config *latest.PostRenderConfig, manifests string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	var err error
+	if config.Args == nil {
+		err = engine.ExecuteSimpleShellCommand(ctx, dir, environ, stdout, stderr, strings.NewReader(manifests), config.Command)
+	} else {
+		err = command.Command(ctx, dir, environ, stdout, stderr, strings.NewReader(manifests), config.Command, config.Args...)
+	}
+	if err != nil {
+		return "", errors.Errorf("run post renderer '%s': %v\n%s", config.Command, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// Hash returns a hash of the local files the post render config uses, which are the kustomize
+// overlay and files that are passed to the command, such as the script it executes
+func Hash(dir string, config *latest.PostRenderConfig) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+
+	postRenderHash := ""
+	if config.Kustomize != "" {
+		overlay := config.Kustomize
+		if !filepath.IsAbs(overlay) {
+			overlay = filepath.Join(dir, overlay)
+		}
+
+		overlayHash, err := hash.Directory(overlay)
+		if err != nil {
+			return "", errors.Wrapf(err, "hash %s", overlay)
+		}
+
+		postRenderHash += overlayHash
+	}
+	if config.Command != "" {
+		words := append([]string{config.Command}, config.Args...)
+		if config.Args == nil {
+			words = strings.Fields(config.Command)
+		}
+
+		// hash the contents of the files, so that changed scripts are detected
+		for _, word := range words {
+			path := word
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+
+			stat, err := os.Stat(path)
+			if err != nil || !stat.Mode().IsRegular() {
+				continue
+			}
+
+			fileHash, err := hash.File(path)
+			if err != nil {
+				return "", errors.Wrapf(err, "hash %s", path)
+			}
+
+			postRenderHash += fileHash
+		}
+	}
+
+	return postRenderHash, nil
+}
+
+// helmPostRenderer is the config that is passed to the helm post renderer
+type helmPostRenderer struct {
+	Dir     string                   `json:"dir"`
+	Environ []string                 `json:"environ"`
+	Config  *latest.PostRenderConfig `json:"config"`
+}
+
+// HelmArgs writes the post render config and the environment into a temporary file, which only
+// the current user can read, and returns the arguments that let helm call devspace as post renderer.
+// The returned file should be removed after helm is done.
+func HelmArgs(dir string, environ expand.Environ, config *latest.PostRenderConfig) ([]string, string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "find devspace executable")
+	}
+
+	variables := []string{}
+	environ.Each(func(name string, vr expand.Variable) bool {
+		if vr.Exported && vr.Kind == expand.String {
+			variables = append(variables, name+"="+vr.Str)
+		}
+		return true
+	})
+
+	out, err := json.Marshal(&helmPostRenderer{
+		Dir:     dir,
+		Environ: variables,
+		Config:  config,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	tempFile, err := os.CreateTemp("", "devspace-post-render-*.json")
+	if err != nil {
+		return nil, "", err
+	}
+	defer tempFile.Close()
+
+	_, err = tempFile.Write(out)
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return nil, "", err
+	}
+
+	return []string{
+		"--post-renderer", executable,
+		"--post-renderer-args", CommandName,
+		"--post-renderer-args", tempFile.Name(),
+	}, tempFile.Name(), nil
+}
+
+// RunHelmPostRenderer is called by helm with the rendered manifests and the file created by HelmArgs.
+// Commands run with the environment of the deployment.
+func RunHelmPostRenderer(ctx context.Context, file string, manifests string) (string, error) {
+	out, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	postRenderer := &helmPostRenderer{}
+	err = json.Unmarshal(out, postRenderer)
+	if err != nil {
+		return "", errors.Wrap(err, "parse post render config")
+	}
+
+	return RenderManifests(ctx, postRenderer.Dir, expand.ListEnviron(postRenderer.Environ...), postRenderer.Config, manifests, log.Discard)
+}
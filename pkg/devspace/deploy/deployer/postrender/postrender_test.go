@@ -0,0 +1,172 @@
+package postrender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"mvdan.cc/sh/v3/expand"
+)
+
+const testManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+spec:
+  type: ClusterIP
+`
+
+func TestRenderPatches(t *testing.T) {
+	config := &latest.PostRenderConfig{
+		Patches: []*latest.PatchTarget{
+			{
+				Target:      latest.Target{Kind: "Deployment", Name: "backend"},
+				PatchConfig: latest.PatchConfig{Operation: "replace", Path: "spec.replicas", Value: 3},
+			},
+			{
+				Target:      latest.Target{Kind: "Deployment", Name: "backend"},
+				PatchConfig: latest.PatchConfig{Operation: "add", Path: "metadata.labels", Value: map[string]interface{}{"app": "backend"}},
+			},
+		},
+	}
+
+	out, err := RenderManifests(context.Background(), t.TempDir(), expand.ListEnviron(os.Environ()...), config, testManifests, log.Discard)
+	assert.NilError(t, err)
+
+	objects, err := ParseManifests(out)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 2)
+	assert.Equal(t, objects[0].Object["spec"].(map[string]interface{})["replicas"], int64(3))
+	assert.DeepEqual(t, objects[0].GetLabels(), map[string]string{"app": "backend"})
+	assert.Equal(t, objects[1].Object["spec"].(map[string]interface{})["type"], "ClusterIP")
+}
+
+func TestRenderCommand(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed is not installed")
+	}
+
+	config := &latest.PostRenderConfig{
+		Patches: []*latest.PatchTarget{
+			{
+				Target:      latest.Target{Kind: "Service", Name: "backend"},
+				PatchConfig: latest.PatchConfig{Operation: "replace", Path: "spec.type", Value: "NodePort"},
+			},
+		},
+		Command: "sed 's/name: backend/name: frontend/'",
+	}
+
+	out, err := RenderManifests(context.Background(), t.TempDir(), expand.ListEnviron(os.Environ()...), config, testManifests, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(out, "name: backend"))
+	assert.Assert(t, strings.Contains(out, "type: NodePort"))
+
+	// args are passed without shell
+	config = &latest.PostRenderConfig{
+		Command: "sed",
+		Args:    []string{"s/ClusterIP/LoadBalancer/"},
+	}
+	out, err = RenderManifests(context.Background(), t.TempDir(), expand.ListEnviron(os.Environ()...), config, testManifests, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(out, "type: LoadBalancer"))
+}
+
+func TestRenderKustomize(t *testing.T) {
+	_, kustomizeErr := exec.LookPath("kustomize")
+	_, kubectlErr := exec.LookPath("kubectl")
+	if kustomizeErr != nil && kubectlErr != nil {
+		t.Skip("neither kustomize nor kubectl is installed")
+	}
+
+	// the overlay references a base next to it
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "base"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "base", "kustomization.yaml"), []byte("resources:\n- configmap.yaml\n"), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "base", "configmap.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: base\n"), 0644))
+	err := os.MkdirAll(filepath.Join(dir, "overlay"), 0755)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "overlay", "kustomization.yaml"), []byte("resources:\n- ../base\ncommonLabels:\n  team: platform\n"), 0644)
+	assert.NilError(t, err)
+
+	out, err := RenderManifests(context.Background(), dir, expand.ListEnviron(os.Environ()...), &latest.PostRenderConfig{Kustomize: "overlay"}, testManifests, log.Discard)
+	assert.NilError(t, err)
+	objects, err := ParseManifests(out)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 3)
+	for _, object := range objects {
+		assert.Equal(t, object.GetLabels()["team"], "platform")
+	}
+
+	// the overlay itself is not changed and the temporary copy is removed
+	kustomization, err := os.ReadFile(filepath.Join(dir, "overlay", "kustomization.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(kustomization), "resources:\n- ../base\ncommonLabels:\n  team: platform\n")
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+}
+
+func TestHelmPostRenderer(t *testing.T) {
+	config := &latest.PostRenderConfig{
+		Patches: []*latest.PatchTarget{
+			{
+				Target:      latest.Target{Name: "backend", Kind: "Deployment"},
+				PatchConfig: latest.PatchConfig{Operation: "replace", Path: "spec.replicas", Value: 2},
+			},
+		},
+	}
+
+	config.Command = "sed \"s/replicas: 2/replicas: $REPLICAS/\""
+
+	// the post renderer runs with the environment of the deployment instead of its own
+	args, file, err := HelmArgs(t.TempDir(), expand.ListEnviron("PATH="+os.Getenv("PATH"), "REPLICAS=5"), config)
+	assert.NilError(t, err)
+	defer os.Remove(file)
+	assert.Equal(t, len(args), 6)
+	assert.Equal(t, args[0], "--post-renderer")
+	assert.Equal(t, args[3], CommandName)
+	assert.Equal(t, args[5], file)
+
+	out, err := RunHelmPostRenderer(context.Background(), file, testManifests)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(out, "replicas: 5"))
+}
+
+func TestHash(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "render.sh"), []byte("#!/bin/sh\ncat\n"), 0755))
+
+	for i, config := range []*latest.PostRenderConfig{
+		{Command: "sh ./render.sh"},
+		{Command: "./render.sh"},
+		{Command: "sh", Args: []string{"render.sh"}},
+	} {
+		before, err := Hash(dir, config)
+		assert.NilError(t, err)
+		assert.Assert(t, before != "", "command %s", config.Command)
+
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "render.sh"), []byte(fmt.Sprintf("#!/bin/sh\nsed s/a/%d/\n", i)), 0755))
+		after, err := Hash(dir, config)
+		assert.NilError(t, err)
+		assert.Assert(t, before != after, "command %s", config.Command)
+	}
+
+	// commands from the PATH are not hashed
+	out, err := Hash(dir, &latest.PostRenderConfig{Command: "kbld -f -"})
+	assert.NilError(t, err)
+	assert.Equal(t, out, "")
+}
@@ -0,0 +1,148 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/postrender"
+	"github.com/pkg/errors"
+)
+
+// PlanResult counts the changes of all planned deployments
+type PlanResult struct {
+	m       sync.Mutex
+	planned bool
+
+	Create int
+	Update int
+	Delete int
+}
+
+// HasChanges returns true if any planned deployment would change the cluster
+func (p *PlanResult) HasChanges() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return p.Create+p.Update+p.Delete > 0
+}
+
+// Planned returns true if at least one deployment was planned
+func (p *PlanResult) Planned() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return p.planned
+}
+
+func (p *PlanResult) String() string {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return fmt.Sprintf("%d to create, %d to update, %d to delete", p.Create, p.Update, p.Delete)
+}
+
+func (p *PlanResult) add(changes []kubectl.Change) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.planned = true
+	for _, change := range changes {
+		switch change.Action {
+		case kubectl.ActionCreate:
+			p.Create++
+		case kubectl.ActionUpdate:
+			p.Update++
+		case kubectl.ActionDelete:
+			p.Delete++
+		}
+	}
+}
+
+// plan renders the deployment, compares it with the live objects and prints the changes
+func plan(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, deployClient deployer.Interface, out io.Writer, result *PlanResult) error {
+	buffer := &bytes.Buffer{}
+	err := deployClient.Render(ctx, buffer)
+	if err != nil {
+		return err
+	}
+
+	objects, err := postrender.ParseManifests(buffer.String())
+	if err != nil {
+		return err
+	}
+
+	previous, err := deployedObjects(ctx, deployConfig, deployClient)
+	if err != nil {
+		return errors.Wrap(err, "get deployed objects")
+	}
+
+	fieldManager := ""
+	clientSide := false
+	if deployConfig.Kubectl != nil {
+		fieldManager = deployConfig.Kubectl.FieldManager
+		clientSide = !deployConfig.Kubectl.ServerSideApply
+	}
+
+	planner, err := kubectl.NewPlanner(ctx, fieldManager, clientSide)
+	if err != nil {
+		return err
+	}
+
+	namespace := deployConfig.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	changes, err := planner.Plan(ctx.Context(), namespace, objects, previous)
+	if err != nil {
+		return err
+	}
+
+	kubectl.PrintChanges(out, deployConfig.Name, changes)
+	if result != nil {
+		result.add(changes)
+	}
+	return nil
+}
+
+// deployedObjects returns the objects of the last deploy
+func deployedObjects(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, deployClient deployer.Interface) ([]remotecache.KubectlObject, error) {
+	switch d := deployClient.(type) {
+	case *kubectl.DeployConfig:
+		deployCache, ok := ctx.Config().RemoteCache().GetDeployment(deployConfig.Name)
+		if ok && deployCache.Kubectl != nil {
+			return deployCache.Kubectl.Objects, nil
+		}
+	case *helm.DeployConfig:
+		manifests, err := d.DeployedManifests(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := postrender.ParseManifests(manifests)
+		if err != nil {
+			return nil, err
+		}
+
+		kubeObjects := []remotecache.KubectlObject{}
+		for _, object := range objects {
+			kubeObjects = append(kubeObjects, remotecache.KubectlObject{
+				APIVersion: object.GetAPIVersion(),
+				Kind:       object.GetKind(),
+				Name:       object.GetName(),
+				Namespace:  object.GetNamespace(),
+			})
+		}
+		return kubeObjects, nil
+	}
+
+	return nil, nil
+}
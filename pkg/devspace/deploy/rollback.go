@@ -0,0 +1,112 @@
+package deploy
+
+import (
+	"os/user"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+)
+
+// RollbackOptions describe how the deployments should be rolled back
+type RollbackOptions struct {
+	To int `long:"to" description:"The revision to roll back to, defaults to the previous revision"`
+}
+
+// Rollback rolls back all deployments or a set of deployments to a previous deploy that is stored in the remote cache
+func (c *controller) Rollback(ctx devspacecontext.Context, deployments []string, options *RollbackOptions) error {
+	if options == nil {
+		options = &RollbackOptions{}
+	}
+	if len(deployments) == 0 {
+		deployments = nil
+	} else if options.To > 0 && len(deployments) > 1 {
+		return errors.New("--to can only be used with a single deployment")
+	}
+
+	deploymentCaches := ctx.Config().RemoteCache().ListDeployments()
+	if deployments == nil && options.To > 0 && len(deploymentCaches) > 1 {
+		return errors.New("please specify the deployment to roll back when using --to")
+	}
+
+	for _, name := range deployments {
+		if _, ok := ctx.Config().RemoteCache().GetDeployment(name); !ok {
+			return errors.Errorf("couldn't find deployment %s in the remote cache, has it been deployed?", name)
+		}
+	}
+
+	rolledBack := 0
+	for _, deploymentCache := range deploymentCaches {
+		if deployments != nil && !stringutil.Contains(deployments, deploymentCache.Name) {
+			continue
+		}
+
+		ctx := ctx.WithLogger(ctx.Log().WithPrefix("rollback:" + deploymentCache.Name + " "))
+		target, err := rollbackTarget(&deploymentCache, options.To)
+		if err != nil {
+			if deployments == nil && options.To == 0 {
+				ctx.Log().Infof("Skip rolling back deployment %s: %v", deploymentCache.Name, err)
+				continue
+			}
+
+			return err
+		}
+
+		ctx.Log().Infof("Rolling back deployment %s to revision %d...", deploymentCache.Name, target.Revision)
+		record := target
+		record.Time = time.Now()
+		record.User = ""
+		record.RollbackOf = target.Revision
+		if currentUser, err := user.Current(); err == nil {
+			record.User = currentUser.Username
+		}
+
+		if deploymentCache.Kubectl != nil {
+			err = kubectl.Rollback(ctx, &deploymentCache, target)
+		} else if deploymentCache.Helm != nil {
+			record.HelmRevision, err = helm.Rollback(ctx, &deploymentCache, target)
+		} else {
+			err = errors.Errorf("deployment %s has no deployment method", deploymentCache.Name)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "roll back deployment %s", deploymentCache.Name)
+		}
+
+		deploymentCache.AddHistory(record)
+		ctx.Config().RemoteCache().SetDeployment(deploymentCache.Name, deploymentCache)
+		ctx.Log().Donef("Successfully rolled back deployment %s to revision %d", deploymentCache.Name, target.Revision)
+		rolledBack++
+	}
+
+	if rolledBack == 0 {
+		ctx.Log().Info("No deployments to roll back")
+		return nil
+	}
+
+	return ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+}
+
+func rollbackTarget(deploymentCache *remotecache.DeploymentCache, revision int) (remotecache.DeploymentRecord, error) {
+	if len(deploymentCache.History) == 0 {
+		return remotecache.DeploymentRecord{}, errors.Errorf("deployment %s has no deploy history", deploymentCache.Name)
+	} else if revision == 0 {
+		if len(deploymentCache.History) < 2 {
+			return remotecache.DeploymentRecord{}, errors.Errorf("deployment %s has no previous revision", deploymentCache.Name)
+		}
+
+		return deploymentCache.History[1], nil
+	}
+
+	record, ok := deploymentCache.GetHistory(revision)
+	if !ok {
+		return remotecache.DeploymentRecord{}, errors.Errorf("couldn't find revision %d of deployment %s, the remote cache only keeps the last %d revisions and removes the oldest if the stored manifests get too large", revision, deploymentCache.Name, remotecache.MaxDeploymentHistory)
+	} else if record.Revision == deploymentCache.History[0].Revision {
+		return remotecache.DeploymentRecord{}, errors.Errorf("deployment %s is already at revision %d", deploymentCache.Name, revision)
+	}
+
+	return record, nil
+}
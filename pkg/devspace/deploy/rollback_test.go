@@ -0,0 +1,39 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"gotest.tools/assert"
+)
+
+func TestRollbackTarget(t *testing.T) {
+	deploymentCache := &remotecache.DeploymentCache{Name: "test"}
+	_, err := rollbackTarget(deploymentCache, 0)
+	assert.Error(t, err, "deployment test has no deploy history")
+
+	for i := 0; i < remotecache.MaxDeploymentHistory+2; i++ {
+		record := remotecache.DeploymentRecord{}
+		assert.NilError(t, record.SetManifests("kind: ConfigMap"))
+		deploymentCache.AddHistory(record)
+	}
+	assert.Equal(t, len(deploymentCache.History), remotecache.MaxDeploymentHistory)
+	assert.Equal(t, deploymentCache.History[0].Revision, remotecache.MaxDeploymentHistory+2)
+
+	target, err := rollbackTarget(deploymentCache, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, target.Revision, remotecache.MaxDeploymentHistory+1)
+	manifests, err := target.GetManifests()
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, "kind: ConfigMap")
+
+	target, err = rollbackTarget(deploymentCache, 5)
+	assert.NilError(t, err)
+	assert.Equal(t, target.Revision, 5)
+
+	_, err = rollbackTarget(deploymentCache, 1)
+	assert.Error(t, err, "couldn't find revision 1 of deployment test, the remote cache only keeps the last 10 revisions and removes the oldest if the stored manifests get too large")
+
+	_, err = rollbackTarget(deploymentCache, remotecache.MaxDeploymentHistory+2)
+	assert.Error(t, err, "deployment test is already at revision 12")
+}
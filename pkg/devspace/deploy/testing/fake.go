@@ -23,3 +23,8 @@ func (f *FakeController) Deploy(ctx devspacecontext.Context, deployments []strin
 func (f *FakeController) Purge(ctx devspacecontext.Context, deployments []string, options *deploy.PurgeOptions) error {
 	return nil
 }
+
+// Rollback rolls back the deployments
+func (f *FakeController) Rollback(ctx devspacecontext.Context, deployments []string, options *deploy.RollbackOptions) error {
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/services/logs"
 	"github.com/loft-sh/devspace/pkg/devspace/services/proxycommands"
 	"github.com/loft-sh/devspace/pkg/devspace/services/ssh"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
 	"github.com/loft-sh/devspace/pkg/devspace/services/terminal"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/tomb"
@@ -34,6 +35,7 @@ import (
 	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/services/podreplace"
 	"github.com/loft-sh/devspace/pkg/devspace/services/portforwarding"
@@ -121,6 +123,7 @@ func (d *devPod) Stop() {
 
 func (d *devPod) startWithRetry(ctx devspacecontext.Context, devPodConfig *latest.DevPod, options Options) error {
 	t := &tomb.Tomb{}
+	dependency, _ := values.DependencyNameFrom(ctx.Context())
 
 	go func(ctx devspacecontext.Context) {
 		// wait for parent context cancel
@@ -133,6 +136,7 @@ func (d *devPod) startWithRetry(ctx devspacecontext.Context, devPodConfig *lates
 		if ctx.IsDone() {
 			<-t.Dead()
 			ctx.Log().Debugf("Stopped dev %s", devPodConfig.Name)
+			status.GetRegistry().StopDevPod(dependency, devPodConfig.Name)
 			close(d.done)
 			return
 		}
@@ -177,6 +181,7 @@ func (d *devPod) startWithRetry(ctx devspacecontext.Context, devPodConfig *lates
 		}
 
 		ctx.Log().Debugf("Stopped dev %s", devPodConfig.Name)
+		status.GetRegistry().StopDevPod(dependency, devPodConfig.Name)
 		d.m.Lock()
 		d.err = t.Err()
 		d.m.Unlock()
@@ -286,6 +291,8 @@ func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod,
 	d.m.Lock()
 	d.selectedPod = selectedPod
 	d.m.Unlock()
+	dependency, _ := values.DependencyNameFrom(ctx.Context())
+	status.GetRegistry().StartDevPod(dependency, devPodConfig.Name, selectedPod.Pod.Namespace, selectedPod.Pod.Name, selectedPod.Container.Name)
 
 	// Run dev.open configs
 	if !opts.DisableOpen {
@@ -12,7 +12,8 @@ import (
 
 // Client implements Interface
 type Client struct {
-	Releases []*types.Release
+	Releases  []*types.Release
+	Manifests map[string]string
 }
 
 func (f *Client) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.HelmConfig) (string, error) {
@@ -35,11 +36,31 @@ func (f *Client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return fmt.Errorf("release %s not found", releaseName)
 }
 
+// Rollback rolls a helm release back to the given revision
+func (f *Client) Rollback(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			return nil
+		}
+	}
+	return fmt.Errorf("release %s not found", releaseName)
+}
+
 // ListReleases lists all helm Releases
 func (f *Client) ListReleases(ctx devspacecontext.Context, releaseNamespace string) ([]*types.Release, error) {
 	return f.Releases, nil
 }
 
+// GetManifest returns the manifest of a helm release
+func (f *Client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			return f.Manifests[releaseName], nil
+		}
+	}
+	return "", fmt.Errorf("release %s not found", releaseName)
+}
+
 // InstallChart implements interface
 func (f *Client) InstallChart(ctx devspacecontext.Context, releaseName string, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (*types.Release, error) {
 	for _, release := range f.Releases {
@@ -235,6 +235,49 @@ func (c *client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return nil
 }
 
+func (c *client) Rollback(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		revision,
+		"--wait",
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+	_, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+	out, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (c *client) ListReleases(ctx devspacecontext.Context, namespace string) ([]*types.Release, error) {
 	args := []string{
 		"list",
@@ -0,0 +1,34 @@
+package hook
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
+	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// shouldExecute evaluates the if expression of the hook. Expressions that are not
+// wrapped in $( ) are executed as such, so they can be written like shell conditions.
+func shouldExecute(ctx devspacecontext.Context, hookConfig *latest.HookConfig) (bool, error) {
+	if hookConfig.If == "" {
+		return true, nil
+	}
+
+	condition := hookConfig.If
+	if !expression.ExpressionMatchRegex.MatchString(condition) {
+		condition = "$(" + condition + ")"
+	}
+
+	value, err := runtimevar.NewRuntimeResolver(ctx.WorkingDir(), true).FillRuntimeVariables(ctx.Context(), condition, ctx.Config(), ctx.Dependencies())
+	if err != nil {
+		return false, errors.Wrapf(err, "evaluate if of hook '%s'", hookName(hookConfig))
+	}
+
+	execute, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("if of hook '%s' has to evaluate to true or false, but evaluated to '%v'", hookName(hookConfig), value)
+	}
+
+	return execute, nil
+}
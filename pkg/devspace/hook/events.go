@@ -0,0 +1,179 @@
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// Event is a single record of the event stream. A record is written for
+// every hook event, regardless if there are hooks configured for it.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+
+	Name       string `json:"name,omitempty"`
+	Pipeline   string `json:"pipeline,omitempty"`
+	Dependency string `json:"dependency,omitempty"`
+
+	Image     string   `json:"image,omitempty"`
+	ImageTags []string `json:"imageTags,omitempty"`
+
+	Deployment         string `json:"deployment,omitempty"`
+	DeploymentRevision string `json:"deploymentRevision,omitempty"`
+
+	SyncPath string `json:"syncPath,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+var (
+	eventsMutex  sync.Mutex
+	eventsWriter io.WriteCloser
+)
+
+// StartEventStream opens the given file or unix socket and writes newline delimited
+// json for every hook event into it until CloseEventStream is called
+func StartEventStream(file, socket string) error {
+	var (
+		writer io.WriteCloser
+		err    error
+	)
+	if file != "" && socket != "" {
+		return fmt.Errorf("only one of --events-file and --events-socket can be used")
+	} else if file != "" {
+		writer, err = os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		if err != nil {
+			return errors.Wrap(err, "open events file")
+		}
+	} else if socket != "" {
+		writer, err = net.Dial("unix", socket)
+		if err != nil {
+			return errors.Wrap(err, "connect to events socket")
+		}
+	} else {
+		return nil
+	}
+
+	SetEventWriter(writer)
+	return nil
+}
+
+// SetEventWriter sets the writer the event stream is written to
+func SetEventWriter(writer io.WriteCloser) {
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+
+	eventsWriter = writer
+}
+
+// CloseEventStream closes the event stream if there is one
+func CloseEventStream() error {
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+
+	if eventsWriter == nil {
+		return nil
+	}
+
+	err := eventsWriter.Close()
+	eventsWriter = nil
+	return err
+}
+
+func writeEvents(ctx devspacecontext.Context, extraEnv map[string]interface{}, events []string) {
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+
+	if eventsWriter == nil {
+		return
+	}
+
+	template := newEvent(ctx, extraEnv)
+	for _, e := range events {
+		// wildcard events only exist to match hooks
+		if strings.HasSuffix(e, ":*") {
+			continue
+		}
+
+		event := *template
+		event.Event = e
+		out, err := json.Marshal(&event)
+		if err != nil {
+			continue
+		}
+
+		_, err = eventsWriter.Write(append(out, '\n'))
+		if err != nil {
+			logpkg.GetInstance().Warnf("Stop writing events, because of: %v", err)
+			_ = eventsWriter.Close()
+			eventsWriter = nil
+			return
+		}
+	}
+}
+
+func newEvent(ctx devspacecontext.Context, extraEnv map[string]interface{}) *Event {
+	event := &Event{
+		Time: time.Now(),
+	}
+	if ctx != nil {
+		if ctx.Config() != nil && ctx.Config().Config() != nil {
+			event.Name = ctx.Config().Config().Name
+		}
+		if ctx.Context() != nil {
+			event.Pipeline, _ = values.PipelineFrom(ctx.Context())
+			event.Dependency, _ = values.DependencyNameFrom(ctx.Context())
+		}
+	}
+
+	for key, value := range extraEnv {
+		if value == nil {
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "image_name":
+			event.Image = fmt.Sprintf("%v", value)
+		case "image_tags":
+			if tags, ok := value.([]string); ok {
+				event.ImageTags = tags
+			}
+		case "deploy_name":
+			event.Deployment = fmt.Sprintf("%v", value)
+		case "sync_config":
+			if syncConfig, ok := value.(*latest.SyncConfig); ok {
+				event.SyncPath = syncConfig.Path
+			}
+		case "error":
+			if err, ok := value.(error); ok {
+				event.Error = err.Error()
+			} else {
+				event.Error = fmt.Sprintf("%v", value)
+			}
+		}
+	}
+
+	if event.Deployment != "" && ctx != nil && ctx.Config() != nil && ctx.Config().RemoteCache() != nil {
+		deploymentCache, ok := ctx.Config().RemoteCache().GetDeployment(event.Deployment)
+		if ok && deploymentCache.Helm != nil {
+			event.DeploymentRevision = deploymentCache.Helm.ReleaseRevision
+		} else if ok && len(deploymentCache.History) > 0 {
+			// kubectl and kustomize deployments use the revision of their last deploy record
+			event.DeploymentRevision = strconv.Itoa(deploymentCache.History[0].Revision)
+		}
+	}
+
+	return event
+}
@@ -50,6 +50,9 @@ type Hook interface {
 
 // LogExecuteHooks executes plugin hooks and config hooks and prints errors to the log
 func LogExecuteHooks(ctx devspacecontext.Context, extraEnv map[string]interface{}, events ...string) {
+	// write to the event stream
+	writeEvents(ctx, extraEnv, events)
+
 	// call plugin first
 	plugin.LogExecutePluginHookWithContext(extraEnv, events...)
 
@@ -72,6 +75,9 @@ func LogExecuteHooks(ctx devspacecontext.Context, extraEnv map[string]interface{
 
 // ExecuteHooks executes plugin hooks and config hooks
 func ExecuteHooks(ctx devspacecontext.Context, extraEnv map[string]interface{}, events ...string) error {
+	// write to the event stream
+	writeEvents(ctx, extraEnv, events)
+
 	// call plugin first
 	err := plugin.ExecutePluginHookWithContext(extraEnv, events...)
 	if err != nil {
@@ -124,7 +130,15 @@ func executeSingle(ctx devspacecontext.Context, extraEnv map[string]string, even
 				continue
 			}
 
-			err := runHook(ctx, hookConfig, extraEnv, event)
+			execute, err := shouldExecute(ctx, hookConfig)
+			if err != nil {
+				return err
+			} else if !execute {
+				ctx.Log().Debugf("Skip hook '%s' at %s, because its if condition is false", hookName(hookConfig), event)
+				continue
+			}
+
+			err = runHook(ctx, hookConfig, extraEnv, event)
 			if err != nil {
 				return err
 			}
@@ -146,6 +160,12 @@ func runHook(ctx devspacecontext.Context, hookConfig *latest.HookConfig, extraEn
 	}
 	defer writer.Close()
 
+	// The standard output of hooks with output is captured instead of printed
+	var outWriter io.Writer = writer
+	if hookConfig.Output {
+		outWriter = io.Discard
+	}
+
 	// Decide which hook type to use
 	var hook Hook
 	if hookConfig.Container != nil {
@@ -159,10 +179,10 @@ func runHook(ctx devspacecontext.Context, hookConfig *latest.HookConfig, extraEn
 		} else if hookConfig.Wait != nil {
 			hook = NewWaitHook()
 		} else {
-			hook = NewRemoteHook(NewRemoteCommandHook(writer, writer))
+			hook = NewRemoteHook(NewRemoteCommandHook(outWriter, writer))
 		}
 	} else {
-		hook = NewLocalCommandHook(writer, writer)
+		hook = NewLocalCommandHook(outWriter, writer)
 	}
 
 	// Execute the hook
@@ -1,10 +1,15 @@
 package hook
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/util/log"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
@@ -82,3 +87,154 @@ func TestHookWithExecution(t *testing.T) {
 		t.Fatalf("Failed to execute 1 hook with empty When.After: %v", err)
 	}
 }
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestEventStream(t *testing.T) {
+	remoteCache := &remotecache.RemoteCache{}
+	remoteCache.SetDeployment("backend", remotecache.DeploymentCache{
+		Name: "backend",
+		Helm: &remotecache.HelmCache{ReleaseRevision: "3"},
+	})
+	frontend := remotecache.DeploymentCache{
+		Name:    "frontend",
+		Kubectl: &remotecache.KubectlCache{},
+	}
+	frontend.AddHistory(remotecache.DeploymentRecord{})
+	frontend.AddHistory(remotecache.DeploymentRecord{})
+	remoteCache.SetDeployment("frontend", frontend)
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{Name: "project"},
+		localcache.New(constants.DefaultCacheFolder),
+		remoteCache,
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+	ctx := devspacecontext.NewContext(values.WithDependencyName(context.Background(), "api"), nil, log.Discard).WithConfig(conf)
+
+	buffer := &bytes.Buffer{}
+	SetEventWriter(nopCloser{buffer})
+	defer func() {
+		_ = CloseEventStream()
+	}()
+
+	err := ExecuteHooks(ctx, map[string]interface{}{
+		"IMAGE_NAME": "my-image",
+		"IMAGE_TAGS": []string{"abc"},
+	}, EventsForSingle("after:build", "api")...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ExecuteHooks(ctx, map[string]interface{}{
+		"DEPLOY_NAME": "backend",
+		"ERROR":       fmt.Errorf("deploy failed"),
+	}, "error:deploy:backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ExecuteHooks(ctx, map[string]interface{}{
+		"DEPLOY_NAME": "frontend",
+	}, "after:deploy:frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 events, got %d: %s", len(lines), buffer.String())
+	}
+
+	build := &Event{}
+	err = json.Unmarshal([]byte(lines[0]), build)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if build.Event != "after:build:api" || build.Name != "project" || build.Dependency != "api" || build.Image != "my-image" || len(build.ImageTags) != 1 || build.ImageTags[0] != "abc" {
+		t.Fatalf("unexpected build event %s", lines[0])
+	}
+
+	deploy := &Event{}
+	err = json.Unmarshal([]byte(lines[1]), deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deploy.Deployment != "backend" || deploy.DeploymentRevision != "3" || deploy.Error != "deploy failed" {
+		t.Fatalf("unexpected deploy event %s", lines[1])
+	}
+
+	kubectlDeploy := &Event{}
+	err = json.Unmarshal([]byte(lines[2]), kubectlDeploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kubectlDeploy.Deployment != "frontend" || kubectlDeploy.DeploymentRevision != "2" {
+		t.Fatalf("unexpected kubectl deploy event %s", lines[2])
+	}
+}
+
+func TestHookConditionAndOutput(t *testing.T) {
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{
+			Hooks: []*latest.HookConfig{
+				{
+					Name:    "check",
+					Events:  []string{"my-event"},
+					Command: "echo yes",
+					Output:  true,
+				},
+				{
+					Name:    "skipped",
+					Events:  []string{"my-event"},
+					If:      `[ "${runtime.hooks.check.output}" = "no" ]`,
+					Command: "echo skipped",
+					Output:  true,
+				},
+				{
+					Name:    "executed",
+					Events:  []string{"my-event"},
+					If:      `$([ "${runtime.hooks.check.output}" = "yes" ])`,
+					Command: "echo executed",
+					Output:  true,
+				},
+			},
+		},
+		localcache.New(constants.DefaultCacheFolder),
+		&remotecache.RemoteCache{},
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+
+	err := ExecuteHooks(devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf), nil, "my-event")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runtimeVariables := conf.ListRuntimeVariables()
+	if runtimeVariables["hooks.check.output"] != "yes" {
+		t.Fatalf("unexpected output of hook check: %v", runtimeVariables["hooks.check.output"])
+	}
+	if _, ok := runtimeVariables["hooks.skipped.output"]; ok {
+		t.Fatalf("hook skipped was executed")
+	}
+	if runtimeVariables["hooks.executed.output"] != "executed" {
+		t.Fatalf("unexpected output of hook executed: %v", runtimeVariables["hooks.executed.output"])
+	}
+
+	// conditions have to evaluate to a boolean
+	conf.Config().Hooks = []*latest.HookConfig{{
+		Events:  []string{"my-event"},
+		If:      "echo maybe",
+		Command: "echo",
+	}}
+	err = ExecuteHooks(devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf), nil, "my-event")
+	if err == nil || !strings.Contains(err.Error(), "has to evaluate to true or false") {
+		t.Fatalf("expected error for non boolean condition, got %v", err)
+	}
+}
@@ -72,6 +72,9 @@ func (l *localCommandHook) Execute(ctx devspacecontext.Context, hook *latest.Hoo
 		if hook.Name != "" {
 			ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".stdout", strings.TrimSpace(stdout.String()))
 			ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".stderr", strings.TrimSpace(stderr.String()))
+			if hook.Output {
+				ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".output", strings.TrimSpace(stdout.String()))
+			}
 		}
 	}()
 
@@ -63,6 +63,9 @@ func (r *remoteCommandHook) ExecuteRemotely(ctx devspacecontext.Context, hook *l
 		if hook.Name != "" {
 			ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".stdout", strings.TrimSpace(stdout.String()))
 			ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".stderr", strings.TrimSpace(stderr.String()))
+			if hook.Output {
+				ctx.Config().SetRuntimeVariable("hooks."+hook.Name+".output", strings.TrimSpace(stdout.String()))
+			}
 		}
 	}()
 
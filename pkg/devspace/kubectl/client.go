@@ -78,6 +78,9 @@ type Client interface {
 	// to allow continuous reading. Can also follow a log if specified.
 	Logs(ctx context.Context, namespace, podName, containerName string, lastContainerLog bool, tail *int64, follow bool) (io.ReadCloser, error)
 
+	// LogsWithOptions starts a new logs request to the given pod with the given log options
+	LogsWithOptions(ctx context.Context, namespace, podName string, options *k8sv1.PodLogOptions) (io.ReadCloser, error)
+
 	// IsInCluster returns true if in cluster kubernetes configuration is detected
 	IsInCluster() bool
 }
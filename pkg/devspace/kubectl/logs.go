@@ -30,12 +30,17 @@ func (client *client) Logs(ctx context.Context, namespace, podName, containerNam
 		lines = *tail
 	}
 
-	request := client.KubeClient().CoreV1().RESTClient().Get().Namespace(namespace).Name(podName).Resource("pods").SubResource("log").VersionedParams(&v1.PodLogOptions{
+	return client.LogsWithOptions(ctx, namespace, podName, &v1.PodLogOptions{
 		Container: containerName,
 		TailLines: &lines,
 		Previous:  lastContainerLog,
 		Follow:    follow,
-	}, scheme.ParameterCodec)
+	})
+}
+
+// LogsWithOptions starts a new logs request with the given options
+func (client *client) LogsWithOptions(ctx context.Context, namespace, podName string, options *v1.PodLogOptions) (io.ReadCloser, error) {
+	request := client.KubeClient().CoreV1().RESTClient().Get().Namespace(namespace).Name(podName).Resource("pods").SubResource("log").VersionedParams(options, scheme.ParameterCodec)
 	if request.URL().String() == "" {
 		return nil, errors.New("Request url is empty")
 	}
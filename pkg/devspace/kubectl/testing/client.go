@@ -133,6 +133,12 @@ func (c *Client) Logs(ctx context.Context, namespace, podName, containerName str
 	return retVal, nil
 }
 
+// LogsWithOptions is a fake implementation of function
+func (c *Client) LogsWithOptions(ctx context.Context, namespace, podName string, options *k8sv1.PodLogOptions) (io.ReadCloser, error) {
+	retVal := io.NopCloser(strings.NewReader("ContainerLogs"))
+	return retVal, nil
+}
+
 // GetUpgraderWrapper is a fake implementation of function
 func (c *Client) GetUpgraderWrapper() (http.RoundTripper, kubectl.UpgraderWrapper, error) {
 	return nil, nil, nil
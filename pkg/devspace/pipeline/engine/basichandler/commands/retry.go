@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+type RetryOptions struct {
+	Attempts int           `long:"attempts" short:"a" description:"How often the command should be executed at most" default:"3"`
+	Backoff  time.Duration `long:"backoff" short:"b" description:"The time to wait before the second attempt. The time is doubled for every further attempt" default:"1s"`
+}
+
+// Retry executes the given command until it succeeds or the maximum amount of attempts is reached
+func Retry(ctx context.Context, args []string, handler types.ExecHandler) error {
+	options := &RetryOptions{}
+	args, err := flags.NewParser(options, flags.PassAfterNonOption).ParseArgs(args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if len(args) == 0 || options.Attempts < 1 || options.Backoff < 0 {
+		return fmt.Errorf("usage: retry [--attempts 3] [--backoff 1s] command [argument ...]")
+	}
+
+	hc := interp.HandlerCtx(ctx)
+	backoff := options.Backoff
+	for attempt := 1; ; attempt++ {
+		err = handler.ExecHandler(ctx, args)
+		if isSuccess(err) || attempt >= options.Attempts {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(hc.Stderr, "retry: %s failed (attempt %d/%d), retrying in %s\n", args[0], attempt, options.Attempts, backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isSuccess(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	status, ok := interp.IsExitStatus(err)
+	return ok && status == 0
+}
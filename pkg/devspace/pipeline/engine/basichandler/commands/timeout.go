@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// Timeout executes the given command and cancels it if it doesn't finish within the given duration.
+// Similar to the coreutils timeout, it exits with status 124 if the command has timed out.
+func Timeout(ctx context.Context, args []string, handler types.ExecHandler) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: timeout DURATION command [argument ...]")
+	}
+
+	duration, err := parseTimeoutDuration(args[0])
+	if err != nil {
+		return fmt.Errorf("usage: timeout DURATION command [argument ...]: %v", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	err = handler.ExecHandler(timeoutCtx, args[1:])
+	if timeoutCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		hc := interp.HandlerCtx(ctx)
+		_, _ = fmt.Fprintf(hc.Stderr, "timeout: %s timed out after %s\n", args[1], duration)
+		return interp.NewExitStatus(124)
+	}
+
+	return err
+}
+
+func parseTimeoutDuration(value string) (time.Duration, error) {
+	// plain numbers are seconds
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	return time.ParseDuration(value)
+}
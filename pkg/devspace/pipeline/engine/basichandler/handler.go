@@ -63,6 +63,12 @@ var OverwriteCommands = map[string]func(ctx context.Context, args []string, hand
 	"run_watch": func(ctx context.Context, args []string, handler types.ExecHandler) error {
 		return HandleError(ctx, "run_watch", enginecommands.RunWatch(ctx, args, handler, log.Discard))
 	},
+	"retry": func(ctx context.Context, args []string, handler types.ExecHandler) error {
+		return HandleError(ctx, "retry", enginecommands.Retry(ctx, args, handler))
+	},
+	"timeout": func(ctx context.Context, args []string, handler types.ExecHandler) error {
+		return HandleError(ctx, "timeout", enginecommands.Timeout(ctx, args, handler))
+	},
 }
 
 // EnsureCommands are commands where devspace makes sure those are installed locally before
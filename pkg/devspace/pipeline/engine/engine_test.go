@@ -106,6 +106,33 @@ func TestShellCatEnforce(t *testing.T) {
 	}
 }
 
+func TestShellRetry(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	err := ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stderr, stderr, nil, "retry --attempts 3 --backoff 10ms cat noFile.txt")
+	if err == nil {
+		t.Fatal("FAIL: TestShellRetry")
+	}
+	assert.Equal(t, strings.Count(stderr.String(), "cat: noFile.txt: No such file or directory"), 3)
+	assert.Assert(t, strings.Contains(stderr.String(), "retry: cat failed (attempt 2/3), retrying in 20ms"))
+
+	stdout := &bytes.Buffer{}
+	err = ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stdout, nil, nil, "retry echo hello")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout.String(), "hello\n")
+}
+
+func TestShellTimeout(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	err := ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stderr, stderr, nil, "timeout 100ms sleep 5 || echo exit $?")
+	assert.NilError(t, err)
+	assert.Equal(t, stderr.String(), "timeout: sleep timed out after 100ms\nexit 124\n")
+
+	stdout := &bytes.Buffer{}
+	err = ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stdout, nil, nil, "timeout 5 echo hello")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout.String(), "hello\n")
+}
+
 func TestKubectlDownload(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
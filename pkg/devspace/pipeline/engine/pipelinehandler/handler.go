@@ -38,6 +38,14 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 		hc := interp.HandlerCtx(devCtx.Context())
 		return basichandlercommands.RunWatch(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline), devCtx.Log())
 	},
+	"retry": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return basichandlercommands.Retry(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline))
+	},
+	"timeout": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return basichandlercommands.Timeout(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline))
+	},
 	"run_pipelines": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		hc := interp.HandlerCtx(devCtx.Context())
 		return commands.RunPipelines(devCtx, pipeline, args, hc.Env)
@@ -1,17 +1,23 @@
 package pipeline
 
 import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/interrupt"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/loft-sh/devspace/pkg/util/tomb"
-	"io"
+	"github.com/pkg/errors"
 	"mvdan.cc/sh/v3/expand"
-	"os"
-	"sync"
 )
 
 type Job struct {
@@ -65,10 +71,21 @@ func (j *Job) Run(ctx devspacecontext.Context, args []string, environ expand.Env
 	}
 
 	j.m.Lock()
-	ctx = ctx.WithContext(j.t.Context(ctx.Context()))
+	finallyCtx := ctx.WithContext(values.WithPipeline(withoutCancel{ctx.Context()}, j.Config.Name))
+	ctx = ctx.WithContext(values.WithPipeline(j.t.Context(ctx.Context()), j.Config.Name))
 	t := j.t
 	j.m.Unlock()
 
+	// finally is executed exactly once, either after the job has stopped
+	// or if DevSpace was interrupted
+	finallyOnce := &sync.Once{}
+	finally := func() (err error) {
+		finallyOnce.Do(func() {
+			err = j.executeFinally(finallyCtx, args, environ)
+		})
+		return err
+	}
+
 	t.Go(func() error {
 		// start the actual job
 		done := t.NotifyGo(func() error {
@@ -78,10 +95,27 @@ func (j *Job) Run(ctx devspacecontext.Context, args []string, environ expand.Env
 		// wait until job is dying
 		select {
 		case <-ctx.Context().Done():
+			if j.Config.Finally != "" {
+				<-done
+				err := finally()
+				if err != nil {
+					ctx.Log().Errorf("Error executing finally: %v", err)
+				}
+			}
 			return nil
 		case <-done:
 		}
 
+		// execute finally after the job has exited
+		if j.Config.Finally != "" {
+			err := finally()
+			if err != nil && t.Alive() {
+				return errors.Wrap(err, "finally")
+			} else if err != nil {
+				ctx.Log().Errorf("Error executing finally: %v", err)
+			}
+		}
+
 		// check if errored
 		if !t.Alive() {
 			return t.Err()
@@ -90,10 +124,66 @@ func (j *Job) Run(ctx devspacecontext.Context, args []string, environ expand.Env
 		return nil
 	})
 
-	return t.Wait()
+	if j.Config.Finally == "" {
+		return t.Wait()
+	}
+
+	return interrupt.Global.Run(t.Wait, func() {
+		stopJob(t, finallyStopTimeout)
+		_ = finally()
+	})
+}
+
+// finallyStopTimeout is how long DevSpace waits for an interrupted job to exit
+// before finally is executed anyway. Nested pipelines can only exit after the
+// interrupt was handled, so this has to be bounded.
+const finallyStopTimeout = time.Second * 10
+
+// stopJob kills the job and waits until it has exited or the timeout is reached,
+// so that finally doesn't run while the job is still running
+func stopJob(t *tomb.Tomb, timeout time.Duration) {
+	t.Kill(nil)
+	select {
+	case <-t.Dead():
+	case <-time.After(timeout):
+	}
 }
 
 func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.Tomb, environ expand.Environ) error {
+	if j.Config.Run != "" {
+		err := j.executeScript(ctx, j.Config.Run, args, parent, environ)
+		if err != nil {
+			return err
+		}
+	}
+	if len(j.Config.Steps) == 0 {
+		return nil
+	}
+
+	results, err := runSteps(ctx, j.Config.Steps, parent, func(ctx devspacecontext.Context, step latest.PipelineStep) error {
+		ctx = ctx.WithLogger(ctx.Log().WithPrefix(step.Name + " "))
+		return j.executeScript(ctx, step.Run, args, parent, environ)
+	})
+	printErr := printStepResults(ctx.Log(), results, j.Pipeline.Options().StepsJSON)
+	if err != nil {
+		return err
+	}
+
+	return printErr
+}
+
+func (j *Job) executeFinally(ctx devspacecontext.Context, args []string, environ expand.Environ) error {
+	if j.Config.Finally == "" {
+		return nil
+	}
+
+	t := &tomb.Tomb{}
+	err := j.executeScript(ctx, j.Config.Finally, args, t, environ)
+	_ = t.Wait()
+	return err
+}
+
+func (j *Job) executeScript(ctx devspacecontext.Context, script string, args []string, parent *tomb.Tomb, environ expand.Environ) error {
 	ctx = ctx.WithLogger(ctx.Log())
 	stdoutReader, stdoutWriter := io.Pipe()
 	defer stdoutWriter.Close()
@@ -118,6 +208,17 @@ func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.T
 	})
 
 	handler := pipelinehandler.NewPipelineExecHandler(ctx, stdoutWriter, stderrWriter, j.Pipeline)
-	_, err := engine.ExecutePipelineShellCommand(ctx.Context(), j.Config.Run, args, ctx.WorkingDir(), j.Config.ContinueOnError, stdoutWriter, stderrWriter, os.Stdin, environ, handler)
+	_, err := engine.ExecutePipelineShellCommand(ctx.Context(), script, args, ctx.WorkingDir(), j.Config.ContinueOnError, stdoutWriter, stderrWriter, os.Stdin, environ, handler)
 	return err
 }
+
+// withoutCancel keeps the values of the parent context, but is never cancelled,
+// which allows finally to run after the job was stopped
+type withoutCancel struct {
+	parent context.Context
+}
+
+func (withoutCancel) Deadline() (deadline time.Time, ok bool) { return }
+func (withoutCancel) Done() <-chan struct{}                   { return nil }
+func (withoutCancel) Err() error                              { return nil }
+func (c withoutCancel) Value(key interface{}) interface{}     { return c.parent.Value(key) }
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"gotest.tools/assert"
+)
+
+func TestStopJob(t *testing.T) {
+	// the job needs some time to exit after it was killed
+	exited := int32(0)
+	job := &tomb.Tomb{}
+	job.Go(func() error {
+		<-job.Dying()
+		time.Sleep(time.Millisecond * 50)
+		atomic.StoreInt32(&exited, 1)
+		return nil
+	})
+
+	stopJob(job, time.Second*10)
+	assert.Equal(t, atomic.LoadInt32(&exited), int32(1))
+
+	// a job that doesn't exit is only waited for until the timeout
+	release := make(chan struct{})
+	defer close(release)
+	stuck := &tomb.Tomb{}
+	stuck.Go(func() error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	stopJob(stuck, time.Millisecond*50)
+	assert.Assert(t, time.Since(start) < time.Second*5)
+}
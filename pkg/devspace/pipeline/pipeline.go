@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -166,6 +167,24 @@ func (p *pipeline) Name() string {
 	return p.name
 }
 
+func (p *pipeline) RunningPipelines() []string {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	running := []string{}
+	if p.main.Config != nil && !p.main.Terminated() {
+		running = append(running, p.main.Config.Name)
+	}
+	for _, j := range p.jobs {
+		if j.Config != nil && !j.Terminated() {
+			running = append(running, j.Config.Name)
+		}
+	}
+
+	sort.Strings(running)
+	return running
+}
+
 func (p *pipeline) DevPodManager() devpod.Manager {
 	return p.devPodManager
 }
@@ -405,9 +424,11 @@ func (p *pipeline) startNewDependency(ctx devspacecontext.Context, dependency ty
 		return err
 	}
 
+	// dev pods are started with the dev context, so it needs the dependency name as well
 	devCtx, _ := values.DevContextFrom(ctx.Context())
-	devCtxCancel, cancelDevCtx := context.WithCancel(devCtx)
+	devCtxCancel, cancelDevCtx := context.WithCancel(values.WithDependencyName(devCtx, dependency.Name()))
 	ctx = ctx.WithContext(values.WithDevContext(ctx.Context(), devCtxCancel))
+	ctx = ctx.WithContext(values.WithDependencyName(ctx.Context(), dependency.Name()))
 	dependencyDevPodManager := devpod.NewManager(cancelDevCtx)
 	pip := NewPipeline(dependency.Name(), dependencyDevPodManager, p.dependencyRegistry, pipelineConfig, p.options)
 	pip.(*pipeline).parent = p
@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	StepStatusSucceeded = "succeeded"
+	StepStatusFailed    = "failed"
+	StepStatusCancelled = "cancelled"
+)
+
+// StepResult is the outcome of a single pipeline step
+type StepResult struct {
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	Started         *time.Time `json:"started,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds"`
+	Error           string     `json:"error,omitempty"`
+}
+
+type stepDone struct {
+	name string
+	err  error
+}
+
+// runSteps executes the given steps as a directed acyclic graph. Steps without unfinished
+// needs are started in parallel within the parent tomb. If a step fails, all steps that
+// directly or indirectly need it are cancelled, while independent steps keep running.
+func runSteps(ctx devspacecontext.Context, steps []latest.PipelineStep, parent *tomb.Tomb, run func(ctx devspacecontext.Context, step latest.PipelineStep) error) ([]*StepResult, error) {
+	results := map[string]*StepResult{}
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+	stepsByName := map[string]latest.PipelineStep{}
+	for _, step := range steps {
+		results[step.Name] = &StepResult{Name: step.Name}
+		pending[step.Name] = len(step.Needs)
+		stepsByName[step.Name] = step
+		for _, need := range step.Needs {
+			dependents[need] = append(dependents[need], step.Name)
+		}
+	}
+
+	done := make(chan stepDone, len(steps))
+	running := 0
+	start := func(step latest.PipelineStep) {
+		now := time.Now()
+		results[step.Name].Started = &now
+		running++
+		parent.Go(func() error {
+			done <- stepDone{name: step.Name, err: run(ctx, step)}
+			return nil
+		})
+	}
+
+	var cancel func(name string)
+	cancel = func(name string) {
+		for _, dependent := range dependents[name] {
+			if results[dependent].Status != "" {
+				continue
+			}
+
+			results[dependent].Status = StepStatusCancelled
+			cancel(dependent)
+		}
+	}
+
+	for _, step := range steps {
+		if pending[step.Name] == 0 {
+			start(step)
+		}
+	}
+
+	errs := []error{}
+	for running > 0 {
+		finished := <-done
+		running--
+
+		result := results[finished.name]
+		result.DurationSeconds = time.Since(*result.Started).Seconds()
+		if finished.err != nil {
+			result.Status = StepStatusFailed
+			result.Error = finished.err.Error()
+			errs = append(errs, errors.Wrapf(finished.err, "step %s", finished.name))
+			cancel(finished.name)
+			continue
+		}
+
+		result.Status = StepStatusSucceeded
+		for _, dependent := range dependents[finished.name] {
+			pending[dependent]--
+			if pending[dependent] == 0 && results[dependent].Status == "" {
+				if ctx.IsDone() {
+					results[dependent].Status = StepStatusCancelled
+					cancel(dependent)
+					continue
+				}
+
+				start(stepsByName[dependent])
+			}
+		}
+	}
+
+	retResults := make([]*StepResult, 0, len(steps))
+	for _, step := range steps {
+		if results[step.Name].Status == "" {
+			results[step.Name].Status = StepStatusCancelled
+		}
+
+		retResults = append(retResults, results[step.Name])
+	}
+
+	return retResults, utilerrors.NewAggregate(errs)
+}
+
+// printStepResults prints the results as table or as json through the logger
+func printStepResults(logger log.Logger, results []*StepResult, asJSON bool) error {
+	if asJSON {
+		out, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+
+		logger.WriteString(logrus.InfoLevel, string(out)+"\n")
+		return nil
+	}
+
+	values := [][]string{}
+	for _, result := range results {
+		duration := "-"
+		if result.Started != nil {
+			duration = (time.Duration(result.DurationSeconds * float64(time.Second))).Round(time.Millisecond).String()
+		}
+
+		values = append(values, []string{result.Name, result.Status, duration, result.Error})
+	}
+
+	log.PrintTable(logger, []string{"Step", "Status", "Duration", "Error"}, values)
+	return nil
+}
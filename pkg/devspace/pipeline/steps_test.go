@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/sirupsen/logrus"
+	"gotest.tools/assert"
+)
+
+func stepStatus(results []*StepResult) map[string]string {
+	status := map[string]string{}
+	for _, result := range results {
+		status[result.Name] = result.Status
+	}
+	return status
+}
+
+func TestRunStepsSkipsDependentsOfFailedSteps(t *testing.T) {
+	steps := []latest.PipelineStep{
+		{Name: "lint"},
+		{Name: "build"},
+		{Name: "test", Needs: []string{"build"}},
+		{Name: "deploy", Needs: []string{"test", "lint"}},
+	}
+
+	m := sync.Mutex{}
+	started := map[string]bool{}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard)
+	results, err := runSteps(ctx, steps, &tomb.Tomb{}, func(ctx devspacecontext.Context, step latest.PipelineStep) error {
+		m.Lock()
+		started[step.Name] = true
+		m.Unlock()
+
+		if step.Name == "build" {
+			return fmt.Errorf("compile error")
+		}
+		return nil
+	})
+	assert.ErrorContains(t, err, "step build: compile error")
+	assert.DeepEqual(t, stepStatus(results), map[string]string{
+		"lint":   StepStatusSucceeded,
+		"build":  StepStatusFailed,
+		"test":   StepStatusCancelled,
+		"deploy": StepStatusCancelled,
+	})
+	assert.DeepEqual(t, started, map[string]bool{"lint": true, "build": true})
+	assert.Equal(t, results[1].Error, "compile error")
+	assert.Assert(t, results[2].Started == nil)
+}
+
+func TestRunStepsCancellation(t *testing.T) {
+	steps := []latest.PipelineStep{
+		{Name: "build"},
+		{Name: "test", Needs: []string{"build"}},
+		{Name: "deploy", Needs: []string{"test"}},
+	}
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := sync.Mutex{}
+	started := map[string]bool{}
+	ctx := devspacecontext.NewContext(cancelCtx, nil, log.Discard)
+	results, err := runSteps(ctx, steps, &tomb.Tomb{}, func(ctx devspacecontext.Context, step latest.PipelineStep) error {
+		m.Lock()
+		started[step.Name] = true
+		m.Unlock()
+
+		// the pipeline is cancelled while the first step runs
+		cancel()
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stepStatus(results), map[string]string{
+		"build":  StepStatusSucceeded,
+		"test":   StepStatusCancelled,
+		"deploy": StepStatusCancelled,
+	})
+	assert.DeepEqual(t, started, map[string]bool{"build": true})
+}
+
+func TestPrintStepResultsJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := log.NewStreamLogger(out, out, logrus.InfoLevel)
+	results := []*StepResult{
+		{Name: "build", Status: StepStatusFailed, Error: "compile error"},
+		{Name: "test", Status: StepStatusCancelled},
+	}
+
+	assert.NilError(t, printStepResults(logger, results, true))
+
+	printed := []*StepResult{}
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &printed))
+	assert.DeepEqual(t, printed, results)
+}
@@ -17,6 +17,9 @@ type Options struct {
 	PurgeOptions      deploy.PurgeOptions
 	DependencyOptions DependencyOptions
 	DevOptions        devpod.Options
+
+	// StepsJSON prints the timings of pipeline steps as json instead of a table
+	StepsJSON bool
 }
 
 type DependencyOptions struct {
@@ -69,6 +72,10 @@ type Pipeline interface {
 	// project like my-microservice etc.
 	Name() string
 
+	// RunningPipelines returns the names of the main pipeline and all sub pipelines
+	// that are currently running
+	RunningPipelines() []string
+
 	// Done returns a channel that is closed when the pipeline is done running
 	Done() <-chan struct{}
 
@@ -0,0 +1,267 @@
+package secrets
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// AgeKeyFileEnv is the environment variable sops uses for the path of the age identity file
+	AgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+
+	// AgeKeyEnv is the environment variable sops uses for the age identities itself
+	AgeKeyEnv = "SOPS_AGE_KEY"
+)
+
+// ageHeader is the first line of a binary age file
+const ageHeader = "age-encryption.org/v1\n"
+
+// DefaultIdentityFile returns the path of the age identity file that is used if none is specified,
+// which is the same location sops uses.
+func DefaultIdentityFile() (string, error) {
+	if identityFile := os.Getenv(AgeKeyFileEnv); identityFile != "" {
+		return identityFile, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "sops", "age", "keys.txt"), nil
+}
+
+// LoadIdentities loads the age identities from the identity file. If the identity file is empty,
+// the identities are loaded from the SOPS_AGE_KEY environment variable or the default identity file.
+func LoadIdentities(identityFile string) ([]age.Identity, error) {
+	if identityFile == "" {
+		if key := os.Getenv(AgeKeyEnv); key != "" {
+			identities, err := age.ParseIdentities(strings.NewReader(key))
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse identities from %s", AgeKeyEnv)
+			}
+
+			return identities, nil
+		}
+
+		var err error
+		identityFile, err = DefaultIdentityFile()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identityFile, err := homedir.Expand(identityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read identity file %s", identityFile)
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(out))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse identity file %s", identityFile)
+	}
+
+	return identities, nil
+}
+
+// LoadRecipients parses the given recipients and the recipients file, which contains one
+// recipient per line. If no recipients are specified, the recipients of the identities are used.
+func LoadRecipients(recipients []string, recipientsFile string, identities []age.Identity) ([]age.Recipient, error) {
+	retRecipients := []age.Recipient{}
+	for _, r := range recipients {
+		recipient, err := age.ParseX25519Recipient(r)
+		if err != nil {
+			return nil, err
+		}
+
+		retRecipients = append(retRecipients, recipient)
+	}
+
+	if recipientsFile != "" {
+		out, err := os.ReadFile(recipientsFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read recipients file %s", recipientsFile)
+		}
+
+		fileRecipients, err := age.ParseRecipients(bytes.NewReader(out))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse recipients file %s", recipientsFile)
+		}
+
+		retRecipients = append(retRecipients, fileRecipients...)
+	}
+
+	if len(retRecipients) == 0 {
+		for _, identity := range identities {
+			if x25519Identity, ok := identity.(*age.X25519Identity); ok {
+				retRecipients = append(retRecipients, x25519Identity.Recipient())
+			}
+		}
+	}
+
+	return retRecipients, nil
+}
+
+// IsEncrypted returns true if the data is age encrypted or a sops file
+func IsEncrypted(data []byte) bool {
+	if isAgeEncrypted(data) {
+		return true
+	}
+
+	doc := map[string]interface{}{}
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return false
+	}
+
+	_, ok := doc[sopsMetadataKey]
+	return ok
+}
+
+// DecryptFile decrypts a sops or age encrypted yaml or json file
+func DecryptFile(path string, identities []age.Identity) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := Decrypt(data, identities)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypt %s", path)
+	}
+
+	return doc, nil
+}
+
+// Decrypt decrypts sops or age encrypted yaml or json
+func Decrypt(data []byte, identities []age.Identity) (map[string]interface{}, error) {
+	if isAgeEncrypted(data) {
+		plaintext, err := decryptAge(data, identities)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := map[string]interface{}{}
+		err = yaml.Unmarshal(plaintext, &doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse decrypted file")
+		}
+
+		return doc, nil
+	}
+
+	return decryptSOPS(data, identities)
+}
+
+// DecryptAge decrypts an age encrypted file and returns the plaintext
+func DecryptAge(data []byte, identities []age.Identity) ([]byte, error) {
+	if !isAgeEncrypted(data) {
+		if IsEncrypted(data) {
+			return nil, errors.New("sops files can only be edited with sops")
+		}
+
+		return nil, errors.New("file is not age encrypted")
+	}
+
+	return decryptAge(data, identities)
+}
+
+// Encrypt encrypts the yaml or json plaintext to the recipients and returns an armored age file
+func Encrypt(plaintext []byte, recipients []age.Recipient) ([]byte, error) {
+	doc := map[string]interface{}{}
+	err := yaml.Unmarshal(plaintext, &doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "file is not a yaml or json object")
+	}
+
+	return encryptAge(plaintext, recipients)
+}
+
+func isAgeEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageHeader)) || bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header))
+}
+
+// encryptAge encrypts the plaintext to the recipients and returns an armored age file
+func encryptAge(plaintext []byte, recipients []age.Recipient) ([]byte, error) {
+	out := &bytes.Buffer{}
+	armorWriter := armor.NewWriter(out)
+	writer, err := age.Encrypt(armorWriter, recipients...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = writer.Write(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = armorWriter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// decryptAge decrypts an armored or binary age file
+func decryptAge(data []byte, identities []age.Identity) ([]byte, error) {
+	var reader io.Reader = bytes.NewReader(data)
+	if !bytes.HasPrefix(data, []byte(ageHeader)) {
+		reader = armor.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	}
+
+	plaintext, err := age.Decrypt(reader, identities...)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(plaintext)
+}
+
+// Lookup returns the value at the dot separated path of the decrypted document,
+// e.g. database.password or users.0.name
+func Lookup(doc map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,213 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"gotest.tools/assert"
+)
+
+func TestAgeRoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+
+	encrypted, err := Encrypt([]byte("database:\n  password: secret\nusers:\n- name: foo\n"), []age.Recipient{identity.Recipient()})
+	assert.NilError(t, err)
+	assert.Assert(t, IsEncrypted(encrypted))
+	assert.Assert(t, !strings.Contains(string(encrypted), "secret"))
+
+	doc, err := Decrypt(encrypted, []age.Identity{identity})
+	assert.NilError(t, err)
+
+	value, ok := Lookup(doc, "database.password")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "secret")
+	value, ok = Lookup(doc, "users.0.name")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "foo")
+	_, ok = Lookup(doc, "database.user")
+	assert.Assert(t, !ok)
+
+	other, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+	_, err = Decrypt(encrypted, []age.Identity{other})
+	assert.ErrorContains(t, err, "no identity matched")
+
+	_, err = Encrypt([]byte("- not an object"), []age.Recipient{identity.Recipient()})
+	assert.ErrorContains(t, err, "not a yaml or json object")
+}
+
+// the following files were encrypted with sops 3.8.1 for the test identity
+const sopsTestIdentity = "AGE-SECRET-KEY-14VWHL92WQQZWJ565EL02KYSW8JQKDZMJFXP3HGZETT26PT5XKKPQCW98CJ"
+
+const sopsTestYAML = `#ENC[AES256_GCM,data:CLosbsOC031KHMXKW/VnYjyB,iv:SEqCXAGYgZy1/ea34966Xy8NwfoZ17zHyHVH9nCkKxY=,tag:Jsic+jXsp5wKaMzm+NMg6Q==,type:comment]
+database:
+    password: ENC[AES256_GCM,data:6o2vleJe,iv:XedCXQFHZi8nEpVnyTaKK4AblQKQ7OsWnHN8WK/qCyA=,tag:GWwZAKNYcVSJjzU85prGrQ==,type:str]
+    port: ENC[AES256_GCM,data:IEmJYg==,iv:/C83gQir0hQDpvz0ANEKta36O0K1XXLJALheYPkzq08=,tag:cGTluZb1EtBukZLemQH0Aw==,type:int]
+    ssl: ENC[AES256_GCM,data:y8ipJQ==,iv:rj8GOD9p5ijkmUkAdZ01Rs2gh87Yl/lCiIywMQ+KrP4=,tag:4OY3rMaVv0pZ+VIomCJREA==,type:bool]
+    ratio: ENC[AES256_GCM,data:c7hR,iv:dKyDfV3oq1bwRfNyuYe5aYs8tdwTMGhsqf+niT0rKYA=,tag:MJdiJka8YbOSZeD6jAHKxA==,type:float]
+    empty: ""
+hosts:
+    - ENC[AES256_GCM,data:v7inn1xLsA==,iv:Z76WjXSO5Jy+RxxWcqUDlJ6w8w0EArsmPGzCZ5p5cIo=,tag:dlXYy9uJYhYcT4Rhgfsieg==,type:str]
+    - ENC[AES256_GCM,data:H1/LdSZdSw==,iv:lfnbcj9WvND83HWotiZPkfs3ns4OwVnJvR+p8LYdmAA=,tag:y2dfz+q+vxPshEWfIKaSQw==,type:str]
+region_unencrypted: eu-west
+sops:
+    kms: []
+    gcp_kms: []
+    azure_kv: []
+    hc_vault: []
+    age:
+        - recipient: age15csq8fdqjy5j7mpmcc8uaz3kfrx3nqlpksm9kc6uze9v8rgwygzqfgykz2
+          enc: |
+            -----BEGIN AGE ENCRYPTED FILE-----
+            YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSBLNkx1aG91bUVLSFVqYzFB
+            U3BPblBMNm1nVzk1alo0Z0dpRnRBSWZFbVdRCm4zUmtuRXJlRVRvNVF1dEJPQWNI
+            dVFnSml4cnlVeUF1Z20xV1BOZStmTDAKLS0tICtCM2ZwQ1NPK1VQTmRmY1RWWXN1
+            b1NoekJLWFFJNmx6YS9RckNqejBwUUUKCQVYEUU+NzEiJrmzc0hzfJN5p1Hce4Em
+            Vcz+RHwiYYMLRDgeVhEgF4D4YVDXW3qkRY2gWLRVljiieWZ+9iB+yA==
+            -----END AGE ENCRYPTED FILE-----
+    lastmodified: "2026-10-16T19:16:21Z"
+    mac: ENC[AES256_GCM,data:WrbNm3xsQf9bauXQi5o1z5znTmVWLK2LUX21vbvBieBNR0owxnA654RVukO0wY+3wvvz+rE2+z46arLE2ya0qox7KU+icl+a3/a94AtXs7h9LGOXi0cycxIa7FZDuKiScUIRHr0SdtmnOfHHXD8DCS4hNt7yH52Q3VPdnxxrZlM=,iv:u33k7XFLIm2+PCG5Fd5mdfVUmdRKBAW6uU8rIIjUaqU=,tag:suYfX7ux7yjUOXVAukK5gg==,type:str]
+    pgp: []
+    unencrypted_suffix: _unencrypted
+    version: 3.8.1
+`
+
+const sopsTestJSON = `{
+	"database": {
+		"password": "ENC[AES256_GCM,data:NMh0OOqW,iv:N0hnd6L/hvMXekRXFYeCrcLqqy6rhbr12cyh6oEM2M8=,tag:sAjacOzMwi0WSPZ+3z2PBg==,type:str]",
+		"port": "ENC[AES256_GCM,data:J37XmQ==,iv:LlHWMM763xEAmFVWaMPwjjeEfhbTL5oANAZbjzPUk/o=,tag:TEHX4jvc7gLsJ0FycWLwlw==,type:float]"
+	},
+	"hosts": [
+		"ENC[AES256_GCM,data:uYIka+qLfg==,iv:jdzkXqZZMvc/1MAXVTsPvyCmE5u9+ZhoPOSlkK7TbSU=,tag:083T1mBhu5cyxLmeySJq1g==,type:str]",
+		"ENC[AES256_GCM,data:nOtldxo9VA==,iv:TEgsPzGl2DA+PxzfVrHPCXWvPBBZ1s0H4yx7cm9OavE=,tag:LNCgIvqCkdiEpB2vlzdnvA==,type:str]"
+	],
+	"sops": {
+		"kms": null,
+		"gcp_kms": null,
+		"azure_kv": null,
+		"hc_vault": null,
+		"age": [
+			{
+				"recipient": "age15csq8fdqjy5j7mpmcc8uaz3kfrx3nqlpksm9kc6uze9v8rgwygzqfgykz2",
+				"enc": "-----BEGIN AGE ENCRYPTED FILE-----\nYWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSArYVNCRUZWOTQreEd4SXA1\nNDM0S0JTNXg3dDdaNzl6TC9vcDBXaEMxRVRjCmw4aGtqa0ZBWGtGcFo0a3NWeStU\nSUI3cVlJcWpETHNhQkR5dGE5Q2RJWGsKLS0tIEd1bi9IUkpXYUhDUzcvMWZDaHZp\nelg0TDE2TzdHc0JQNnpVMnhSOXI3TFEKckv3Yw4Y3jofN5iNsbPk+R8NSuXZobQe\nmyJqy1Y+YXqvRqe98PepVXW8bFYO5q2Alg8C8+YXEnsf9+Uu9AjGgQ==\n-----END AGE ENCRYPTED FILE-----\n"
+			}
+		],
+		"lastmodified": "2026-10-16T19:16:21Z",
+		"mac": "ENC[AES256_GCM,data:LptxiFgGRFMdXNequXEx+peDDb80AAz+gdAD1Xqu79I6E9lmHBubUW0QuRugq7fPeEK2HVn1I4JYN7FKTEpbNrGHjNeU5jGpaHAVwOzDbqbReu0e2QPdeho4ISDwQpCTkyVsJRBNUCiz2NyMnjAqT+jbQVAU3gRBcuyYmXOqzMs=,iv:nre7cKmStCdLtEV47FXry5kiF585Ja4D0+UHNEBLtT4=,tag:gJatiraTwzsgq+T+dbY6QA==,type:str]",
+		"pgp": null,
+		"unencrypted_suffix": "_unencrypted",
+		"version": "3.8.1"
+	}
+}`
+
+func TestSOPS(t *testing.T) {
+	identity, err := age.ParseX25519Identity(sopsTestIdentity)
+	assert.NilError(t, err)
+	identities := []age.Identity{identity}
+
+	assert.Assert(t, IsEncrypted([]byte(sopsTestYAML)))
+	decrypted, err := Decrypt([]byte(sopsTestYAML), identities)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decrypted, map[string]interface{}{
+		"database": map[string]interface{}{
+			"password": "secret",
+			"port":     5432,
+			"ssl":      true,
+			"ratio":    1.5,
+			"empty":    "",
+		},
+		"hosts":              []interface{}{"a.local", "b.local"},
+		"region_unencrypted": "eu-west",
+	})
+
+	decrypted, err = Decrypt([]byte(sopsTestJSON), identities)
+	assert.NilError(t, err)
+	value, _ := Lookup(decrypted, "database.password")
+	assert.Equal(t, value, "secret")
+	value, _ = Lookup(decrypted, "hosts.1")
+	assert.Equal(t, value, "b.local")
+
+	other, err := age.GenerateX25519Identity()
+	assert.NilError(t, err)
+	_, err = Decrypt([]byte(sopsTestYAML), []age.Identity{other})
+	assert.ErrorContains(t, err, "no identity matched")
+
+	lines := strings.Split(sopsTestYAML, "\n")
+	replaceLine := func(prefix string, replacement ...string) string {
+		out := []string{}
+		for _, line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				out = append(out, replacement...)
+			} else {
+				out = append(out, line)
+			}
+		}
+		return strings.Join(out, "\n")
+	}
+	lineWithPrefix := func(prefix string) string {
+		for _, line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				return line
+			}
+		}
+		t.Fatalf("no line with prefix %s", prefix)
+		return ""
+	}
+	hosts := []string{}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "    - ENC[") {
+			hosts = append(hosts, line)
+		}
+	}
+	assert.Equal(t, len(hosts), 2)
+	firstHost, secondHost := hosts[0], hosts[1]
+
+	testCases := map[string]struct {
+		document    string
+		expectedErr string
+	}{
+		"plaintext value": {
+			document:    replaceLine("    password:", "    password: plain"),
+			expectedErr: "database.password is not encrypted",
+		},
+		"plaintext list item": {
+			document:    replaceLine(firstHost, "    - c.local"),
+			expectedErr: "hosts is not encrypted",
+		},
+		"value moved to another path": {
+			document:    replaceLine("    port:", strings.Replace(lineWithPrefix("    password:"), "password", "port", 1)),
+			expectedErr: "decrypt database.port: authentication failed",
+		},
+		"reordered list items": {
+			document:    replaceLine(firstHost, secondHost, firstHost),
+			expectedErr: "sops mac does not match",
+		},
+		"removed list item": {
+			document:    replaceLine(firstHost),
+			expectedErr: "sops mac does not match",
+		},
+		"removed value": {
+			document:    replaceLine("    ssl:"),
+			expectedErr: "sops mac does not match",
+		},
+		"changed unencrypted value": {
+			document:    replaceLine("region_unencrypted:", "region_unencrypted: us-east"),
+			expectedErr: "sops mac does not match",
+		},
+		"added unencrypted value": {
+			document:    replaceLine("region_unencrypted:", "region_unencrypted: eu-west", "zone_unencrypted: a"),
+			expectedErr: "sops mac does not match",
+		},
+		"missing mac": {
+			document:    replaceLine("    mac:"),
+			expectedErr: "sops file has no mac",
+		},
+	}
+
+	for name, testCase := range testCases {
+		_, err := Decrypt([]byte(testCase.document), identities)
+		assert.ErrorContains(t, err, testCase.expectedErr, name)
+	}
+}
@@ -0,0 +1,352 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const sopsMetadataKey = "sops"
+
+// sopsValuePattern matches a single value that was encrypted by sops
+var sopsValuePattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)]$`)
+
+// sopsMetadata is the part of the sops metadata that is needed to decrypt a document
+type sopsMetadata struct {
+	Age []struct {
+		Recipient string `yaml:"recipient"`
+		Enc       string `yaml:"enc"`
+	} `yaml:"age"`
+
+	LastModified string `yaml:"lastmodified"`
+	MAC          string `yaml:"mac"`
+
+	UnencryptedSuffix string `yaml:"unencrypted_suffix"`
+	EncryptedSuffix   string `yaml:"encrypted_suffix"`
+	UnencryptedRegex  string `yaml:"unencrypted_regex"`
+	EncryptedRegex    string `yaml:"encrypted_regex"`
+	MACOnlyEncrypted  bool   `yaml:"mac_only_encrypted"`
+}
+
+// sopsDecrypter decrypts the values of a sops document in document order and
+// computes the document mac the same way sops does
+type sopsDecrypter struct {
+	metadata *sopsMetadata
+	dataKey  []byte
+	mac      hash.Hash
+
+	unencryptedRegex *regexp.Regexp
+	encryptedRegex   *regexp.Regexp
+}
+
+// decryptSOPS decrypts a sops yaml or json document that was encrypted for age recipients.
+// The data key of the document is decrypted with the identities and then used to decrypt
+// every value. Afterwards the document mac is verified, so values that were added,
+// removed, reordered or replaced with plaintext are detected.
+func decryptSOPS(data []byte, identities []age.Identity) (map[string]interface{}, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, err
+	} else if len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("file is neither sops nor age encrypted")
+	}
+
+	root := document.Content[0]
+	var metadataNode *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == sopsMetadataKey {
+			metadataNode = root.Content[i+1]
+		}
+	}
+	if metadataNode == nil {
+		return nil, errors.New("file is neither sops nor age encrypted")
+	}
+
+	metadata := &sopsMetadata{}
+	err = metadataNode.Decode(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sops metadata")
+	} else if len(metadata.Age) == 0 {
+		return nil, errors.New("sops file has no age recipients, only age is supported")
+	} else if metadata.MAC == "" {
+		return nil, errors.New("sops file has no mac")
+	}
+
+	decrypter := &sopsDecrypter{
+		metadata: metadata,
+		mac:      sha512.New(),
+	}
+	if metadata.UnencryptedRegex != "" {
+		decrypter.unencryptedRegex, err = regexp.Compile(metadata.UnencryptedRegex)
+		if err != nil {
+			return nil, errors.Wrap(err, "compile unencrypted_regex")
+		}
+	}
+	if metadata.EncryptedRegex != "" {
+		decrypter.encryptedRegex, err = regexp.Compile(metadata.EncryptedRegex)
+		if err != nil {
+			return nil, errors.Wrap(err, "compile encrypted_regex")
+		}
+	}
+
+	decrypter.dataKey, err = decryptSOPSDataKey(metadata, identities)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt sops data key")
+	}
+
+	out, err := decrypter.decryptMapping(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decrypter.verifyMAC()
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func decryptSOPSDataKey(metadata *sopsMetadata, identities []age.Identity) ([]byte, error) {
+	var lastErr error
+	for _, ageKey := range metadata.Age {
+		dataKey, err := decryptAge([]byte(ageKey.Enc), identities)
+		if err == nil {
+			return dataKey, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (s *sopsDecrypter) decryptMapping(node *yaml.Node, path []string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if len(path) == 0 && key == sopsMetadataKey {
+			continue
+		}
+
+		childPath := append(append([]string{}, path...), key)
+		value, err := s.decryptNode(node.Content[i+1], childPath)
+		if err != nil {
+			return nil, err
+		}
+
+		out[key] = value
+	}
+
+	return out, nil
+}
+
+func (s *sopsDecrypter) decryptNode(node *yaml.Node, path []string) (interface{}, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return s.decryptMapping(node, path)
+	case yaml.SequenceNode:
+		// list items share the path of the list, their order is protected by the mac
+		out := make([]interface{}, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := s.decryptNode(child, path)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, value)
+		}
+		return out, nil
+	case yaml.AliasNode:
+		return s.decryptNode(node.Alias, path)
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil, nil
+		}
+
+		return s.decryptLeaf(node, path)
+	}
+
+	return nil, errors.Errorf("unexpected yaml node at %s", strings.Join(path, "."))
+}
+
+func (s *sopsDecrypter) decryptLeaf(node *yaml.Node, path []string) (interface{}, error) {
+	var (
+		value     interface{}
+		encrypted = s.shouldBeEncrypted(path)
+	)
+	if encrypted {
+		if node.Tag != "!!str" || (node.Value != "" && !sopsValuePattern.MatchString(node.Value)) {
+			return nil, errors.Errorf("%s is not encrypted, but the sops metadata requires it to be", strings.Join(path, "."))
+		}
+
+		// sops does not encrypt empty strings
+		value = ""
+		if node.Value != "" {
+			var err error
+			value, err = decryptSOPSString(node.Value, strings.Join(path, ":")+":", s.dataKey)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decrypt %s", strings.Join(path, "."))
+			}
+		}
+	} else {
+		err := node.Decode(&value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode %s", strings.Join(path, "."))
+		}
+	}
+
+	if !s.metadata.MACOnlyEncrypted || encrypted {
+		err := writeSOPSMACValue(s.mac, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "compute mac of %s", strings.Join(path, "."))
+		}
+	}
+
+	return value, nil
+}
+
+// shouldBeEncrypted applies the same rules sops uses to decide if a value is encrypted
+func (s *sopsDecrypter) shouldBeEncrypted(path []string) bool {
+	encrypted := true
+	if s.metadata.UnencryptedSuffix != "" {
+		for _, key := range path {
+			if strings.HasSuffix(key, s.metadata.UnencryptedSuffix) {
+				encrypted = false
+				break
+			}
+		}
+	}
+	if s.metadata.EncryptedSuffix != "" {
+		encrypted = false
+		for _, key := range path {
+			if strings.HasSuffix(key, s.metadata.EncryptedSuffix) {
+				encrypted = true
+				break
+			}
+		}
+	}
+	if s.unencryptedRegex != nil {
+		for _, key := range path {
+			if s.unencryptedRegex.MatchString(key) {
+				encrypted = false
+				break
+			}
+		}
+	}
+	if s.encryptedRegex != nil {
+		encrypted = false
+		for _, key := range path {
+			if s.encryptedRegex.MatchString(key) {
+				encrypted = true
+				break
+			}
+		}
+	}
+
+	return encrypted
+}
+
+// verifyMAC compares the mac of the decrypted values with the mac stored in the
+// metadata, which is encrypted with the last modified date as additional data
+func (s *sopsDecrypter) verifyMAC() error {
+	lastModified, err := time.Parse(time.RFC3339, s.metadata.LastModified)
+	if err != nil {
+		return errors.Wrap(err, "parse sops lastmodified")
+	}
+
+	expected, err := decryptSOPSString(s.metadata.MAC, lastModified.Format(time.RFC3339), s.dataKey)
+	if err != nil {
+		return errors.Wrap(err, "decrypt sops mac")
+	}
+
+	computed := fmt.Sprintf("%X", s.mac.Sum(nil))
+	if expected != computed {
+		return errors.New("sops mac does not match, the file was modified after it was encrypted")
+	}
+
+	return nil
+}
+
+// writeSOPSMACValue writes the value in the representation sops uses for the mac
+func writeSOPSMACValue(writer io.Writer, value interface{}) error {
+	var out string
+	switch v := value.(type) {
+	case string:
+		out = v
+	case int:
+		out = strconv.Itoa(v)
+	case float64:
+		out = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		out = "False"
+		if v {
+			out = "True"
+		}
+	default:
+		return errors.Errorf("unsupported value type %T", value)
+	}
+
+	_, err := io.WriteString(writer, out)
+	return err
+}
+
+func decryptSOPSString(value string, additionalData string, key []byte) (interface{}, error) {
+	matches := sopsValuePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, errors.New("value is not encrypted")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(matches[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode data")
+	}
+	iv, err := base64.StdEncoding.DecodeString(matches[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode iv")
+	}
+	tag, err := base64.StdEncoding.DecodeString(matches[3])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode tag")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, errors.New("authentication failed")
+	}
+
+	switch matches[4] {
+	case "str", "bytes":
+		return string(plaintext), nil
+	case "int":
+		return strconv.Atoi(string(plaintext))
+	case "float":
+		return strconv.ParseFloat(string(plaintext), 64)
+	case "bool":
+		return strconv.ParseBool(string(plaintext))
+	}
+
+	return nil, errors.Errorf("unexpected type %s", matches[4])
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
+)
+
+// Status is the struct that is returned by the /api/status request
+type Status struct {
+	Name      string            `json:"name,omitempty"`
+	Pipelines []*PipelineStatus `json:"pipelines"`
+	DevPods   []*status.DevPod  `json:"devPods"`
+}
+
+// PipelineStatus describes a running pipeline of the project or one of its dependencies
+type PipelineStatus struct {
+	Name       string `json:"name"`
+	Dependency string `json:"dependency,omitempty"`
+}
+
+func (h *handler) status(w http.ResponseWriter, r *http.Request) {
+	retStatus := &Status{
+		Pipelines: []*PipelineStatus{},
+		DevPods:   status.GetRegistry().List(),
+	}
+	if h.pipeline != nil {
+		retStatus.Name = h.pipeline.Name()
+		retStatus.Pipelines = runningPipelines(h.pipeline, "")
+	}
+
+	b, err := json.Marshal(retStatus)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func runningPipelines(pipe types.Pipeline, dependency string) []*PipelineStatus {
+	retPipelines := []*PipelineStatus{}
+	for _, name := range pipe.RunningPipelines() {
+		retPipelines = append(retPipelines, &PipelineStatus{
+			Name:       name,
+			Dependency: dependency,
+		})
+	}
+
+	dependencies := pipe.Dependencies()
+	dependencyNames := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		dependencyNames = append(dependencyNames, name)
+	}
+	sort.Strings(dependencyNames)
+	for _, name := range dependencyNames {
+		retPipelines = append(retPipelines, runningPipelines(dependencies[name], name)...)
+	}
+
+	return retPipelines
+}
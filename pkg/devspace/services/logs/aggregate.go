@@ -0,0 +1,278 @@
+package logs
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/scanner"
+	"github.com/mgutz/ansi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// refreshInterval is the interval in which new pods are searched for
+var refreshInterval = time.Second * 2
+
+// AggregateOptions describe which containers are streamed by StartAggregatedLogs
+type AggregateOptions struct {
+	Selector selector.Selector
+
+	Follow bool
+	Tail   *int64
+	Since  time.Duration
+	Grep   *regexp.Regexp
+	JSON   bool
+}
+
+// Line is a single log line that is printed with json output
+type Line struct {
+	Time      *time.Time `json:"time,omitempty"`
+	Namespace string     `json:"namespace"`
+	Pod       string     `json:"pod"`
+	Container string     `json:"container"`
+	Message   string     `json:"message"`
+}
+
+type aggregator struct {
+	options AggregateOptions
+	writer  io.Writer
+
+	m         sync.Mutex
+	writerM   sync.Mutex
+	streaming map[string]bool
+	streamed  map[string]string
+	lastTime  map[string]time.Time
+	colors    map[string]string
+}
+
+// StartAggregatedLogs streams the logs of all containers that match the selector with a prefix
+// for each pod. If follow is true, new pods are picked up until the context is done.
+func StartAggregatedLogs(ctx devspacecontext.Context, options AggregateOptions, writer io.Writer) error {
+	a := &aggregator{
+		options:   options,
+		writer:    writer,
+		streaming: map[string]bool{},
+		streamed:  map[string]string{},
+		lastTime:  map[string]time.Time{},
+		colors:    map[string]string{},
+	}
+
+	filter := selector.NewFilter(ctx.KubeClient())
+	waitGroup := sync.WaitGroup{}
+	waiting := false
+	for {
+		containers, err := filter.SelectContainers(ctx.Context(), options.Selector)
+		if err != nil {
+			if ctx.IsDone() {
+				return nil
+			}
+
+			return err
+		}
+
+		started := 0
+		for _, container := range containers {
+			status := containerStatus(container)
+			if status == nil || (status.State.Running == nil && status.State.Terminated == nil) {
+				continue
+			}
+
+			started++
+			if !a.start(container, status) {
+				continue
+			}
+
+			waitGroup.Add(1)
+			go func(container *selector.SelectedPodContainer) {
+				defer waitGroup.Done()
+
+				err := a.stream(ctx, container)
+				if err != nil && !ctx.IsDone() {
+					ctx.Log().Warnf("Error streaming logs of pod:container %s:%s: %v", container.Pod.Name, container.Container.Name, err)
+				}
+			}(container)
+		}
+
+		if !options.Follow {
+			waitGroup.Wait()
+			return nil
+		} else if started == 0 && !waiting {
+			ctx.Log().Infof("Waiting for containers with %s to start...", options.Selector.String())
+		}
+		waiting = started == 0
+
+		select {
+		case <-ctx.Context().Done():
+			waitGroup.Wait()
+			return nil
+		case <-time.After(refreshInterval):
+		}
+	}
+}
+
+func (a *aggregator) start(container *selector.SelectedPodContainer, status *corev1.ContainerStatus) bool {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	// terminated containers are only streamed once
+	key := containerKey(container)
+	if a.streaming[key] || (status.State.Terminated != nil && a.streamed[key] == status.ContainerID) {
+		return false
+	}
+
+	a.streaming[key] = true
+	a.streamed[key] = status.ContainerID
+	if _, ok := a.colors[container.Pod.Name]; !ok {
+		a.colors[container.Pod.Name] = logpkg.Colors[len(a.colors)%len(logpkg.Colors)]
+	}
+	return true
+}
+
+func (a *aggregator) stop(container *selector.SelectedPodContainer, lastTime *time.Time) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	key := containerKey(container)
+	delete(a.streaming, key)
+	if lastTime != nil {
+		a.lastTime[key] = *lastTime
+	}
+}
+
+func (a *aggregator) logOptions(container *selector.SelectedPodContainer) *corev1.PodLogOptions {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	options := &corev1.PodLogOptions{
+		Container:  container.Container.Name,
+		Follow:     a.options.Follow,
+		Timestamps: true,
+	}
+
+	// continue where we stopped if the container was restarted
+	if lastTime, ok := a.lastTime[containerKey(container)]; ok {
+		sinceTime := metav1.NewTime(lastTime.Add(time.Nanosecond))
+		options.SinceTime = &sinceTime
+		return options
+	}
+
+	options.TailLines = a.options.Tail
+	if a.options.Since > 0 {
+		sinceSeconds := int64(a.options.Since.Seconds())
+		if sinceSeconds < 1 {
+			sinceSeconds = 1
+		}
+		options.SinceSeconds = &sinceSeconds
+	}
+	return options
+}
+
+func (a *aggregator) stream(ctx devspacecontext.Context, container *selector.SelectedPodContainer) error {
+	var lastTime *time.Time
+	defer func() {
+		a.stop(container, lastTime)
+	}()
+
+	reader, err := ctx.KubeClient().LogsWithOptions(ctx.Context(), container.Pod.Namespace, container.Pod.Name, a.logOptions(container))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if a.options.Follow {
+		ctx.Log().Infof("Streaming logs of pod:container %s:%s", ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+	}
+
+	s := scanner.NewScanner(reader)
+	for s.Scan() {
+		line := parseLine(container, s.Text())
+		if line.Time != nil {
+			lastTime = line.Time
+		}
+		if a.options.Grep != nil && !a.options.Grep.MatchString(line.Message) {
+			continue
+		}
+
+		err = a.write(line)
+		if err != nil {
+			return err
+		}
+	}
+	if s.Err() != nil && !ctx.IsDone() {
+		return s.Err()
+	}
+
+	if a.options.Follow && !ctx.IsDone() {
+		ctx.Log().Infof("Stopped streaming logs of pod:container %s:%s", ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+	}
+	return nil
+}
+
+func (a *aggregator) write(line *Line) error {
+	var out []byte
+	if a.options.JSON {
+		var err error
+		out, err = json.Marshal(line)
+		if err != nil {
+			return err
+		}
+	} else {
+		a.m.Lock()
+		color := a.colors[line.Pod]
+		a.m.Unlock()
+
+		out = []byte(ansi.Color(line.Pod+":"+line.Container, color) + " " + line.Message)
+	}
+
+	a.writerM.Lock()
+	defer a.writerM.Unlock()
+
+	_, err := a.writer.Write(append(out, '\n'))
+	return err
+}
+
+// parseLine splits the timestamp kubernetes adds in front of every log line from the message
+func parseLine(container *selector.SelectedPodContainer, text string) *Line {
+	line := &Line{
+		Namespace: container.Pod.Namespace,
+		Pod:       container.Pod.Name,
+		Container: container.Container.Name,
+		Message:   text,
+	}
+
+	splitted := strings.SplitN(text, " ", 2)
+	t, err := time.Parse(time.RFC3339Nano, splitted[0])
+	if err != nil {
+		return line
+	}
+
+	line.Time = &t
+	line.Message = ""
+	if len(splitted) == 2 {
+		line.Message = splitted[1]
+	}
+	return line
+}
+
+func containerStatus(container *selector.SelectedPodContainer) *corev1.ContainerStatus {
+	statuses := append([]corev1.ContainerStatus{}, container.Pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, container.Pod.Status.ContainerStatuses...)
+	for i := range statuses {
+		if statuses[i].Name == container.Container.Name {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
+
+func containerKey(container *selector.SelectedPodContainer) string {
+	return container.Pod.Namespace + "/" + container.Pod.Name + "/" + container.Container.Name
+}
@@ -0,0 +1,83 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseLine(t *testing.T) {
+	container := &selector.SelectedPodContainer{
+		Pod:       &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}},
+		Container: &corev1.Container{Name: "container"},
+	}
+
+	line := parseLine(container, "2022-01-02T03:04:05.123456789Z hello world")
+	assert.Assert(t, line.Time != nil)
+	assert.Equal(t, line.Time.Nanosecond(), 123456789)
+	assert.Equal(t, line.Message, "hello world")
+	assert.Equal(t, line.Pod, "pod")
+
+	line = parseLine(container, "no timestamp")
+	assert.Assert(t, line.Time == nil)
+	assert.Equal(t, line.Message, "no timestamp")
+}
+
+func TestStartAggregatedLogs(t *testing.T) {
+	kubeClient := &fakekube.Client{Client: fake.NewSimpleClientset()}
+	for _, name := range []string{"pod-a", "pod-b"} {
+		_, err := kubeClient.Client.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "test"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "container"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "container",
+					Ready: true,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			},
+		}, metav1.CreateOptions{})
+		assert.NilError(t, err)
+	}
+
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithKubeClient(kubeClient)
+	out := &bytes.Buffer{}
+	err := StartAggregatedLogs(ctx, AggregateOptions{
+		Selector: selector.Selector{LabelSelector: "app=test", Namespace: "default"},
+		JSON:     true,
+	}, out)
+	assert.NilError(t, err)
+
+	pods := []string{}
+	for _, l := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		line := &Line{}
+		assert.NilError(t, json.Unmarshal([]byte(l), line))
+		assert.Equal(t, line.Message, "ContainerLogs")
+		pods = append(pods, line.Pod)
+	}
+	sort.Strings(pods)
+	assert.DeepEqual(t, pods, []string{"pod-a", "pod-b"})
+
+	// lines that do not match are dropped
+	out.Reset()
+	err = StartAggregatedLogs(ctx, AggregateOptions{
+		Selector: selector.Selector{LabelSelector: "app=test", Namespace: "default"},
+		Grep:     regexp.MustCompile("error"),
+	}, out)
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "")
+}
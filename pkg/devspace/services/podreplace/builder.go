@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -97,6 +98,54 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 				},
 			})
 		}
+	case *appsv1.DaemonSet:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "DaemonSet"
+		// copy the selector, it is still needed to find the pods of the daemon set
+		deployment.Spec.Selector = t.Spec.Selector.DeepCopy()
+		podTemplate.Labels = t.Spec.Template.Labels
+		podTemplate.Annotations = t.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.Template.Spec.DeepCopy()
+
+		// pin the replaced pod to one of the nodes the daemon set is running on
+		podTemplate.Spec.NodeSelector, err = daemonSetNodeSelector(t)
+		if err != nil {
+			return nil, err
+		}
+		nodeName, err := findDaemonSetNode(ctx, t)
+		if err != nil {
+			return nil, err
+		} else if nodeName != "" {
+			if podTemplate.Spec.NodeSelector == nil {
+				podTemplate.Spec.NodeSelector = map[string]string{}
+			}
+			podTemplate.Spec.NodeSelector[corev1.LabelHostname] = nodeName
+		}
+	case *batchv1.Job:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "Job"
+		podTemplate.Labels = jobPodLabels(t.Spec.Template.Labels)
+		podTemplate.Annotations = t.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.Template.Spec.DeepCopy()
+		podTemplate.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	case *batchv1.CronJob:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "CronJob"
+		podTemplate.Labels = jobPodLabels(t.Spec.JobTemplate.Spec.Template.Labels)
+		podTemplate.Annotations = t.Spec.JobTemplate.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.JobTemplate.Spec.Template.Spec.DeepCopy()
+		podTemplate.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	case *customTarget:
+		deployment.Annotations[TargetNameAnnotation] = t.GetName()
+		deployment.Annotations[TargetKindAnnotation] = t.GetKind()
+		deployment.Annotations[TargetAPIVersionAnnotation] = t.GetAPIVersion()
+		template, err := t.podTemplate()
+		if err != nil {
+			return nil, err
+		}
+		podTemplate.Labels = template.Labels
+		podTemplate.Annotations = template.Annotations
+		podTemplate.Spec = template.Spec
 	default:
 		return nil, fmt.Errorf("unrecognized object")
 	}
@@ -177,6 +226,79 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 	return deployment, nil
 }
 
+// jobPodLabels removes the labels the job controller adds to its pods, otherwise
+// the job controller would try to adopt the replaced pod
+func jobPodLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	retLabels := map[string]string{}
+	for k, v := range labels {
+		if k == "controller-uid" || k == "job-name" || strings.HasPrefix(k, "batch.kubernetes.io/") {
+			continue
+		}
+
+		retLabels[k] = v
+	}
+
+	return retLabels
+}
+
+// daemonSetNodeSelector returns the original node selector of the daemon set, which
+// is replaced while the daemon set is scaled down
+func daemonSetNodeSelector(daemonSet *appsv1.DaemonSet) (map[string]string, error) {
+	if daemonSet.Annotations == nil || daemonSet.Annotations[NodeSelectorAnnotation] == "" {
+		// copy the node selector, the replaced pod adds its node to it
+		var nodeSelector map[string]string
+		for k, v := range daemonSet.Spec.Template.Spec.NodeSelector {
+			if nodeSelector == nil {
+				nodeSelector = map[string]string{}
+			}
+			nodeSelector[k] = v
+		}
+		return nodeSelector, nil
+	}
+
+	nodeSelector := map[string]string{}
+	err := json.Unmarshal([]byte(daemonSet.Annotations[NodeSelectorAnnotation]), &nodeSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse original node selector")
+	}
+
+	return nodeSelector, nil
+}
+
+// findDaemonSetNode returns the node the replaced pod of a daemon set should run on. This is
+// either the node that was remembered while scaling down the daemon set or the node of one of
+// its currently running pods.
+func findDaemonSetNode(ctx devspacecontext.Context, daemonSet *appsv1.DaemonSet) (string, error) {
+	if daemonSet.Annotations != nil && daemonSet.Annotations[NodeAnnotation] != "" {
+		return daemonSet.Annotations[NodeAnnotation], nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return "", errors.Wrap(err, "parse daemon set selector")
+	}
+
+	pods, err := ctx.KubeClient().KubeClient().CoreV1().Pods(daemonSet.Namespace).List(ctx.Context(), metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return "", errors.Wrap(err, "list daemon set pods")
+	}
+
+	nodeName := ""
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || (pod.Labels != nil && pod.Labels[selector.ReplacedLabel] == "true") {
+			continue
+		} else if nodeName == "" || pod.Spec.NodeName < nodeName {
+			nodeName = pod.Spec.NodeName
+		}
+	}
+
+	return nodeName, nil
+}
+
 func modifyDevContainer(ctx devspacecontext.Context, devPod *latest.DevPod, devContainer *latest.DevContainer, podTemplate *corev1.PodTemplateSpec) error {
 	err := replaceImage(ctx, devPod, devContainer, podTemplate)
 	if err != nil {
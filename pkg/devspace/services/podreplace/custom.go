@@ -0,0 +1,214 @@
+package podreplace
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// TargetAPIVersionAnnotation holds the api version of a custom parent
+	TargetAPIVersionAnnotation = "devspace.sh/parent-api-version"
+
+	// ReplicasPathAnnotation holds the path to the replicas field of a custom parent
+	ReplicasPathAnnotation = "devspace.sh/replicas-path"
+
+	defaultPodTemplatePath = ".spec.template"
+	defaultReplicasPath    = ".spec.replicas"
+)
+
+var (
+	dynamicClientsMutex sync.Mutex
+	dynamicClients      = map[*rest.Config]dynamic.Interface{}
+)
+
+// customTarget is a workload of an arbitrary kind that embeds a pod template,
+// such as an Argo Rollout
+type customTarget struct {
+	*unstructured.Unstructured
+
+	PodTemplatePath string
+	ReplicasPath    string
+}
+
+// isCustomTarget checks if the given api version needs to be retrieved through the dynamic client.
+// Workloads of the built-in api versions are always scaled down and up through their typed clients.
+func isCustomTarget(apiVersion string) bool {
+	return apiVersion != "" && apiVersion != "apps/v1" && apiVersion != "batch/v1"
+}
+
+func findCustomTarget(ctx devspacecontext.Context, target *latest.DevPodTarget, namespace string) (*customTarget, error) {
+	obj, err := getCustomTarget(ctx, target.APIVersion, target.Kind, namespace, target.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.PodTemplatePath != "" {
+		obj.PodTemplatePath = target.PodTemplatePath
+	}
+	if target.ReplicasPath != "" {
+		obj.ReplicasPath = target.ReplicasPath
+	}
+
+	return obj, nil
+}
+
+func getCustomTarget(ctx devspacecontext.Context, apiVersion, kind, namespace, name string) (*customTarget, error) {
+	resource, err := customTargetResource(ctx, apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := resource.Namespace(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// the replicas path of a scaled down target is stored in its annotations
+	replicasPath := defaultReplicasPath
+	if obj.GetAnnotations() != nil && obj.GetAnnotations()[ReplicasPathAnnotation] != "" {
+		replicasPath = obj.GetAnnotations()[ReplicasPathAnnotation]
+	}
+
+	return &customTarget{
+		Unstructured:    obj,
+		PodTemplatePath: defaultPodTemplatePath,
+		ReplicasPath:    replicasPath,
+	}, nil
+}
+
+// dynamicClient returns the dynamic client for the rest config, which is shared by
+// all custom targets
+func dynamicClient(restConfig *rest.Config) (dynamic.Interface, error) {
+	dynamicClientsMutex.Lock()
+	defer dynamicClientsMutex.Unlock()
+
+	if dynamicClients[restConfig] != nil {
+		return dynamicClients[restConfig], nil
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+
+	dynamicClients[restConfig] = client
+	return client, nil
+}
+
+func customTargetResource(ctx devspacecontext.Context, apiVersion, kind string) (dynamic.NamespaceableResourceInterface, error) {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse api version %s", apiVersion)
+	}
+
+	resources, err := ctx.KubeClient().KubeClient().Discovery().ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "discover resources of %s", apiVersion)
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind != kind || strings.Contains(resource.Name, "/") {
+			continue
+		}
+
+		client, err := dynamicClient(ctx.KubeClient().RestConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		return client.Resource(groupVersion.WithResource(resource.Name)), nil
+	}
+
+	return nil, fmt.Errorf("couldn't find resource for kind %s in %s", kind, apiVersion)
+}
+
+func (c *customTarget) podTemplate() (*corev1.PodTemplateSpec, error) {
+	path, err := parseFieldPath(c.PodTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, found, err := unstructured.NestedMap(c.Object, path...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get pod template at %s", c.PodTemplatePath)
+	} else if !found {
+		return nil, fmt.Errorf("couldn't find a pod template at %s in %s %s", c.PodTemplatePath, c.GetKind(), c.GetName())
+	}
+
+	podTemplate := &corev1.PodTemplateSpec{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(raw, podTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert pod template")
+	}
+
+	return podTemplate, nil
+}
+
+func (c *customTarget) replicas() (int64, error) {
+	path, err := parseFieldPath(c.ReplicasPath)
+	if err != nil {
+		return 0, err
+	}
+
+	replicas, found, err := unstructured.NestedInt64(c.Object, path...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "get replicas at %s", c.ReplicasPath)
+	} else if !found {
+		return 1, nil
+	}
+
+	return replicas, nil
+}
+
+func (c *customTarget) setReplicas(replicas int64) error {
+	path, err := parseFieldPath(c.ReplicasPath)
+	if err != nil {
+		return err
+	}
+
+	return unstructured.SetNestedField(c.Object, replicas, path...)
+}
+
+func (c *customTarget) patch(ctx devspacecontext.Context, patchType types.PatchType, data []byte) error {
+	resource, err := customTargetResource(ctx, c.GetAPIVersion(), c.GetKind())
+	if err != nil {
+		return err
+	}
+
+	_, err = resource.Namespace(c.GetNamespace()).Patch(ctx.Context(), c.GetName(), patchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// parseFieldPath converts a simple JSONPath such as .spec.template or {.spec.template}
+// into its fields
+func parseFieldPath(path string) ([]string, error) {
+	trimmed := strings.TrimSpace(path)
+	trimmed = strings.TrimSuffix(strings.TrimPrefix(trimmed, "{"), "}")
+	trimmed = strings.TrimPrefix(trimmed, "$")
+	trimmed = strings.TrimPrefix(trimmed, ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid path '%s'", path)
+	}
+
+	fields := strings.Split(trimmed, ".")
+	for _, field := range fields {
+		if field == "" || strings.ContainsAny(field, "[]*") {
+			return nil, fmt.Errorf("invalid path '%s': only simple field paths such as .spec.template are supported", path)
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,80 @@
+package podreplace
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	testCases := map[string][]string{
+		".spec.template":     {"spec", "template"},
+		"{.spec.replicas}":   {"spec", "replicas"},
+		"$.spec.workload.pt": {"spec", "workload", "pt"},
+		"spec.replicas":      {"spec", "replicas"},
+	}
+
+	for path, expected := range testCases {
+		fields, err := parseFieldPath(path)
+		assert.NilError(t, err, path)
+		assert.DeepEqual(t, fields, expected)
+	}
+
+	for _, path := range []string{"", ".", ".spec..template", ".spec.containers[0]"} {
+		_, err := parseFieldPath(path)
+		assert.Assert(t, err != nil, path)
+	}
+}
+
+func TestCustomTarget(t *testing.T) {
+	target := &customTarget{
+		Unstructured: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata": map[string]interface{}{
+				"name": "test",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							"app": "test",
+						},
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name":  "app",
+								"image": "nginx",
+							},
+						},
+					},
+				},
+			},
+		}},
+		PodTemplatePath: defaultPodTemplatePath,
+		ReplicasPath:    defaultReplicasPath,
+	}
+
+	podTemplate, err := target.podTemplate()
+	assert.NilError(t, err)
+	assert.Equal(t, podTemplate.Labels["app"], "test")
+	assert.Equal(t, len(podTemplate.Spec.Containers), 1)
+	assert.Equal(t, podTemplate.Spec.Containers[0].Image, "nginx")
+
+	replicas, err := target.replicas()
+	assert.NilError(t, err)
+	assert.Equal(t, replicas, int64(3))
+
+	err = target.setReplicas(0)
+	assert.NilError(t, err)
+	replicas, err = target.replicas()
+	assert.NilError(t, err)
+	assert.Equal(t, replicas, int64(0))
+
+	target.PodTemplatePath = ".spec.workloadRef"
+	_, err = target.podTemplate()
+	assert.Assert(t, err != nil)
+}
@@ -15,11 +15,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name string) (runtime.Object, error) {
+func findTargetByKindName(ctx devspacecontext.Context, apiVersion, kind, namespace, name string) (runtime.Object, error) {
+	if isCustomTarget(apiVersion) {
+		target, err := getCustomTarget(ctx, apiVersion, kind, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return target, nil
+	}
+
 	var (
 		err    error
 		parent runtime.Object
 	)
+	apiVersion = "apps/v1"
 	switch kind {
 	case "ReplicaSet":
 		parent, err = ctx.KubeClient().KubeClient().AppsV1().ReplicaSets(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
@@ -27,6 +37,14 @@ func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name str
 		parent, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
 	case "StatefulSet":
 		parent, err = ctx.KubeClient().KubeClient().AppsV1().StatefulSets(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	case "DaemonSet":
+		parent, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	case "Job":
+		apiVersion = "batch/v1"
+		parent, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	case "CronJob":
+		apiVersion = "batch/v1"
+		parent, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
 	default:
 		return nil, fmt.Errorf("unrecognized parent kind")
 	}
@@ -35,7 +53,7 @@ func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name str
 	}
 
 	typeAccessor, _ := meta.TypeAccessor(parent)
-	typeAccessor.SetAPIVersion("apps/v1")
+	typeAccessor.SetAPIVersion(apiVersion)
 	typeAccessor.SetKind(kind)
 	return parent, nil
 }
@@ -103,6 +121,63 @@ func findTargetBySelector(ctx devspacecontext.Context, devPod *latest.DevPod, fi
 		}
 	}
 
+	// daemonSets
+	daemonSets, err := ctx.KubeClient().KubeClient().AppsV1().DaemonSets(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list DaemonSets")
+	}
+	for _, d := range daemonSets.Items {
+		if filter != nil && !filter(&d) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &d.Spec.Template, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "DaemonSet"
+			return &d, nil
+		}
+	}
+
+	// cronJobs
+	cronJobs, err := ctx.KubeClient().KubeClient().BatchV1().CronJobs(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list CronJobs")
+	}
+	for _, d := range cronJobs.Items {
+		if filter != nil && !filter(&d) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &d.Spec.JobTemplate.Spec.Template, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "CronJob"
+			return &d, nil
+		}
+	}
+
+	// jobs
+	jobs, err := ctx.KubeClient().KubeClient().BatchV1().Jobs(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list Jobs")
+	}
+	for _, d := range jobs.Items {
+		if len(d.OwnerReferences) > 0 || (filter != nil && !filter(&d)) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &d.Spec.Template, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "Job"
+			return &d, nil
+		}
+	}
+
 	return nil, nil
 }
 
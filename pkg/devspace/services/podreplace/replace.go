@@ -1,6 +1,7 @@
 package podreplace
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +34,13 @@ const (
 	DevPodConfigHashAnnotation = "devspace.sh/config-hash"
 
 	ReplicasAnnotation = "devspace.sh/replicas"
+
+	// SuspendAnnotation holds the original suspend value of a replaced Job or CronJob
+	SuspendAnnotation = "devspace.sh/suspend"
+	// NodeSelectorAnnotation holds the original node selector of a replaced DaemonSet
+	NodeSelectorAnnotation = "devspace.sh/node-selector"
+	// NodeAnnotation holds the node the replaced pod of a DaemonSet is pinned to
+	NodeAnnotation = "devspace.sh/node"
 )
 
 type PodReplacer interface {
@@ -97,14 +106,18 @@ func (p *replacer) ReplacePod(ctx devspacecontext.Context, devPod *latest.DevPod
 	}
 
 	// try to find a replaceable deployment statefulset etc.
-	target, err := findTargetBySelector(ctx, devPod, nil)
+	target, err := findTarget(ctx, devPod, namespace)
 	if err != nil {
 		return err
 	} else if target == nil {
-		return fmt.Errorf("couldn't find a matching deployment, statefulset or replica set")
+		return fmt.Errorf("couldn't find a matching deployment, statefulset, replica set, daemon set, job or cron job")
 	}
 
 	// make sure we already save the cache here
+	devPodCache.TargetAPIVersion = ""
+	if devPod.Target != nil {
+		devPodCache.TargetAPIVersion = devPod.Target.APIVersion
+	}
 	devPodCache.TargetKind = target.GetObjectKind().GroupVersionKind().Kind
 	devPodCache.TargetName = target.(metav1.Object).GetName()
 	devPodCache.Deployment = target.(metav1.Object).GetName() + "-devspace"
@@ -130,7 +143,12 @@ func updateNeeded(ctx devspacecontext.Context, deployment *appsv1.Deployment, de
 		return true, deleteDeployment(ctx, deployment)
 	}
 
-	target, err := findTargetByKindName(ctx, deployment.Annotations[TargetKindAnnotation], deployment.Namespace, deployment.Annotations[TargetNameAnnotation])
+	var target runtime.Object
+	if devPod.Target != nil {
+		target, err = findTarget(ctx, devPod, deployment.Namespace)
+	} else {
+		target, err = findTargetByKindName(ctx, deployment.Annotations[TargetAPIVersionAnnotation], deployment.Annotations[TargetKindAnnotation], deployment.Namespace, deployment.Annotations[TargetNameAnnotation])
+	}
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			return true, deleteDeployment(ctx, deployment)
@@ -193,6 +211,30 @@ func updateNeeded(ctx devspacecontext.Context, deployment *appsv1.Deployment, de
 	return false, nil
 }
 
+// findTarget returns the workload referenced by dev.*.target or searches for a
+// workload that matches the selector of the dev pod
+func findTarget(ctx devspacecontext.Context, devPod *latest.DevPod, namespace string) (runtime.Object, error) {
+	if devPod.Target != nil {
+		var (
+			target runtime.Object
+			err    error
+		)
+		if isCustomTarget(devPod.Target.APIVersion) {
+			target, err = findCustomTarget(ctx, devPod.Target, namespace)
+		} else {
+			// use the same typed path as reverting the replacement
+			target, err = findTargetByKindName(ctx, devPod.Target.APIVersion, devPod.Target.Kind, namespace, devPod.Target.Name)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "find %s %s", devPod.Target.Kind, devPod.Target.Name)
+		}
+
+		return target, nil
+	}
+
+	return findTargetBySelector(ctx, devPod, nil)
+}
+
 func deleteDeployment(ctx devspacecontext.Context, deployment *appsv1.Deployment) error {
 	// delete the owning deployment or pod
 	err := ctx.KubeClient().KubeClient().AppsV1().Deployments(deployment.Namespace).Delete(ctx.Context(), deployment.Name, metav1.DeleteOptions{})
@@ -446,6 +488,117 @@ func scaleDownTarget(ctx devspacecontext.Context, obj runtime.Object) error {
 		}
 
 		return nil
+	case *appsv1.DaemonSet:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		// daemon sets cannot be scaled, so we use a node selector that matches no node
+		if _, ok := t.Annotations[NodeSelectorAnnotation]; ok {
+			return nil
+		}
+
+		// remember the node the replaced pod is pinned to
+		nodeName, err := findDaemonSetNode(ctx, t)
+		if err != nil {
+			return err
+		} else if nodeName != "" {
+			t.Annotations[NodeAnnotation] = nodeName
+		}
+
+		nodeSelector, err := json.Marshal(t.Spec.Template.Spec.NodeSelector)
+		if err != nil {
+			return err
+		}
+
+		t.Annotations[NodeSelectorAnnotation] = string(nodeSelector)
+		t.Spec.Template.Spec.NodeSelector = map[string]string{selector.ReplacedLabel: "true"}
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *batchv1.Job:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		if _, ok := t.Annotations[SuspendAnnotation]; ok {
+			return nil
+		}
+
+		t.Annotations[SuspendAnnotation] = strconv.FormatBool(t.Spec.Suspend != nil && *t.Spec.Suspend)
+		t.Spec.Suspend = ptr.Bool(true)
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *batchv1.CronJob:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		if _, ok := t.Annotations[SuspendAnnotation]; ok {
+			return nil
+		}
+
+		t.Annotations[SuspendAnnotation] = strconv.FormatBool(t.Spec.Suspend != nil && *t.Spec.Suspend)
+		t.Spec.Suspend = ptr.Bool(true)
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *customTarget:
+		annotations := t.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		replicas, err := t.replicas()
+		if err != nil {
+			return err
+		} else if replicas == 0 {
+			return nil
+		}
+
+		annotations[ReplicasAnnotation] = strconv.FormatInt(replicas, 10)
+		annotations[ReplicasPathAnnotation] = t.ReplicasPath
+		t.SetAnnotations(annotations)
+		err = t.setReplicas(0)
+		if err != nil {
+			return err
+		}
+
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		return t.patch(ctx, patch.Type(), bytes)
 	}
 
 	return fmt.Errorf("unrecognized object")
@@ -0,0 +1,199 @@
+package podreplace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testLabels = map[string]string{"app": "test"}
+
+func testPodTemplate(nodeSelector map[string]string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: testLabels},
+		Spec: corev1.PodSpec{
+			NodeSelector: nodeSelector,
+			Containers:   []corev1.Container{{Name: "app", Image: "nginx"}},
+		},
+	}
+}
+
+func newTestContext(kubeClient *fake.Clientset) (devspacecontext.Context, *remotecache.RemoteCache) {
+	cache := remotecache.NewCache("test", "devspace-cache-test")
+	conf := config.NewConfig(nil, nil, &latest.Config{}, localcache.New(""), cache, nil, "")
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.NewFakeLogger()).
+		WithConfig(conf).
+		WithKubeClient(&kubectltesting.Client{Client: kubeClient})
+	return ctx, cache
+}
+
+func replaceAndRevert(t *testing.T, ctx devspacecontext.Context, cache *remotecache.RemoteCache, kubeClient *fake.Clientset, devPod *latest.DevPod, kind string, replaced func(), reverted func()) {
+	replacer := NewPodReplacer()
+	err := replacer.ReplacePod(ctx, devPod)
+	assert.NilError(t, err, kind)
+
+	devPodCache, ok := cache.GetDevPod("test")
+	assert.Assert(t, ok, kind)
+	assert.Equal(t, devPodCache.TargetKind, kind)
+	assert.Equal(t, devPodCache.Deployment, "test-devspace")
+
+	deployment, err := kubeClient.AppsV1().Deployments("testNamespace").Get(context.Background(), "test-devspace", metav1.GetOptions{})
+	assert.NilError(t, err, kind)
+	assert.Equal(t, deployment.Annotations[TargetKindAnnotation], kind)
+	assert.Equal(t, deployment.Annotations[TargetNameAnnotation], "test")
+	replaced()
+
+	deleted, err := replacer.RevertReplacePod(ctx, &devPodCache, nil)
+	assert.NilError(t, err, kind)
+	assert.Assert(t, deleted, kind)
+	_, ok = cache.GetDevPod("test")
+	assert.Assert(t, !ok, kind)
+	reverted()
+}
+
+func TestReplaceDaemonSet(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testNamespace"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: testLabels},
+				Template: testPodTemplate(map[string]string{"disk": "ssd"}),
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-b", Namespace: "testNamespace", Labels: testLabels},
+			Spec:       corev1.PodSpec{NodeName: "node-b"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-a", Namespace: "testNamespace", Labels: testLabels},
+			Spec:       corev1.PodSpec{NodeName: "node-a"},
+		},
+	)
+	ctx, cache := newTestContext(kubeClient)
+	getDaemonSet := func() *appsv1.DaemonSet {
+		daemonSet, err := kubeClient.AppsV1().DaemonSets("testNamespace").Get(context.Background(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return daemonSet
+	}
+
+	replaceAndRevert(t, ctx, cache, kubeClient, &latest.DevPod{Name: "test", LabelSelector: testLabels}, "DaemonSet", func() {
+		daemonSet := getDaemonSet()
+		assert.Equal(t, daemonSet.Annotations[NodeSelectorAnnotation], `{"disk":"ssd"}`)
+		assert.Equal(t, daemonSet.Annotations[NodeAnnotation], "node-a")
+		assert.DeepEqual(t, daemonSet.Spec.Template.Spec.NodeSelector, map[string]string{selector.ReplacedLabel: "true"})
+
+		// the replaced pod runs on the node of the daemon set pod it replaces
+		deployment, err := kubeClient.AppsV1().Deployments("testNamespace").Get(context.Background(), "test-devspace", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, deployment.Spec.Template.Spec.NodeSelector["disk"], "ssd")
+		assert.Equal(t, deployment.Spec.Template.Spec.NodeSelector[corev1.LabelHostname], "node-a")
+	}, func() {
+		daemonSet := getDaemonSet()
+		_, ok := daemonSet.Annotations[NodeSelectorAnnotation]
+		assert.Assert(t, !ok)
+		_, ok = daemonSet.Annotations[NodeAnnotation]
+		assert.Assert(t, !ok)
+		assert.DeepEqual(t, daemonSet.Spec.Template.Spec.NodeSelector, map[string]string{"disk": "ssd"})
+	})
+}
+
+func TestReplaceJob(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testNamespace"},
+		Spec: batchv1.JobSpec{
+			Template: testPodTemplate(nil),
+		},
+	})
+	ctx, cache := newTestContext(kubeClient)
+	getJob := func() *batchv1.Job {
+		job, err := kubeClient.BatchV1().Jobs("testNamespace").Get(context.Background(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return job
+	}
+
+	replaceAndRevert(t, ctx, cache, kubeClient, &latest.DevPod{Name: "test", LabelSelector: testLabels}, "Job", func() {
+		job := getJob()
+		assert.Equal(t, job.Annotations[SuspendAnnotation], "false")
+		assert.Equal(t, *job.Spec.Suspend, true)
+	}, func() {
+		job := getJob()
+		_, ok := job.Annotations[SuspendAnnotation]
+		assert.Assert(t, !ok)
+		assert.Equal(t, *job.Spec.Suspend, false)
+	})
+}
+
+func TestReplaceCronJob(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testNamespace"},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			Suspend:  ptr.Bool(true),
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: testPodTemplate(nil)},
+			},
+		},
+	})
+	ctx, cache := newTestContext(kubeClient)
+	getCronJob := func() *batchv1.CronJob {
+		cronJob, err := kubeClient.BatchV1().CronJobs("testNamespace").Get(context.Background(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return cronJob
+	}
+
+	// an already suspended cron job stays suspended after the revert
+	replaceAndRevert(t, ctx, cache, kubeClient, &latest.DevPod{Name: "test", LabelSelector: testLabels}, "CronJob", func() {
+		cronJob := getCronJob()
+		assert.Equal(t, cronJob.Annotations[SuspendAnnotation], "true")
+		assert.Equal(t, *cronJob.Spec.Suspend, true)
+	}, func() {
+		cronJob := getCronJob()
+		_, ok := cronJob.Annotations[SuspendAnnotation]
+		assert.Assert(t, !ok)
+		assert.Equal(t, *cronJob.Spec.Suspend, true)
+	})
+}
+
+func TestReplaceTargetDeployment(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testNamespace"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(3),
+			Selector: &metav1.LabelSelector{MatchLabels: testLabels},
+			Template: testPodTemplate(nil),
+		},
+	})
+	ctx, cache := newTestContext(kubeClient)
+	getDeployment := func() *appsv1.Deployment {
+		deployment, err := kubeClient.AppsV1().Deployments("testNamespace").Get(context.Background(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return deployment
+	}
+
+	// built-in targets are scaled down and up the same way as selected workloads
+	devPod := &latest.DevPod{Name: "test", Target: &latest.DevPodTarget{APIVersion: "apps/v1", Kind: "Deployment", Name: "test"}}
+	replaceAndRevert(t, ctx, cache, kubeClient, devPod, "Deployment", func() {
+		deployment := getDeployment()
+		assert.Equal(t, deployment.Annotations[ReplicasAnnotation], "3")
+		assert.Equal(t, *deployment.Spec.Replicas, int32(0))
+	}, func() {
+		deployment := getDeployment()
+		assert.Equal(t, len(deployment.Annotations), 0)
+		assert.Equal(t, *deployment.Spec.Replicas, int32(3))
+	})
+}
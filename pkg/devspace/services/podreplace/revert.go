@@ -8,6 +8,7 @@ import (
 	patch2 "github.com/loft-sh/devspace/pkg/util/patch"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,7 +63,7 @@ func (p *replacer) RevertReplacePod(ctx devspacecontext.Context, devPodCache *re
 	}
 
 	// scale up parent
-	parent, err := findTargetByKindName(ctx, devPodCache.TargetKind, namespace, devPodCache.TargetName)
+	parent, err := findTargetByKindName(ctx, devPodCache.TargetAPIVersion, devPodCache.TargetKind, namespace, devPodCache.TargetName)
 	if err != nil {
 		ctx.Log().Debugf("Error getting parent by name: %v", err)
 		ctx.Config().RemoteCache().DeleteDevPod(devPodCache.Name)
@@ -89,30 +90,94 @@ func scaleUpTarget(ctx devspacecontext.Context, parent runtime.Object) error {
 
 	// check if required annotation is there
 	annotations := metaParent.GetAnnotations()
-	if annotations == nil || annotations[ReplicasAnnotation] == "" {
+	if annotations == nil {
 		return nil
 	}
 
-	// scale up parent
-	oldReplica, err := strconv.Atoi(annotations[ReplicasAnnotation])
-	if err != nil {
-		return errors.Wrap(err, "parse old replicas")
-	} else if oldReplica == 0 {
-		return nil
-	}
-
-	oldReplica32 := int32(oldReplica)
 	switch t := parent.(type) {
-	case *appsv1.ReplicaSet:
-		t.Spec.Replicas = &oldReplica32
-	case *appsv1.Deployment:
-		t.Spec.Replicas = &oldReplica32
-	case *appsv1.StatefulSet:
-		t.Spec.Replicas = &oldReplica32
+	case *appsv1.ReplicaSet, *appsv1.Deployment, *appsv1.StatefulSet:
+		if annotations[ReplicasAnnotation] == "" {
+			return nil
+		}
+
+		// scale up parent
+		oldReplica, err := strconv.Atoi(annotations[ReplicasAnnotation])
+		if err != nil {
+			return errors.Wrap(err, "parse old replicas")
+		} else if oldReplica == 0 {
+			return nil
+		}
+
+		oldReplica32 := int32(oldReplica)
+		switch t := t.(type) {
+		case *appsv1.ReplicaSet:
+			t.Spec.Replicas = &oldReplica32
+		case *appsv1.Deployment:
+			t.Spec.Replicas = &oldReplica32
+		case *appsv1.StatefulSet:
+			t.Spec.Replicas = &oldReplica32
+		}
+
+		// delete replicas annotation
+		delete(annotations, ReplicasAnnotation)
+	case *appsv1.DaemonSet:
+		if _, ok := annotations[NodeSelectorAnnotation]; !ok {
+			return nil
+		}
+
+		// restore the original node selector
+		nodeSelector, err := daemonSetNodeSelector(t)
+		if err != nil {
+			return err
+		}
+
+		t.Spec.Template.Spec.NodeSelector = nodeSelector
+		delete(annotations, NodeSelectorAnnotation)
+		delete(annotations, NodeAnnotation)
+	case *batchv1.Job, *batchv1.CronJob:
+		if annotations[SuspendAnnotation] == "" {
+			return nil
+		}
+
+		// restore the original suspend value
+		suspend, err := strconv.ParseBool(annotations[SuspendAnnotation])
+		if err != nil {
+			return errors.Wrap(err, "parse old suspend")
+		}
+
+		switch t := t.(type) {
+		case *batchv1.Job:
+			t.Spec.Suspend = &suspend
+		case *batchv1.CronJob:
+			t.Spec.Suspend = &suspend
+		}
+
+		delete(annotations, SuspendAnnotation)
+	case *customTarget:
+		if annotations[ReplicasAnnotation] == "" {
+			return nil
+		}
+
+		// scale up parent
+		oldReplica, err := strconv.ParseInt(annotations[ReplicasAnnotation], 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parse old replicas")
+		} else if oldReplica == 0 {
+			return nil
+		}
+
+		err = t.setReplicas(oldReplica)
+		if err != nil {
+			return errors.Wrap(err, "set replicas")
+		}
+
+		// delete replicas annotations
+		delete(annotations, ReplicasAnnotation)
+		delete(annotations, ReplicasPathAnnotation)
+	default:
+		return nil
 	}
 
-	// delete replicas annotation
-	delete(annotations, ReplicasAnnotation)
 	metaParent.SetAnnotations(annotations)
 
 	// create patch
@@ -130,6 +195,14 @@ func scaleUpTarget(ctx devspacecontext.Context, parent runtime.Object) error {
 		_, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
 	case *appsv1.StatefulSet:
 		_, err = ctx.KubeClient().KubeClient().AppsV1().StatefulSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *appsv1.DaemonSet:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *batchv1.Job:
+		_, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *batchv1.CronJob:
+		_, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *customTarget:
+		err = t.patch(ctx, patch.Type(), bytes)
 	}
 	if err != nil {
 		return errors.Wrap(err, "patch parent")
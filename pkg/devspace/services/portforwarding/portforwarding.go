@@ -14,7 +14,9 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
 	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/pkg/errors"
@@ -28,9 +30,21 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 
 	// forward
 	initDoneArray := []chan struct{}{}
-	if len(devPod.Ports) > 0 {
+	tcpPorts, udpPorts := splitPortMappings(devPod.Ports)
+	if len(tcpPorts) > 0 {
 		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
-			return startPortForwardingWithHooks(ctx, devPod.Name, devPod.Ports, selector, parent)
+			return startPortForwardingWithHooks(ctx, devPod.Name, tcpPorts, func() error {
+				return StartForwarding(ctx, devPod.Name, tcpPorts, selector, parent)
+			})
+		}))
+	}
+
+	// udp ports are forwarded through the helper
+	if len(udpPorts) > 0 {
+		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
+			return startPortForwardingWithHooks(ctx, devPod.Name, udpPorts, func() error {
+				return StartUDPForwarding(ctx, devPod.Name, string(devPod.Arch), udpPorts, selector.WithContainer(devPod.Container), parent)
+			})
 		}))
 	}
 
@@ -62,6 +76,7 @@ func startReversePortForwardingWithHooks(ctx devspacecontext.Context, name, arch
 	// start reverse port forwarding
 	err := StartReversePortForwarding(ctx, name, arch, portMappings, selector, parent)
 	if err != nil {
+		setPortForwardStatus(ctx, name, true, "", portMappings, err)
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"reverse_port_forwarding_config": portMappings,
 			"error":                          err,
@@ -76,7 +91,7 @@ func startReversePortForwardingWithHooks(ctx devspacecontext.Context, name, arch
 	return nil
 }
 
-func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, portMappings []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, portMappings []*latest.PortMapping, start func() error) error {
 	pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 		"port_forwarding_config": portMappings,
 	}, hook.EventsForSingle("start:portForwarding", name).With("portForwarding.start")...)
@@ -85,8 +100,9 @@ func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, port
 	}
 
 	// start port forwarding
-	err := StartForwarding(ctx, name, portMappings, selector, parent)
+	err := start()
 	if err != nil {
+		setPortForwardStatus(ctx, name, false, "", portMappings, err)
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"port_forwarding_config": portMappings,
 			"error":                  err,
@@ -164,6 +180,7 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 	case <-ctx.Context().Done():
 		return nil
 	case <-readyChan:
+		setPortForwardStatus(ctx, name, false, "", portMappings, nil)
 		ctx.Log().Donef("Port forwarding started on: %s", strings.Join(portsFormatted, ", "))
 	case err := <-errorChan:
 		if ctx.IsDone() {
@@ -187,6 +204,7 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 				return nil
 			}
 			if err != nil {
+				setPortForwardStatus(ctx, name, false, "", portMappings, err)
 				ctx.Log().Errorf("Restarting because: %v", err)
 				shouldExit := sync.PrintPodError(ctx.Context(), ctx.KubeClient(), pod, ctx.Log())
 				pf.Close()
@@ -202,6 +220,7 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 				for {
 					err = StartForwarding(ctx, name, portMappings, selector, parent)
 					if err != nil {
+						setPortForwardStatus(ctx, name, false, "", portMappings, err)
 						hook.LogExecuteHooks(ctx, map[string]interface{}{
 							"port_forwarding_config": portMappings,
 							"error":                  err,
@@ -237,3 +256,40 @@ func stopPortForwarding(ctx devspacecontext.Context, name string, portMappings [
 		ctx.Log().Debugf("Stopped port forwarding %v", m.Port)
 	}
 }
+
+func setPortForwardStatus(ctx devspacecontext.Context, name string, reverse bool, container string, portMappings []*latest.PortMapping, err error) {
+	portForward := &status.PortForward{
+		Ports:     make([]string, 0, len(portMappings)),
+		Container: container,
+		Healthy:   err == nil,
+	}
+	for _, m := range portMappings {
+		if m.Protocol == latest.PortProtocolUDP {
+			portForward.Ports = append(portForward.Ports, m.Port+"/udp")
+			continue
+		}
+
+		portForward.Ports = append(portForward.Ports, m.Port)
+	}
+	if err != nil {
+		portForward.Error = err.Error()
+	}
+
+	dependency, _ := values.DependencyNameFrom(ctx.Context())
+	status.GetRegistry().SetPortForward(dependency, name, reverse, portForward)
+}
+
+func splitPortMappings(portMappings []*latest.PortMapping) ([]*latest.PortMapping, []*latest.PortMapping) {
+	tcpPorts := []*latest.PortMapping{}
+	udpPorts := []*latest.PortMapping{}
+	for _, portMapping := range portMappings {
+		if portMapping.Protocol == latest.PortProtocolUDP {
+			udpPorts = append(udpPorts, portMapping)
+			continue
+		}
+
+		tcpPorts = append(tcpPorts, portMapping)
+	}
+
+	return tcpPorts, udpPorts
+}
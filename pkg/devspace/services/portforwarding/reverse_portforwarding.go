@@ -17,6 +17,15 @@ import (
 )
 
 func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string, portForwarding []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	return startHelperForwarding(ctx, name, arch, portForwarding, selector, true, parent)
+}
+
+// StartUDPForwarding forwards the given udp ports to the container through the DevSpace helper
+func StartUDPForwarding(ctx devspacecontext.Context, name, arch string, portForwarding []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	return startHelperForwarding(ctx, name, arch, portForwarding, selector, false, parent)
+}
+
+func startHelperForwarding(ctx devspacecontext.Context, name, arch string, portForwarding []*latest.PortMapping, selector targetselector.TargetSelector, reverse bool, parent *tomb.Tomb) error {
 	if ctx.IsDone() {
 		return nil
 	}
@@ -45,57 +54,70 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 	}()
 
 	go func() {
-		err := tunnel.StartReverseForward(ctx.Context(), stdoutReader, stdinWriter, portForwarding, closeChan, container.Pod.Namespace, container.Pod.Name, ctx.Log())
+		var err error
+		if reverse {
+			err = tunnel.StartReverseForward(ctx.Context(), stdoutReader, stdinWriter, portForwarding, closeChan, container.Pod.Namespace, container.Pod.Name, ctx.Log())
+		} else {
+			err = tunnel.StartForward(ctx.Context(), stdoutReader, stdinWriter, portForwarding, closeChan, ctx.Log())
+		}
 		if err != nil {
 			errorChan <- err
 		}
 	}()
 
+	hookConfig, hookName, description := "reverse_port_forwarding_config", "reversePortForwarding", "reverse port-forwarding"
+	if !reverse {
+		hookConfig, hookName, description = "port_forwarding_config", "portForwarding", "port-forwarding"
+	}
+
+	setPortForwardStatus(ctx, name, reverse, container.Container.Name, portForwarding, nil)
 	parent.Go(func() error {
 		select {
 		case <-ctx.Context().Done():
 			close(closeChan)
 			_ = stdinWriter.Close()
 			_ = stdoutWriter.Close()
-			doneReverseForwarding(ctx, name, portForwarding, parent)
+			doneHelperForwarding(ctx, name, portForwarding, reverse, parent)
 		case err := <-errorChan:
 			if ctx.IsDone() {
 				close(closeChan)
 				_ = stdinWriter.Close()
 				_ = stdoutWriter.Close()
-				doneReverseForwarding(ctx, name, portForwarding, parent)
+				doneHelperForwarding(ctx, name, portForwarding, reverse, parent)
 				return nil
 			}
 			if err != nil {
+				setPortForwardStatus(ctx, name, reverse, container.Container.Name, portForwarding, err)
 				ctx.Log().Errorf("Restarting because: %v", err)
 				shouldExit := sync.PrintPodError(ctx.Context(), ctx.KubeClient(), container.Pod, ctx.Log())
 				close(closeChan)
 				_ = stdinWriter.Close()
 				_ = stdoutWriter.Close()
 				hook.LogExecuteHooks(ctx, map[string]interface{}{
-					"reverse_port_forwarding_config": portForwarding,
-					"error":                          err,
-				}, hook.EventsForSingle("restart:reversePortForwarding", name).With("reversePortForwarding.restart")...)
+					hookConfig: portForwarding,
+					"error":    err,
+				}, hook.EventsForSingle("restart:"+hookName, name).With(hookName+".restart")...)
 				if shouldExit {
-					doneReverseForwarding(ctx, name, portForwarding, parent)
+					doneHelperForwarding(ctx, name, portForwarding, reverse, parent)
 					return nil
 				}
 
 				for {
-					err = StartReversePortForwarding(ctx, name, arch, portForwarding, selector, parent)
+					err = startHelperForwarding(ctx, name, arch, portForwarding, selector, reverse, parent)
 					if err != nil {
+						setPortForwardStatus(ctx, name, reverse, container.Container.Name, portForwarding, err)
 						hook.LogExecuteHooks(ctx, map[string]interface{}{
-							"reverse_port_forwarding_config": portForwarding,
-							"error":                          err,
-						}, hook.EventsForSingle("restart:reversePortForwarding", name).With("reversePortForwarding.restart")...)
-						ctx.Log().Errorf("Error restarting reverse port-forwarding: %v", err)
+							hookConfig: portForwarding,
+							"error":    err,
+						}, hook.EventsForSingle("restart:"+hookName, name).With(hookName+".restart")...)
+						ctx.Log().Errorf("Error restarting %s: %v", description, err)
 						ctx.Log().Errorf("Will try again in 15 seconds")
 
 						select {
 						case <-time.After(time.Second * 15):
 							continue
 						case <-ctx.Context().Done():
-							doneReverseForwarding(ctx, name, portForwarding, parent)
+							doneHelperForwarding(ctx, name, portForwarding, reverse, parent)
 							return nil
 						}
 					}
@@ -110,7 +132,12 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 	return nil
 }
 
-func doneReverseForwarding(ctx devspacecontext.Context, name string, portForwarding []*latest.PortMapping, parent *tomb.Tomb) {
+func doneHelperForwarding(ctx devspacecontext.Context, name string, portForwarding []*latest.PortMapping, reverse bool, parent *tomb.Tomb) {
+	if !reverse {
+		stopPortForwarding(ctx, name, portForwarding, parent)
+		return
+	}
+
 	hook.LogExecuteHooks(ctx, map[string]interface{}{
 		"reverse_port_forwarding_config": portForwarding,
 	}, hook.EventsForSingle("stop:reversePortForwarding", name).With("reversePortForwarding.stop")...)
@@ -0,0 +1,183 @@
+package proxycommands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/survey"
+	"github.com/pkg/errors"
+)
+
+const (
+	confirmYes = "Yes"
+	confirmNo  = "No"
+)
+
+// newAuditLog returns the log every invocation of a proxy command is written to
+func newAuditLog() log.Logger {
+	return log.GetFileLogger("proxy-commands")
+}
+
+// policyEnforcer checks invocations against the policy of a proxy command and remembers
+// which commands were already confirmed by the user
+type policyEnforcer struct {
+	log log.Logger
+
+	// policies are the compiled policies of the proxy commands
+	policies map[*latest.ProxyCommandPolicy]*compiledPolicy
+
+	m         sync.Mutex
+	confirmed map[string]bool
+}
+
+// compiledPolicy is a policy with the allowed args compiled to regular expressions
+type compiledPolicy struct {
+	deniedFlags []string
+	allowedArgs []*regexp.Regexp
+
+	err error
+}
+
+func newPolicyEnforcer(commands []*latest.ProxyCommand, log log.Logger) *policyEnforcer {
+	policies := map[*latest.ProxyCommandPolicy]*compiledPolicy{}
+	for _, command := range commands {
+		if command.Policy != nil {
+			policies[command.Policy] = compilePolicy(command.Policy)
+		}
+	}
+
+	return &policyEnforcer{
+		log:       log,
+		policies:  policies,
+		confirmed: map[string]bool{},
+	}
+}
+
+func compilePolicy(policy *latest.ProxyCommandPolicy) *compiledPolicy {
+	compiled := &compiledPolicy{deniedFlags: policy.DeniedFlags}
+	for _, allowedArgs := range policy.AllowedArgs {
+		regex, err := regexp.Compile("^(?:" + allowedArgs + ")$")
+		if err != nil {
+			compiled.err = errors.Wrapf(err, "compile allowed args %s", allowedArgs)
+			return compiled
+		}
+
+		compiled.allowedArgs = append(compiled.allowedArgs, regex)
+	}
+
+	return compiled
+}
+
+// Check returns an error if the command is not allowed to be invoked with the given arguments
+func (p *policyEnforcer) Check(name string, policy *latest.ProxyCommandPolicy, args []string) error {
+	if policy == nil {
+		return nil
+	}
+
+	compiled, ok := p.policies[policy]
+	if !ok {
+		compiled = compilePolicy(policy)
+	}
+
+	err := compiled.checkArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if policy.Confirm {
+		return p.confirm(name, args)
+	}
+
+	return nil
+}
+
+func (p *policyEnforcer) confirm(name string, args []string) error {
+	// hold the lock while asking, so parallel invocations are not asked twice
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.confirmed[name] {
+		return nil
+	}
+
+	answer, err := p.log.Question(&survey.QuestionOptions{
+		Question:     fmt.Sprintf("The container wants to run '%s' on your computer. Allow '%s' for the rest of this session?", strings.Join(append([]string{name}, args...), " "), name),
+		DefaultValue: confirmNo,
+		Options:      []string{confirmYes, confirmNo},
+	})
+	if err != nil {
+		return errors.Wrap(err, "confirm command")
+	} else if answer != confirmYes {
+		return fmt.Errorf("command %s was denied by the user", name)
+	}
+
+	p.confirmed[name] = true
+	return nil
+}
+
+func (c *compiledPolicy) checkArgs(args []string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	for _, arg := range args {
+		for _, deniedFlag := range c.deniedFlags {
+			if isDeniedFlag(arg, deniedFlag) {
+				return fmt.Errorf("flag %s is not allowed", deniedFlag)
+			}
+		}
+	}
+
+	if len(c.allowedArgs) == 0 {
+		return nil
+	}
+
+	joinedArgs := joinArgs(args)
+	for _, allowedArgs := range c.allowedArgs {
+		if allowedArgs.MatchString(joinedArgs) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("arguments '%s' are not allowed", joinedArgs)
+}
+
+// joinArgs joins the arguments with spaces. Arguments that are empty or contain whitespace,
+// quotes or backslashes are single quoted like in a shell, so that the argument boundaries
+// stay unambiguous and an argument can't pass for several arguments or the other way round.
+func joinArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\r\v\f'\"\\") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+
+		quoted = append(quoted, arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// isDeniedFlag checks if the argument sets the denied flag. Besides the flag itself and the flag
+// with a value, long options match if the argument is an abbreviation of them (--outp=file),
+// because getopt and git accept unique prefixes. Short options match if they are combined with
+// other short options or their value (-vX, -cvalue). This also denies a few arguments that don't
+// set the flag, such as a value of another short option that contains the letter.
+func isDeniedFlag(arg, deniedFlag string) bool {
+	if arg == deniedFlag || strings.HasPrefix(arg, deniedFlag+"=") {
+		return true
+	}
+
+	if strings.HasPrefix(deniedFlag, "--") {
+		name := strings.SplitN(arg, "=", 2)[0]
+		return len(name) > 2 && strings.HasPrefix(name, "--") && strings.HasPrefix(deniedFlag, name)
+	} else if len(deniedFlag) == 2 {
+		return len(arg) > 1 && arg[0] == '-' && arg[1] != '-' && strings.ContainsRune(arg[1:], rune(deniedFlag[1]))
+	}
+
+	return false
+}
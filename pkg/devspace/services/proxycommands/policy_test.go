@@ -0,0 +1,65 @@
+package proxycommands
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestCheckArgs(t *testing.T) {
+	policy := &latest.ProxyCommandPolicy{
+		AllowedArgs: []string{"(status|diff|log)( .*)?", "fetch"},
+		DeniedFlags: []string{"--output", "-c", "-X"},
+	}
+
+	testCases := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{args: []string{"status"}},
+		{args: []string{"log", "--oneline", "-n", "5"}},
+		{args: []string{"fetch"}},
+		{args: []string{"fetch", "origin"}, expectedErr: "arguments 'fetch origin' are not allowed"},
+		{args: []string{"push"}, expectedErr: "arguments 'push' are not allowed"},
+		{args: []string{"statuses"}, expectedErr: "arguments 'statuses' are not allowed"},
+		{args: []string{"diff", "--output=/etc/passwd"}, expectedErr: "flag --output is not allowed"},
+		{args: []string{"-c", "core.pager=sh", "log"}, expectedErr: "flag -c is not allowed"},
+		{args: []string{"log", "--oneline"}},
+		{args: []string{"diff", "--outp=/etc/passwd"}, expectedErr: "flag --output is not allowed"},
+		{args: []string{"diff", "--out", "/etc/passwd"}, expectedErr: "flag --output is not allowed"},
+		{args: []string{"-ccore.pager=sh", "log"}, expectedErr: "flag -c is not allowed"},
+		{args: []string{"log", "-vX"}, expectedErr: "flag -X is not allowed"},
+		{args: []string{"log", "-pc"}, expectedErr: "flag -c is not allowed"},
+		{args: []string{"status --porcelain"}, expectedErr: "arguments ''status --porcelain'' are not allowed"},
+		{args: []string{"fet", "ch"}, expectedErr: "arguments 'fet ch' are not allowed"},
+		{args: []string{"log", "--grep=it's a fix"}},
+		{args: []string{"fetch", ""}, expectedErr: "arguments 'fetch ''' are not allowed"},
+	}
+
+	compiled := compilePolicy(policy)
+	for _, testCase := range testCases {
+		err := compiled.checkArgs(testCase.args)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "args %v", testCase.args)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "args %v", testCase.args)
+		}
+	}
+
+	// without allowed args every argument that is not denied is allowed
+	compiled = compilePolicy(&latest.ProxyCommandPolicy{DeniedFlags: []string{"--force"}})
+	assert.NilError(t, compiled.checkArgs([]string{"push", "origin"}))
+	assert.Error(t, compiled.checkArgs([]string{"push", "--force"}), "flag --force is not allowed")
+	assert.Error(t, compiled.checkArgs([]string{"push", "--forc"}), "flag --force is not allowed")
+
+	// arguments with whitespace or quotes are quoted, so they can't pass for several arguments
+	assert.Equal(t, joinArgs([]string{"log", "--grep=it's a fix", "a\tb", ""}), "log '--grep=it'\\''s a fix' 'a\tb' ''")
+	compiled = compilePolicy(&latest.ProxyCommandPolicy{AllowedArgs: []string{"log( [^ ]+)?"}})
+	assert.NilError(t, compiled.checkArgs([]string{"log", "--oneline"}))
+	assert.Error(t, compiled.checkArgs([]string{"log --oneline --output=/etc/passwd"}), "arguments ''log --oneline --output=/etc/passwd'' are not allowed")
+
+	// invalid allowed args deny every invocation
+	compiled = compilePolicy(&latest.ProxyCommandPolicy{AllowedArgs: []string{"("}})
+	assert.ErrorContains(t, compiled.checkArgs([]string{"status"}), "compile allowed args (")
+}
@@ -41,6 +41,8 @@ func NewReverseCommandsServer(localWorkDir, containerWorkDir string, addr string
 
 		commands: commands,
 		log:      log,
+		auditLog: newAuditLog(),
+		policy:   newPolicyEnforcer(commands, log),
 		sshServer: ssh.Server{
 			Addr: addr,
 			PublicKeyHandler: func(ctx ssh.Context, key ssh.PublicKey) bool {
@@ -75,12 +77,18 @@ type Server struct {
 
 	commands  []*latest.ProxyCommand
 	log       log.Logger
+	auditLog  log.Logger
+	policy    *policyEnforcer
 	sshServer ssh.Server
 }
 
 func (s *Server) handler(sess ssh.Session) {
 	cmd, payload, err := s.getCommand(sess)
 	if err != nil {
+		if payload != nil {
+			s.auditLog.Infof("Denied command %q in %s: %v", payload.Args, payload.WorkingDir, err)
+		}
+
 		s.exitWithError(sess, errors.Wrap(err, "construct command"))
 		return
 	}
@@ -120,6 +128,7 @@ func (s *Server) handler(sess ssh.Session) {
 	}
 
 	// exit session
+	s.auditLog.Infof("Ran command %q in %s (container: %s) with exit code %d", payload.Args, cmd.Dir, payload.WorkingDir, sshhelper.ExitCode(err))
 	s.exitWithError(sess, err)
 }
 
@@ -150,7 +159,13 @@ func (s *Server) getCommand(sess ssh.Session) (*exec.Cmd, *types.ProxyCommand, e
 		}
 	}
 	if reverseCommand == nil {
-		return nil, nil, fmt.Errorf("command not allowed")
+		return nil, command, fmt.Errorf("command not allowed")
+	}
+
+	// enforce the policy before anything is executed
+	err = s.policy.Check(command.Args[0], reverseCommand.Policy, command.Args[1:])
+	if err != nil {
+		return nil, command, err
 	}
 
 	c := reverseCommand.Command
@@ -0,0 +1,235 @@
+package sleep
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+)
+
+// Sleep scales the replaced dev pods of the project and its dependencies to zero and stores the
+// previous replicas in the remote cache. If all is true, all deployments and statefulsets in
+// the current namespace are scaled to zero as well.
+func Sleep(ctx devspacecontext.Context, all bool) error {
+	if ctx.KubeClient() == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	amount, err := sleepRecursive(ctx, seen)
+	if err != nil {
+		return err
+	}
+
+	if all {
+		workloads, err := listWorkloads(ctx, ctx.KubeClient().Namespace())
+		if err != nil {
+			return err
+		}
+
+		for _, workload := range workloads {
+			slept, err := sleepWorkload(ctx, workload, seen)
+			if err != nil {
+				return err
+			} else if slept {
+				amount++
+			}
+		}
+	}
+
+	if amount > 0 {
+		ctx.Log().Infof("Scaled %d workload(s) to zero because of inactivity, they will be scaled up again with the next devspace dev", amount)
+	}
+	return nil
+}
+
+func sleepRecursive(ctx devspacecontext.Context, seen map[string]bool) (int, error) {
+	amount := 0
+	for _, d := range ctx.Dependencies() {
+		dependencyAmount, err := sleepRecursive(ctx.AsDependency(d), seen)
+		if err != nil {
+			return 0, err
+		}
+
+		amount += dependencyAmount
+	}
+
+	for _, devPod := range ctx.Config().RemoteCache().ListDevPods() {
+		if devPod.Deployment == "" {
+			continue
+		}
+
+		slept, err := sleepWorkload(ctx, remotecache.SleepingWorkloadCache{
+			Kind:      KindDeployment,
+			Namespace: devPod.Namespace,
+			Name:      devPod.Deployment,
+		}, seen)
+		if err != nil {
+			return 0, err
+		} else if slept {
+			amount++
+		}
+	}
+
+	return amount, nil
+}
+
+// sleepWorkload scales the workload to zero and saves its replicas in the remote cache of the context
+func sleepWorkload(ctx devspacecontext.Context, workload remotecache.SleepingWorkloadCache, seen map[string]bool) (bool, error) {
+	key := workload.Kind + "/" + workload.Namespace + "/" + workload.Name
+	if seen[key] {
+		return false, nil
+	}
+	seen[key] = true
+
+	scale, err := getScale(ctx, workload)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "get scale of %s %s", workload.Kind, workload.Name)
+	} else if scale.Spec.Replicas == 0 {
+		return false, nil
+	}
+
+	// save the replicas before scaling down, so we never lose them
+	workload.Replicas = scale.Spec.Replicas
+	ctx.Config().RemoteCache().SetSleepingWorkload(workload)
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return false, errors.Wrap(err, "save remote cache")
+	}
+
+	ctx.Log().Debugf("Scale %s %s/%s from %d to zero", workload.Kind, workload.Namespace, workload.Name, workload.Replicas)
+	scale.Spec.Replicas = 0
+	err = updateScale(ctx, workload, scale)
+	if err != nil {
+		return false, errors.Wrapf(err, "scale down %s %s", workload.Kind, workload.Name)
+	}
+
+	return true, nil
+}
+
+// WakeUp scales all workloads of the project and its dependencies that were scaled to zero
+// because of inactivity back to their previous replicas.
+func WakeUp(ctx devspacecontext.Context) error {
+	if ctx.KubeClient() == nil {
+		return nil
+	}
+
+	amount, err := wakeUpRecursive(ctx)
+	if err != nil {
+		return err
+	} else if amount > 0 {
+		ctx.Log().Donef("Woke up %d workload(s) that were scaled down because of inactivity", amount)
+	}
+
+	return nil
+}
+
+func wakeUpRecursive(ctx devspacecontext.Context) (int, error) {
+	amount := 0
+	for _, d := range ctx.Dependencies() {
+		dependencyAmount, err := wakeUpRecursive(ctx.AsDependency(d))
+		if err != nil {
+			return 0, err
+		}
+
+		amount += dependencyAmount
+	}
+
+	workloads := ctx.Config().RemoteCache().ListSleepingWorkloads()
+	if len(workloads) == 0 {
+		return amount, nil
+	}
+
+	for _, workload := range workloads {
+		scale, err := getScale(ctx, workload)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return 0, errors.Wrapf(err, "get scale of %s %s", workload.Kind, workload.Name)
+		} else if err == nil && scale.Spec.Replicas == 0 {
+			// only scale up if nobody else has changed the replicas in the meantime
+			ctx.Log().Debugf("Scale %s %s/%s to %d", workload.Kind, workload.Namespace, workload.Name, workload.Replicas)
+			scale.Spec.Replicas = workload.Replicas
+			err = updateScale(ctx, workload, scale)
+			if err != nil {
+				return 0, errors.Wrapf(err, "scale up %s %s", workload.Kind, workload.Name)
+			}
+
+			amount++
+		}
+
+		ctx.Config().RemoteCache().DeleteSleepingWorkload(workload.Kind, workload.Namespace, workload.Name)
+	}
+
+	err := ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return 0, errors.Wrap(err, "save remote cache")
+	}
+
+	return amount, nil
+}
+
+func listWorkloads(ctx devspacecontext.Context, namespace string) ([]remotecache.SleepingWorkloadCache, error) {
+	workloads := []remotecache.SleepingWorkloadCache{}
+	deployments, err := ctx.KubeClient().KubeClient().AppsV1().Deployments(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list deployments")
+	}
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, remotecache.SleepingWorkloadCache{
+			Kind:      KindDeployment,
+			Namespace: namespace,
+			Name:      deployment.Name,
+		})
+	}
+
+	statefulSets, err := ctx.KubeClient().KubeClient().AppsV1().StatefulSets(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list statefulsets")
+	}
+	for _, statefulSet := range statefulSets.Items {
+		workloads = append(workloads, remotecache.SleepingWorkloadCache{
+			Kind:      KindStatefulSet,
+			Namespace: namespace,
+			Name:      statefulSet.Name,
+		})
+	}
+
+	return workloads, nil
+}
+
+func getScale(ctx devspacecontext.Context, workload remotecache.SleepingWorkloadCache) (*autoscalingv1.Scale, error) {
+	apps := ctx.KubeClient().KubeClient().AppsV1()
+	switch workload.Kind {
+	case KindDeployment:
+		return apps.Deployments(workload.Namespace).GetScale(ctx.Context(), workload.Name, metav1.GetOptions{})
+	case KindStatefulSet:
+		return apps.StatefulSets(workload.Namespace).GetScale(ctx.Context(), workload.Name, metav1.GetOptions{})
+	}
+
+	return nil, errors.Errorf("unsupported kind %s", workload.Kind)
+}
+
+func updateScale(ctx devspacecontext.Context, workload remotecache.SleepingWorkloadCache, scale *autoscalingv1.Scale) error {
+	apps := ctx.KubeClient().KubeClient().AppsV1()
+	var err error
+	switch workload.Kind {
+	case KindDeployment:
+		_, err = apps.Deployments(workload.Namespace).UpdateScale(ctx.Context(), workload.Name, scale, metav1.UpdateOptions{})
+	case KindStatefulSet:
+		_, err = apps.StatefulSets(workload.Namespace).UpdateScale(ctx.Context(), workload.Name, scale, metav1.UpdateOptions{})
+	default:
+		err = errors.Errorf("unsupported kind %s", workload.Kind)
+	}
+
+	return err
+}
@@ -0,0 +1,128 @@
+package sleep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSleepAndWakeUp(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		deployment("app-devspace", 1),
+		deployment("api", 3),
+		deployment("app", 0),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testNamespace"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.Int32(2)},
+		},
+	)
+	addScaleReactors(kubeClient)
+
+	cache := remotecache.NewCache("test", "devspace-cache-test")
+	cache.SetDevPod("app", remotecache.DevPodCache{Name: "app", Namespace: "testNamespace", Deployment: "app-devspace"})
+	conf := config.NewConfig(nil, nil, &latest.Config{}, localcache.New(""), cache, nil, "")
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.NewFakeLogger()).
+		WithConfig(conf).
+		WithKubeClient(&kubectltesting.Client{Client: kubeClient})
+
+	// only the dev pod is scaled down
+	err := Sleep(ctx, false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cache.ListSleepingWorkloads(), []remotecache.SleepingWorkloadCache{
+		{Kind: KindDeployment, Namespace: "testNamespace", Name: "app-devspace", Replicas: 1},
+	})
+	assert.Equal(t, replicas(t, kubeClient, "app-devspace"), int32(0))
+	assert.Equal(t, replicas(t, kubeClient, "api"), int32(3))
+
+	// all workloads are scaled down, the already scaled down ones are skipped
+	err = Sleep(ctx, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(cache.ListSleepingWorkloads()), 3)
+	assert.Equal(t, replicas(t, kubeClient, "api"), int32(0))
+
+	// wake up restores the previous replicas
+	err = WakeUp(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(cache.ListSleepingWorkloads()), 0)
+	assert.Equal(t, replicas(t, kubeClient, "app-devspace"), int32(1))
+	assert.Equal(t, replicas(t, kubeClient, "api"), int32(3))
+	assert.Equal(t, replicas(t, kubeClient, "app"), int32(0))
+	statefulSet, err := kubeClient.AppsV1().StatefulSets("testNamespace").Get(context.Background(), "db", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *statefulSet.Spec.Replicas, int32(2))
+}
+
+func deployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testNamespace"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(replicas)},
+	}
+}
+
+func replicas(t *testing.T, kubeClient *fake.Clientset, name string) int32 {
+	deployment, err := kubeClient.AppsV1().Deployments("testNamespace").Get(context.Background(), name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	return *deployment.Spec.Replicas
+}
+
+// addScaleReactors implements the scale subresource, which is not supported by the fake clientset
+func addScaleReactors(kubeClient *fake.Clientset) {
+	for _, resource := range []string{"deployments", "statefulsets"} {
+		resource := resource
+		kubeClient.PrependReactor("get", resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+			getAction := action.(k8stesting.GetAction)
+			if getAction.GetSubresource() != "scale" {
+				return false, nil, nil
+			}
+
+			obj, err := kubeClient.Tracker().Get(getAction.GetResource(), getAction.GetNamespace(), getAction.GetName())
+			if err != nil {
+				return true, nil, err
+			}
+
+			scale := &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: getAction.GetName(), Namespace: getAction.GetNamespace()}}
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				scale.Spec.Replicas = *o.Spec.Replicas
+			case *appsv1.StatefulSet:
+				scale.Spec.Replicas = *o.Spec.Replicas
+			}
+			return true, scale, nil
+		})
+		kubeClient.PrependReactor("update", resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updateAction := action.(k8stesting.UpdateAction)
+			if updateAction.GetSubresource() != "scale" {
+				return false, nil, nil
+			}
+
+			scale := updateAction.GetObject().(*autoscalingv1.Scale)
+			obj, err := kubeClient.Tracker().Get(updateAction.GetResource(), updateAction.GetNamespace(), scale.Name)
+			if err != nil {
+				return true, nil, err
+			}
+
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				o.Spec.Replicas = ptr.Int32(scale.Spec.Replicas)
+			case *appsv1.StatefulSet:
+				o.Spec.Replicas = ptr.Int32(scale.Spec.Replicas)
+			}
+			return true, scale, kubeClient.Tracker().Update(updateAction.GetResource(), obj, updateAction.GetNamespace())
+		})
+	}
+}
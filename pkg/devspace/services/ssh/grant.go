@@ -0,0 +1,128 @@
+package ssh
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	helperssh "github.com/loft-sh/devspace/helper/ssh"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// NewSSHKey parses the given public key and creates a new ssh key cache entry for the user
+func NewSSHKey(user string, publicKey []byte, grantedBy string) (remotecache.SSHKeyCache, error) {
+	if user == "" || strings.ContainsAny(user, " \t\r\n") {
+		return remotecache.SSHKeyCache{}, fmt.Errorf("invalid user name '%s', must not be empty or contain whitespaces", user)
+	} else if user == helperssh.OwnerUser {
+		return remotecache.SSHKeyCache{}, fmt.Errorf("user name '%s' is reserved", user)
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey(publicKey)
+	if err != nil {
+		return remotecache.SSHKeyCache{}, errors.Wrap(err, "parse public key")
+	}
+
+	return remotecache.SSHKeyCache{
+		User:        user,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Fingerprint: ssh.FingerprintSHA256(key),
+		Granted:     time.Now(),
+		GrantedBy:   grantedBy,
+	}, nil
+}
+
+// EncodeGrantedKeys returns the granted keys in the authorized keys format with the user
+// name as comment base64 encoded
+func EncodeGrantedKeys(sshKeys []remotecache.SSHKeyCache) string {
+	out := ""
+	for _, sshKey := range sshKeys {
+		out += sshKey.PublicKey + " " + sshKey.User + "\n"
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(out))
+}
+
+// UpdateGrantedKeys replaces the granted keys of the ssh server in the given container
+// with the keys that are stored in the remote cache
+func UpdateGrantedKeys(ctx devspacecontext.Context, container *selector.SelectedPodContainer, arch string) error {
+	err := inject.InjectDevSpaceHelper(ctx.Context(), ctx.KubeClient(), container.Pod, container.Container.Name, arch, ctx.Log())
+	if err != nil {
+		return err
+	}
+
+	return updateGrantedKeys(ctx, container)
+}
+
+func updateGrantedKeys(ctx devspacecontext.Context, container *selector.SelectedPodContainer) error {
+	command := []string{inject.DevSpaceHelperContainerPath, "ssh-keys", "--granted-keys", EncodeGrantedKeys(ctx.Config().RemoteCache().ListSSHKeys())}
+	stdout, stderr, err := ctx.KubeClient().ExecBuffered(ctx.Context(), container.Pod, container.Container.Name, command, nil)
+	if err != nil {
+		return fmt.Errorf("update granted ssh keys: %s %s %v", string(stdout), string(stderr), err)
+	}
+
+	return nil
+}
+
+// UpdateGrantedKeysInDevContainers updates the granted keys in all running dev containers
+// that have ssh enabled. Dev containers that are not running are skipped, they will
+// receive the keys as soon as ssh is started within them.
+func UpdateGrantedKeysInDevContainers(ctx devspacecontext.Context) error {
+	for _, devPod := range ctx.Config().Config().Dev {
+		var imageSelector []string
+		if devPod.ImageSelector != "" {
+			imageSelectorObject, err := runtimevar.NewRuntimeResolver(ctx.WorkingDir(), true).FillRuntimeVariablesAsImageSelector(ctx.Context(), devPod.ImageSelector, ctx.Config(), ctx.Dependencies())
+			if err != nil {
+				return err
+			}
+
+			imageSelector = []string{imageSelectorObject.Image}
+		}
+
+		var retErr error
+		loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
+			if devContainer.SSH == nil || (devContainer.SSH.Enabled != nil && !*devContainer.SSH.Enabled) {
+				return true
+			}
+
+			options := targetselector.NewEmptyOptions().
+				ApplyConfigParameter(devContainer.Container, devPod.LabelSelector, imageSelector, devPod.Namespace, "").
+				WithSkipInitContainers(true).
+				WithWait(false).
+				WithContainerFilter(selector.FilterNonRunningContainers)
+			container, err := targetselector.NewTargetSelector(options).SelectSingleContainer(ctx.Context(), ctx.KubeClient(), ctx.Log())
+			if err != nil {
+				if _, ok := err.(*targetselector.NotFoundErr); ok {
+					ctx.Log().Infof("Dev container of %s is not running, keys will be updated when ssh is started", devPod.Name)
+					return true
+				}
+
+				retErr = errors.Wrapf(err, "select dev container of %s", devPod.Name)
+				return false
+			}
+
+			err = UpdateGrantedKeys(ctx, container, string(devContainer.Arch))
+			if err != nil {
+				retErr = errors.Wrapf(err, "dev container of %s", devPod.Name)
+				return false
+			}
+
+			ctx.Log().Donef("Updated granted ssh keys in pod %s", container.Pod.Name)
+			return true
+		})
+		if retErr != nil {
+			return retErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package ssh
+
+import (
+	"encoding/base64"
+	"testing"
+
+	helperssh "github.com/loft-sh/devspace/helper/ssh"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"gotest.tools/assert"
+)
+
+func TestGrantedKeys(t *testing.T) {
+	publicKey, _, err := MakeSSHKeyPair()
+	assert.NilError(t, err)
+
+	_, err = NewSSHKey("alice smith", []byte(publicKey), "")
+	assert.ErrorContains(t, err, "invalid user name")
+	_, err = NewSSHKey(helperssh.OwnerUser, []byte(publicKey), "")
+	assert.ErrorContains(t, err, "is reserved")
+	_, err = NewSSHKey("alice", []byte("not a key"), "")
+	assert.ErrorContains(t, err, "parse public key")
+
+	alice, err := NewSSHKey("alice", []byte(publicKey), "bob")
+	assert.NilError(t, err)
+	assert.Equal(t, alice.GrantedBy, "bob")
+
+	// the helper has to map every key back to its user
+	encoded, err := base64.StdEncoding.DecodeString(EncodeGrantedKeys([]remotecache.SSHKeyCache{alice}))
+	assert.NilError(t, err)
+	grantedKeys, err := helperssh.ParseGrantedKeys(encoded)
+	assert.NilError(t, err)
+	assert.Equal(t, len(grantedKeys["alice"]), 1)
+
+	grantedKeys, err = helperssh.ParseGrantedKeys([]byte(""))
+	assert.NilError(t, err)
+	assert.Equal(t, len(grantedKeys), 0)
+}
@@ -13,9 +13,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
 	"github.com/loft-sh/devspace/pkg/devspace/services/portforwarding"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/devspace/services/terminal"
 	"github.com/loft-sh/devspace/pkg/util/tomb"
@@ -137,14 +139,22 @@ func startSSH(ctx devspacecontext.Context, name, arch string, sshConfig *latest.
 		},
 	}, selector, parent)
 	if err != nil {
-		return errors.Wrap(err, "start ssh port forwarding")
+		err = errors.Wrap(err, "start ssh port forwarding")
+		setSSHStatus(ctx, name, sshHost, port, "", err)
+		return err
 	}
 
 	// start ssh
-	return startSSHWithRestart(ctx, arch, sshConfig.RemoteAddress, sshHost, selector, parent)
+	err = startSSHWithRestart(ctx, name, arch, sshConfig.RemoteAddress, sshHost, port, selector, parent)
+	if err != nil {
+		setSSHStatus(ctx, name, sshHost, port, "", err)
+		return err
+	}
+
+	return nil
 }
 
-func startSSHWithRestart(ctx devspacecontext.Context, arch, addr, sshHost string, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+func startSSHWithRestart(ctx devspacecontext.Context, name, arch, addr, sshHost string, port int, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
 	if ctx.IsDone() {
 		return nil
 	}
@@ -175,6 +185,14 @@ func startSSHWithRestart(ctx devspacecontext.Context, arch, addr, sshHost string
 		return errors.Wrap(err, "generate key pair")
 	}
 
+	// make sure the keys of other users that were granted access are up to date
+	if ctx.Config().RemoteCache() != nil {
+		err = updateGrantedKeys(ctx, container)
+		if err != nil {
+			ctx.Log().Warnf("Error updating granted ssh keys: %v", err)
+		}
+	}
+
 	// get command
 	command := []string{inject.DevSpaceHelperContainerPath, "ssh", "--authorized-key", publicKey, "--host-key", hostKey}
 	if addr != "" {
@@ -186,6 +204,7 @@ func startSSHWithRestart(ctx devspacecontext.Context, arch, addr, sshHost string
 		writer := ctx.Log().Writer(logrus.DebugLevel, false)
 		defer writer.Close()
 		for !ctx.IsDone() {
+			setSSHStatus(ctx, name, sshHost, port, container.Container.Name, nil)
 			buffer := &bytes.Buffer{}
 			multiWriter := io.MultiWriter(writer, buffer)
 			err = ctx.KubeClient().ExecStream(ctx.Context(), &kubectl.ExecStreamOptions{
@@ -197,6 +216,7 @@ func startSSHWithRestart(ctx devspacecontext.Context, arch, addr, sshHost string
 				SubResource: kubectl.SubResourceExec,
 			})
 			if err != nil {
+				setSSHStatus(ctx, name, sshHost, port, container.Container.Name, err)
 				select {
 				case <-ctx.Context().Done():
 					return nil
@@ -227,3 +247,18 @@ func startSSHWithRestart(ctx devspacecontext.Context, arch, addr, sshHost string
 	ctx.Log().Donef("Use '%s' to connect via SSH", ansi.Color(fmt.Sprintf("ssh %s", sshHost), "white+b"))
 	return nil
 }
+
+func setSSHStatus(ctx devspacecontext.Context, name, sshHost string, port int, container string, err error) {
+	ssh := &status.SSH{
+		Host:      sshHost,
+		Port:      port,
+		Container: container,
+		Healthy:   err == nil,
+	}
+	if err != nil {
+		ssh.Error = err.Error()
+	}
+
+	dependency, _ := values.DependencyNameFrom(ctx.Context())
+	status.GetRegistry().SetSSH(dependency, name, ssh)
+}
@@ -0,0 +1,242 @@
+package status
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SyncStatusStarting means the sync is starting or doing the initial sync
+	SyncStatusStarting = "starting"
+	// SyncStatusWatching means the initial sync is done and the sync watches for changes
+	SyncStatusWatching = "watching"
+	// SyncStatusRestarting means the sync has failed and is restarting
+	SyncStatusRestarting = "restarting"
+	// SyncStatusStopped means the sync was stopped
+	SyncStatusStopped = "stopped"
+)
+
+// SyncSource is implemented by a running sync to report its progress
+type SyncSource interface {
+	LastSync() time.Time
+	PendingChanges() int
+}
+
+// DevPod is the state of a single running dev pod
+type DevPod struct {
+	Name       string    `json:"name"`
+	Dependency string    `json:"dependency,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Pod        string    `json:"pod,omitempty"`
+	Container  string    `json:"container,omitempty"`
+	Started    time.Time `json:"started"`
+
+	Sync                []*Sync        `json:"sync,omitempty"`
+	PortForwards        []*PortForward `json:"portForwards,omitempty"`
+	ReversePortForwards []*PortForward `json:"reversePortForwards,omitempty"`
+	SSH                 []*SSH         `json:"ssh,omitempty"`
+}
+
+// Sync is the state of a single sync path
+type Sync struct {
+	Path      string `json:"path"`
+	Container string `json:"container,omitempty"`
+	Status    string `json:"status"`
+
+	LastSync       *time.Time `json:"lastSync,omitempty"`
+	PendingChanges int        `json:"pendingChanges"`
+	Error          string     `json:"error,omitempty"`
+
+	Source SyncSource `json:"-"`
+}
+
+// PortForward is the state of a port forwarding or reverse port forwarding
+type PortForward struct {
+	Ports     []string `json:"ports"`
+	Container string   `json:"container,omitempty"`
+	Healthy   bool     `json:"healthy"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// SSH is the state of a ssh endpoint
+type SSH struct {
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	Container string `json:"container,omitempty"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Registry holds the state of all dev pods running in this process. Dev pods are identified
+// by the dependency they belong to and their name, because dependencies can use the same names.
+type Registry struct {
+	m       sync.Mutex
+	devPods map[devPodKey]*DevPod
+}
+
+type devPodKey struct {
+	dependency string
+	name       string
+}
+
+var registry = NewRegistry()
+
+// GetRegistry returns the registry that is shared by all dev pods of this process
+func GetRegistry() *Registry {
+	return registry
+}
+
+// NewRegistry creates a new empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		devPods: map[devPodKey]*DevPod{},
+	}
+}
+
+// StartDevPod resets the state of the dev pod with the given name. The dependency is empty
+// for dev pods of the root project.
+func (r *Registry) StartDevPod(dependency, name, namespace, pod, container string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.devPods[devPodKey{dependency: dependency, name: name}] = &DevPod{
+		Name:       name,
+		Dependency: dependency,
+		Namespace:  namespace,
+		Pod:        pod,
+		Container:  container,
+		Started:    time.Now(),
+	}
+}
+
+// StopDevPod removes the dev pod with the given name. Updates of services that are still
+// stopping are ignored afterwards.
+func (r *Registry) StopDevPod(dependency, name string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	delete(r.devPods, devPodKey{dependency: dependency, name: name})
+}
+
+// SetSync adds or replaces the state of the sync path within the given dev pod
+func (r *Registry) SetSync(dependency, devPod string, sync *Sync) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	d, ok := r.devPods[devPodKey{dependency: dependency, name: devPod}]
+	if !ok {
+		return
+	}
+
+	for i, s := range d.Sync {
+		if s.Path == sync.Path && s.Container == sync.Container {
+			d.Sync[i] = sync
+			return
+		}
+	}
+
+	d.Sync = append(d.Sync, sync)
+}
+
+// SetPortForward adds or replaces the state of the port forwarding within the given dev pod
+func (r *Registry) SetPortForward(dependency, devPod string, reverse bool, portForward *PortForward) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	d, ok := r.devPods[devPodKey{dependency: dependency, name: devPod}]
+	if !ok {
+		return
+	}
+
+	portForwards := &d.PortForwards
+	if reverse {
+		portForwards = &d.ReversePortForwards
+	}
+
+	key := strings.Join(portForward.Ports, ",")
+	for i, p := range *portForwards {
+		if strings.Join(p.Ports, ",") == key {
+			if portForward.Container == "" {
+				portForward.Container = p.Container
+			}
+
+			(*portForwards)[i] = portForward
+			return
+		}
+	}
+
+	*portForwards = append(*portForwards, portForward)
+}
+
+// SetSSH adds or replaces the state of the ssh endpoint within the given dev pod
+func (r *Registry) SetSSH(dependency, devPod string, ssh *SSH) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	d, ok := r.devPods[devPodKey{dependency: dependency, name: devPod}]
+	if !ok {
+		return
+	}
+
+	for i, s := range d.SSH {
+		if s.Host == ssh.Host {
+			d.SSH[i] = ssh
+			return
+		}
+	}
+
+	d.SSH = append(d.SSH, ssh)
+}
+
+// List returns a copy of the state of all dev pods sorted by dependency and name
+func (r *Registry) List() []*DevPod {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	retDevPods := make([]*DevPod, 0, len(r.devPods))
+	for _, d := range r.devPods {
+		devPod := *d
+		devPod.Sync = make([]*Sync, 0, len(d.Sync))
+		for _, s := range d.Sync {
+			sync := *s
+			if sync.Source != nil {
+				lastSync := sync.Source.LastSync()
+				if !lastSync.IsZero() {
+					sync.LastSync = &lastSync
+				}
+				sync.PendingChanges = sync.Source.PendingChanges()
+			}
+			devPod.Sync = append(devPod.Sync, &sync)
+		}
+		devPod.PortForwards = copyPortForwards(d.PortForwards)
+		devPod.ReversePortForwards = copyPortForwards(d.ReversePortForwards)
+		devPod.SSH = make([]*SSH, 0, len(d.SSH))
+		for _, s := range d.SSH {
+			ssh := *s
+			devPod.SSH = append(devPod.SSH, &ssh)
+		}
+
+		retDevPods = append(retDevPods, &devPod)
+	}
+
+	sort.Slice(retDevPods, func(i, j int) bool {
+		if retDevPods[i].Dependency != retDevPods[j].Dependency {
+			return retDevPods[i].Dependency < retDevPods[j].Dependency
+		}
+
+		return retDevPods[i].Name < retDevPods[j].Name
+	})
+	return retDevPods
+}
+
+func copyPortForwards(portForwards []*PortForward) []*PortForward {
+	retPortForwards := make([]*PortForward, 0, len(portForwards))
+	for _, p := range portForwards {
+		portForward := *p
+		retPortForwards = append(retPortForwards, &portForward)
+	}
+
+	return retPortForwards
+}
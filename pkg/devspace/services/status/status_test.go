@@ -0,0 +1,78 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type fakeSource struct {
+	lastSync time.Time
+	pending  int
+}
+
+func (f *fakeSource) LastSync() time.Time {
+	return f.lastSync
+}
+
+func (f *fakeSource) PendingChanges() int {
+	return f.pending
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.StartDevPod("", "app", "default", "app-123", "container")
+
+	source := &fakeSource{}
+	registry.SetSync("", "app", &Sync{Path: "./:/app", Status: SyncStatusStarting, Source: source})
+	registry.SetPortForward("", "app", false, &PortForward{Ports: []string{"8080"}, Healthy: true})
+	registry.SetPortForward("", "app", true, &PortForward{Ports: []string{"9000"}, Container: "container", Healthy: true})
+	registry.SetSSH("", "app", &SSH{Host: "app.test.devspace", Port: 10000, Healthy: true})
+
+	devPods := registry.List()
+	assert.Equal(t, len(devPods), 1)
+	assert.Equal(t, devPods[0].Pod, "app-123")
+	assert.Equal(t, len(devPods[0].Sync), 1)
+	assert.Assert(t, devPods[0].Sync[0].LastSync == nil)
+
+	// updates replace existing entries
+	source.lastSync = time.Now()
+	source.pending = 3
+	registry.SetSync("", "app", &Sync{Path: "./:/app", Status: SyncStatusWatching, Source: source})
+	registry.SetPortForward("", "app", true, &PortForward{Ports: []string{"9000"}, Error: "connection lost"})
+
+	devPods = registry.List()
+	assert.Equal(t, len(devPods[0].Sync), 1)
+	assert.Equal(t, devPods[0].Sync[0].Status, SyncStatusWatching)
+	assert.Equal(t, devPods[0].Sync[0].PendingChanges, 3)
+	assert.Assert(t, devPods[0].Sync[0].LastSync != nil)
+	assert.Equal(t, len(devPods[0].ReversePortForwards), 1)
+	assert.Equal(t, devPods[0].ReversePortForwards[0].Healthy, false)
+	assert.Equal(t, devPods[0].ReversePortForwards[0].Container, "container")
+	assert.Equal(t, len(devPods[0].PortForwards), 1)
+	assert.Equal(t, len(devPods[0].SSH), 1)
+
+	// dev pods of dependencies with the same name are separate entries
+	registry.StartDevPod("api", "app", "default", "api-app-123", "container")
+	registry.SetSSH("api", "app", &SSH{Host: "api.test.devspace", Port: 10001, Healthy: true})
+
+	devPods = registry.List()
+	assert.Equal(t, len(devPods), 2)
+	assert.Equal(t, devPods[0].Dependency, "")
+	assert.Equal(t, devPods[0].SSH[0].Host, "app.test.devspace")
+	assert.Equal(t, devPods[1].Dependency, "api")
+	assert.Equal(t, devPods[1].Pod, "api-app-123")
+	assert.Equal(t, devPods[1].SSH[0].Host, "api.test.devspace")
+
+	registry.StopDevPod("", "app")
+	registry.StopDevPod("api", "app")
+	assert.Equal(t, len(registry.List()), 0)
+
+	// late updates of stopped or unknown dev pods are ignored
+	registry.SetSync("", "app", &Sync{Path: "./:/app", Status: SyncStatusStopped})
+	registry.SetPortForward("", "app", false, &PortForward{Ports: []string{"8080"}})
+	registry.SetSSH("api", "app", &SSH{Host: "api.test.devspace", Port: 10001})
+	registry.SetSSH("", "unknown", &SSH{Host: "unknown.test.devspace", Port: 10002})
+	assert.Equal(t, len(registry.List()), 0)
+}
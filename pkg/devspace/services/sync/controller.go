@@ -21,9 +21,11 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/services/status"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/devspace/sync"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
@@ -46,6 +48,7 @@ type Options struct {
 	Name       string
 	SyncConfig *latest.SyncConfig
 	Arch       string
+	Container  string
 	Selector   targetselector.TargetSelector
 
 	Starter sync.DelayedContainerStarter
@@ -107,6 +110,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 	// start the sync
 	client, pod, err := c.startSync(ctx, options, onInitUploadDone, onInitDownloadDone, onDone, onError)
 	if err != nil {
+		setSyncStatus(ctx, options, nil, status.SyncStatusRestarting, err)
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"sync_config": options.SyncConfig,
 			"ERROR":       err,
@@ -119,6 +123,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 	}
 
 	// should wait for initial sync?
+	setSyncStatus(ctx, options, client, status.SyncStatusStarting, nil)
 	if options.SyncConfig.WaitInitialSync == nil || *options.SyncConfig.WaitInitialSync {
 		ctx.Log().Info("Waiting for initial sync to complete")
 		defer ctx.Log().Info("Initial sync completed")
@@ -130,6 +135,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 		for {
 			select {
 			case err := <-onError:
+				setSyncStatus(ctx, options, client, status.SyncStatusRestarting, err)
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"sync_config": options.SyncConfig,
 					"ERROR":       err,
@@ -177,6 +183,8 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 		}
 	}
 
+	setSyncStatus(ctx, options, client, status.SyncStatusWatching, nil)
+
 	// should we restart the client on error?
 	if options.RestartOnError {
 		parent.Go(func() error {
@@ -193,6 +201,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 					"ERROR":       err,
 				}, hook.EventsForSingle("restart:sync", options.Name).With("sync.restart")...)
 
+				setSyncStatus(ctx, options, client, status.SyncStatusRestarting, err)
 				ctx.Log().Errorf("Restarting because: %v", err)
 				shouldExit := PrintPodError(ctx.Context(), ctx.KubeClient(), pod.Pod, ctx.Log())
 				if shouldExit {
@@ -236,6 +245,7 @@ func syncStop(ctx devspacecontext.Context, syncClient *sync.Sync, options *Optio
 }
 
 func syncDone(ctx devspacecontext.Context, options *Options, parent *tomb.Tomb) {
+	setSyncStatus(ctx, options, nil, status.SyncStatusStopped, nil)
 	parent.Kill(nil)
 	hook.LogExecuteHooks(ctx.WithLogger(options.SyncLog), map[string]interface{}{
 		"sync_config": options.SyncConfig,
@@ -243,6 +253,23 @@ func syncDone(ctx devspacecontext.Context, options *Options, parent *tomb.Tomb)
 	ctx.Log().Debugf("Stopped sync %s", options.SyncConfig.Path)
 }
 
+func setSyncStatus(ctx devspacecontext.Context, options *Options, syncClient *sync.Sync, syncStatus string, err error) {
+	s := &status.Sync{
+		Path:      options.SyncConfig.Path,
+		Container: options.Container,
+		Status:    syncStatus,
+	}
+	if syncClient != nil {
+		s.Source = syncClient
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+
+	dependency, _ := values.DependencyNameFrom(ctx.Context())
+	status.GetRegistry().SetSync(dependency, options.Name, s)
+}
+
 func PrintPodError(ctx context.Context, kubeClient kubectl.Client, pod *v1.Pod, log logpkg.Logger) bool {
 	// check if pod still exists
 	newPod, err := kubeClient.KubeClient().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
@@ -281,6 +308,14 @@ func (c *controller) startSync(ctx devspacecontext.Context, options *Options, on
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "start sync")
 	}
+	syncClient.Options.OnConflict = func(conflict *sync.Conflict) {
+		hook.LogExecuteHooks(ctx.WithLogger(options.SyncLog), map[string]interface{}{
+			"sync_config":   options.SyncConfig,
+			"conflict_path": conflict.Path,
+			"conflict_kept": conflict.Kept,
+			"conflict_copy": conflict.Copy,
+		}, hook.EventsForSingle("conflict:sync", options.Name).With("sync.conflict")...)
+	}
 
 	err = syncClient.Start(onInitUploadDone, onInitDownloadDone, onDone, onError)
 	if err != nil {
@@ -341,6 +376,8 @@ func (c *controller) initClient(ctx devspacecontext.Context, pod *v1.Pod, arch,
 		Verbose:              verbose,
 		InitialSyncCompareBy: compareBy,
 		InitialSync:          syncConfig.InitialSync,
+		Compression:          syncConfig.Compression,
+		ConflictStrategy:     syncConfig.ConflictStrategy,
 		UpstreamDisabled:     upstreamDisabled,
 		DownstreamDisabled:   downstreamDisabled,
 		Log:                  customLog,
@@ -94,7 +94,7 @@ func StartSync(ctx devspacecontext.Context, devPod *latest.DevPod, selector targ
 					defer cancel()
 				}
 
-				return startSync(syncCtx, devPod.Name, string(devContainer.Arch), s, selector.WithContainer(devContainer.Container), starter, parent)
+				return startSync(syncCtx, devPod.Name, string(devContainer.Arch), devContainer.Container, s, selector.WithContainer(devContainer.Container), starter, parent)
 			})
 			initDoneArray = append(initDoneArray, initDone)
 
@@ -115,13 +115,14 @@ func StartSync(ctx devspacecontext.Context, devPod *latest.DevPod, selector targ
 	return nil
 }
 
-func startSync(ctx devspacecontext.Context, name, arch string, syncConfig *latest.SyncConfig, selector targetselector.TargetSelector, starter sync.DelayedContainerStarter, parent *tomb.Tomb) error {
+func startSync(ctx devspacecontext.Context, name, arch, container string, syncConfig *latest.SyncConfig, selector targetselector.TargetSelector, starter sync.DelayedContainerStarter, parent *tomb.Tomb) error {
 	// set options
 	options := &Options{
 		Name:       name,
 		Selector:   selector,
 		SyncConfig: syncConfig,
 		Arch:       arch,
+		Container:  container,
 		Starter:    starter,
 
 		RestartOnError: true,
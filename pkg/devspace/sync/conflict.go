@@ -0,0 +1,162 @@
+package sync
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+const (
+	ConflictSideLocal  = "local"
+	ConflictSideRemote = "remote"
+)
+
+// Conflict describes a file that was changed locally and in the container since it was last synced
+type Conflict struct {
+	// Path is the path of the file relative to the sync path
+	Path string
+
+	// Kept is the side whose version was kept at the path, either local or remote
+	Kept string
+
+	// Copy is the path of the copy of the other version relative to the sync path, only set
+	// with the keepBoth strategy
+	Copy string
+}
+
+// isConflict checks if the file was changed locally as well as remotely since it was last synced.
+// s.fileIndex needs to be locked before this function is called
+func isConflict(s *Sync, relativePath string, stat os.FileInfo, header *tar.Header) bool {
+	if stat.IsDir() || header.FileInfo().IsDir() {
+		return false
+	}
+
+	return changedOnBothSides(s.fileIndex.fileMap[relativePath], stat.ModTime().Unix(), stat.Size(), header.ModTime.Unix(), header.Size)
+}
+
+// changedOnBothSides checks if the local and the remote version of a file differ from each other
+// and from the last synced version
+func changedOnBothSides(base *FileInformation, localMtime, localSize, remoteMtime, remoteSize int64) bool {
+	if base == nil || base.IsDirectory {
+		return false
+	}
+
+	// both sides have the same version
+	if localMtime == remoteMtime && localSize == remoteSize {
+		return false
+	}
+
+	localChanged := localMtime != base.Mtime || localSize != base.Size
+	remoteChanged := remoteMtime != base.Mtime || remoteSize != base.Size
+	return localChanged && remoteChanged
+}
+
+// conflictCopyName returns the path where the version of the given side is kept
+func conflictCopyName(name, side string, now time.Time) string {
+	return name + ".conflict-" + side + "-" + now.Format("20060102-150405")
+}
+
+// resolveConflict resolves the conflict with the configured strategy and returns true if the
+// local version was kept. If the remote version should be kept, the caller has to write it.
+// s.fileIndex needs to be locked before this function is called
+func (u *Unarchiver) resolveConflict(relativePath, outFileName string, stat os.FileInfo, header *tar.Header, tarReader io.Reader) (bool, error) {
+	keepLocal := false
+	switch u.syncConfig.Options.ConflictStrategy {
+	case latest.SyncConflictStrategyLocal:
+		keepLocal = true
+	case latest.SyncConflictStrategyRemote:
+		keepLocal = false
+	default:
+		keepLocal = stat.ModTime().Unix() > header.ModTime.Unix()
+	}
+
+	conflict := &Conflict{
+		Path: relativePath,
+		Kept: ConflictSideRemote,
+	}
+	keepBoth := u.syncConfig.Options.ConflictStrategy == latest.SyncConflictStrategyKeepBoth
+	now := time.Now()
+	if keepLocal {
+		conflict.Kept = ConflictSideLocal
+		if keepBoth {
+			conflict.Copy = conflictCopyName(relativePath, ConflictSideRemote, now)
+			err := writeConflictCopy(conflictCopyName(outFileName, ConflictSideRemote, now), header, tarReader)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		// remember the remote version and upload the local version again, as there
+		// might not be another file system event for it
+		u.syncConfig.fileIndex.fileMap[relativePath] = &FileInformation{
+			Name:  relativePath,
+			Mtime: header.ModTime.Unix(),
+			Mode:  header.FileInfo().Mode(),
+			Size:  header.Size,
+		}
+		u.syncConfig.queueUpload(createFileInformationFromStat(relativePath, stat))
+	} else if keepBoth {
+		conflict.Copy = conflictCopyName(relativePath, ConflictSideLocal, now)
+		err := os.Rename(outFileName, conflictCopyName(outFileName, ConflictSideLocal, now))
+		if err != nil {
+			return false, errors.Wrap(err, "keep local version")
+		}
+	}
+
+	u.syncConfig.onConflict(conflict)
+	return keepLocal, nil
+}
+
+func writeConflictCopy(name string, header *tar.Header, reader io.Reader) error {
+	outFile, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+	if err != nil {
+		return errors.Wrap(err, "create conflict copy")
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, reader)
+	if err != nil {
+		return errors.Wrap(err, "write conflict copy")
+	}
+
+	err = outFile.Close()
+	if err != nil {
+		return errors.Wrap(err, "close conflict copy")
+	}
+
+	_ = os.Chtimes(name, time.Now(), header.ModTime)
+	return nil
+}
+
+// queueUpload makes upstream upload the given file with the next batch of changes
+func (s *Sync) queueUpload(fileInformation *FileInformation) {
+	if s.upstream == nil || s.Options.UpstreamDisabled {
+		return
+	}
+
+	// the file index is locked by the caller, so we don't block on a full event queue
+	go func() {
+		select {
+		case s.upstream.events <- fileInformation:
+		case <-s.ctx.Done():
+		}
+	}()
+}
+
+func (s *Sync) onConflict(conflict *Conflict) {
+	if conflict.Copy != "" {
+		s.log.Warnf("Conflict - '.%s' was changed locally and in the container, kept %s version and stored the other one as '.%s'", conflict.Path, conflict.Kept, path.Clean(conflict.Copy))
+	} else {
+		s.log.Warnf("Conflict - '.%s' was changed locally and in the container, kept %s version", conflict.Path, conflict.Kept)
+	}
+
+	// hooks might take a while, so we don't block the file index
+	if s.Options.OnConflict != nil {
+		go s.Options.OnConflict(conflict)
+	}
+}
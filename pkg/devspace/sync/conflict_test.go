@@ -0,0 +1,275 @@
+//go:build !windows
+// +build !windows
+
+package sync
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/server"
+	"github.com/loft-sh/devspace/helper/util/compression"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestConflictStrategies(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	testCases := []struct {
+		name         string
+		strategy     latest.SyncConflictStrategy
+		localChanged time.Duration
+		expectedFile string
+		expectedKept string
+		expectedCopy string
+	}{
+		{name: "no conflict", expectedFile: "remote!"},
+		{name: "newest", localChanged: 20 * time.Second, expectedFile: "local", expectedKept: ConflictSideLocal},
+		{name: "newest remote", localChanged: 5 * time.Second, expectedFile: "remote!", expectedKept: ConflictSideRemote},
+		{name: "local", strategy: latest.SyncConflictStrategyLocal, localChanged: 5 * time.Second, expectedFile: "local", expectedKept: ConflictSideLocal},
+		{name: "remote", strategy: latest.SyncConflictStrategyRemote, localChanged: 20 * time.Second, expectedFile: "remote!", expectedKept: ConflictSideRemote},
+		{name: "keepBoth", strategy: latest.SyncConflictStrategyKeepBoth, localChanged: 20 * time.Second, expectedFile: "local", expectedKept: ConflictSideLocal, expectedCopy: "remote!"},
+		{name: "keepBoth remote", strategy: latest.SyncConflictStrategyKeepBoth, localChanged: 5 * time.Second, expectedFile: "remote!", expectedKept: ConflictSideRemote, expectedCopy: "local"},
+	}
+
+	for _, testCase := range testCases {
+		local := t.TempDir()
+		localMtime := base.Add(testCase.localChanged)
+		err := os.WriteFile(filepath.Join(local, "file"), []byte("local"), 0644)
+		assert.NilError(t, err)
+		err = os.Chtimes(filepath.Join(local, "file"), localMtime, localMtime)
+		assert.NilError(t, err)
+
+		conflicts := make(chan *Conflict, 1)
+		sync, err := NewSync(context.Background(), local, Options{
+			Log:              log.Discard,
+			ConflictStrategy: testCase.strategy,
+			OnConflict: func(conflict *Conflict) {
+				conflicts <- conflict
+			},
+		})
+		assert.NilError(t, err)
+
+		// the last synced version
+		sync.fileIndex.fileMap["/file"] = &FileInformation{Name: "/file", Mtime: base.Unix(), Size: 5}
+
+		// the remote version that was changed after the last sync
+		buf := &bytes.Buffer{}
+		tarWriter := tar.NewWriter(buf)
+		err = tarWriter.WriteHeader(&tar.Header{Name: "file", Mode: 0644, Size: 7, ModTime: base.Add(10 * time.Second), Typeflag: tar.TypeReg})
+		assert.NilError(t, err)
+		_, err = tarWriter.Write([]byte("remote!"))
+		assert.NilError(t, err)
+		assert.NilError(t, tarWriter.Close())
+
+		err = NewUnarchiver(sync, false, log.Discard).Untar(io.NopCloser(buf), compression.None, sync.LocalPath)
+		assert.NilError(t, err, testCase.name)
+
+		out, err := os.ReadFile(filepath.Join(local, "file"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(out), testCase.expectedFile, testCase.name)
+
+		copies, err := filepath.Glob(filepath.Join(local, "file.conflict-*"))
+		assert.NilError(t, err)
+		if testCase.expectedCopy == "" {
+			assert.Equal(t, len(copies), 0, testCase.name)
+		} else {
+			assert.Equal(t, len(copies), 1, testCase.name)
+			out, err := os.ReadFile(copies[0])
+			assert.NilError(t, err)
+			assert.Equal(t, string(out), testCase.expectedCopy, testCase.name)
+		}
+
+		if testCase.expectedKept == "" {
+			assert.Equal(t, len(conflicts), 0, testCase.name)
+			continue
+		}
+
+		select {
+		case conflict := <-conflicts:
+			assert.Equal(t, conflict.Path, "/file", testCase.name)
+			assert.Equal(t, conflict.Kept, testCase.expectedKept, testCase.name)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%s: expected a conflict", testCase.name)
+		}
+
+		// if the local version is kept, the file index holds the remote version, so that it gets uploaded
+		if testCase.expectedKept == ConflictSideLocal {
+			assert.Equal(t, sync.fileIndex.fileMap["/file"].Size, int64(7), testCase.name)
+		}
+	}
+}
+
+func TestLargeFileConflict(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	content := bytes.Repeat([]byte("0123456789abcdef"), deltaMinFileSize/16+1024)
+	localContent := append(append([]byte{}, content...), []byte("local")...)
+	remoteContent := append([]byte("remote!"), content...)
+	testCases := []struct {
+		name     string
+		strategy latest.SyncConflictStrategy
+		expected []byte
+	}{
+		{name: "local", strategy: latest.SyncConflictStrategyLocal, expected: localContent},
+		{name: "remote", strategy: latest.SyncConflictStrategyRemote, expected: remoteContent},
+	}
+
+	for _, testCase := range testCases {
+		remote, local, _ := initTestDirs(t)
+		for _, dir := range []string{local, remote} {
+			assert.NilError(t, os.WriteFile(filepath.Join(dir, "file"), content, 0644))
+			assert.NilError(t, os.Chtimes(filepath.Join(dir, "file"), base, base))
+		}
+
+		conflicts := make(chan *Conflict, 1)
+		syncClient := startTestSync(t, local, remote, Options{
+			Log:              log.Discard,
+			ConflictStrategy: testCase.strategy,
+			OnConflict: func(conflict *Conflict) {
+				conflicts <- conflict
+			},
+		})
+
+		// change the file in the container and locally
+		remoteMtime := base.Add(10 * time.Second)
+		assert.NilError(t, os.WriteFile(filepath.Join(remote, "file"), remoteContent, 0644))
+		assert.NilError(t, os.Chtimes(filepath.Join(remote, "file"), remoteMtime, remoteMtime))
+		localMtime := base.Add(20 * time.Second)
+		assert.NilError(t, os.WriteFile(filepath.Join(local, "file"), localContent, 0644))
+		assert.NilError(t, os.Chtimes(filepath.Join(local, "file"), localMtime, localMtime))
+
+		select {
+		case conflict := <-conflicts:
+			assert.Equal(t, conflict.Path, "/file", testCase.name)
+			assert.Equal(t, conflict.Kept, testCase.name, testCase.name)
+		case <-time.After(time.Second * 20):
+			t.Fatalf("%s: expected a conflict", testCase.name)
+		}
+
+		// both sides end up with the kept version
+		var localOut, remoteOut []byte
+		for start := time.Now(); time.Since(start) < time.Second*20; time.Sleep(time.Millisecond * 100) {
+			localOut, _ = os.ReadFile(filepath.Join(local, "file"))
+			remoteOut, _ = os.ReadFile(filepath.Join(remote, "file"))
+			if bytes.Equal(localOut, testCase.expected) && bytes.Equal(remoteOut, testCase.expected) {
+				break
+			}
+		}
+		assert.Assert(t, bytes.Equal(localOut, testCase.expected), "%s: unexpected local file", testCase.name)
+		assert.Assert(t, bytes.Equal(remoteOut, testCase.expected), "%s: unexpected remote file", testCase.name)
+		syncClient.Stop(nil)
+	}
+}
+
+func TestUpstreamSkipsRemoteConflicts(t *testing.T) {
+	sync, err := NewSync(context.Background(), t.TempDir(), Options{Log: log.Discard})
+	assert.NilError(t, err)
+	sync.fileIndex.fileMap["/file"] = &FileInformation{Name: "/file", Mtime: 100, Size: deltaMinFileSize}
+	u := &upstream{sync: sync}
+
+	local := &FileInformation{Name: "/file", Mtime: 120, Size: deltaMinFileSize + 1}
+	assert.Assert(t, u.isRemoteConflict(local, &remote.FileSignature{Path: "/file", Exists: true, MtimeUnix: 110, Size: deltaMinFileSize + 2}))
+
+	// unchanged in the container, already the same version or an older helper without modification times
+	assert.Assert(t, !u.isRemoteConflict(local, &remote.FileSignature{Path: "/file", Exists: true, MtimeUnix: 100, Size: deltaMinFileSize}))
+	assert.Assert(t, !u.isRemoteConflict(local, &remote.FileSignature{Path: "/file", Exists: true, MtimeUnix: 120, Size: deltaMinFileSize + 1}))
+	assert.Assert(t, !u.isRemoteConflict(local, &remote.FileSignature{Path: "/file", Exists: true, Size: deltaMinFileSize + 2}))
+
+	// without downstream nobody would resolve the conflict, so the local version is uploaded
+	sync.Options.DownstreamDisabled = true
+	assert.Assert(t, !u.isRemoteConflict(local, &remote.FileSignature{Path: "/file", Exists: true, MtimeUnix: 110, Size: deltaMinFileSize + 2}))
+}
+
+func TestUpstreamSkipsRemoteConflictsWithoutDeltaTransfer(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	remotePath, local, _ := initTestDirs(t)
+	assert.NilError(t, os.WriteFile(filepath.Join(remotePath, "file"), []byte("remote!"), 0644))
+	assert.NilError(t, os.Chtimes(filepath.Join(remotePath, "file"), base.Add(10*time.Second), base.Add(10*time.Second)))
+	assert.NilError(t, os.WriteFile(filepath.Join(local, "file"), []byte("local"), 0644))
+	assert.NilError(t, os.Chtimes(filepath.Join(local, "file"), base.Add(20*time.Second), base.Add(20*time.Second)))
+
+	// small files are not hashed by the helper
+	syncClient := initTestSync(t, local, remotePath, Options{Log: log.Discard})
+	signatureClient, err := syncClient.upstream.client.Signatures(context.Background(), &remote.Paths{Paths: []string{"/file"}})
+	assert.NilError(t, err)
+	signature, err := signatureClient.Recv()
+	assert.NilError(t, err)
+	assert.Equal(t, signature.Size, int64(7))
+	assert.Equal(t, signature.MtimeUnix, base.Add(10*time.Second).Unix())
+	assert.Equal(t, signature.BlockSize, int32(0))
+	assert.Equal(t, len(signature.Blocks), 0)
+
+	// pretend the helper is too old for delta transfer
+	syncClient.upstream.capabilities.capabilities = &remote.Capabilities{}
+	files := []*FileInformation{{Name: "/file", Mtime: base.Add(20 * time.Second).Unix(), Size: 5}}
+	syncClient.fileIndex.fileMapMutex.Lock()
+	defer syncClient.fileIndex.fileMapMutex.Unlock()
+
+	// the file was changed in the container since the last sync
+	syncClient.fileIndex.fileMap["/file"] = &FileInformation{Name: "/file", Mtime: base.Unix(), Size: 4}
+	rest, written, err := syncClient.upstream.applyDeltas(files)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rest), 0)
+	assert.Equal(t, len(written), 0)
+
+	// the file in the container is the last synced version
+	syncClient.fileIndex.fileMap["/file"] = &FileInformation{Name: "/file", Mtime: base.Add(10 * time.Second).Unix(), Size: 7}
+	rest, written, err = syncClient.upstream.applyDeltas(files)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rest), 1)
+	assert.Equal(t, len(written), 0)
+}
+
+// startTestSync starts a sync between local and remote with in process helper servers
+// and returns after the initial sync is done
+func startTestSync(t *testing.T, local, remote string, options Options) *Sync {
+	syncClient := initTestSync(t, local, remote, options)
+	syncClient.readyChan = make(chan bool)
+	go syncClient.startUpstream()
+	<-syncClient.readyChan
+
+	assert.NilError(t, syncClient.initialSync(nil, nil))
+	go syncClient.startDownstream()
+	return syncClient
+}
+
+// initTestSync connects a sync between local and remote to in process helper servers
+// without starting it
+func initTestSync(t *testing.T, local, remote string, options Options) *Sync {
+	syncClient, err := NewSync(context.Background(), local, options)
+	assert.NilError(t, err)
+	syncClient.onError = make(chan error)
+
+	downClientReader, downClientWriter, _ := os.Pipe()
+	downServerReader, downServerWriter, _ := os.Pipe()
+	upClientReader, upClientWriter, _ := os.Pipe()
+	upServerReader, upServerWriter, _ := os.Pipe()
+	t.Cleanup(func() {
+		for _, f := range []*os.File{downClientReader, downClientWriter, downServerReader, downServerWriter, upClientReader, upClientWriter, upServerReader, upServerWriter} {
+			_ = f.Close()
+		}
+	})
+
+	go func() {
+		_ = server.StartDownstreamServer(downServerReader, downClientWriter, &server.DownstreamOptions{
+			RemotePath: remote,
+		})
+	}()
+	assert.NilError(t, syncClient.InitDownstream(downClientReader, downServerWriter))
+
+	go func() {
+		_ = server.StartUpstreamServer(upServerReader, upClientWriter, &server.UpstreamOptions{
+			UploadPath: remote,
+		})
+	}()
+	assert.NilError(t, syncClient.InitUpstream(upClientReader, upServerWriter))
+	return syncClient
+}
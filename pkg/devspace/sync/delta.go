@@ -0,0 +1,481 @@
+package sync
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/util/compression"
+	"github.com/loft-sh/devspace/helper/util/delta"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deltaMinFileSize is the minimum size of a file to be transferred as delta,
+// smaller files are always sent completely within the tar archive
+const deltaMinFileSize = delta.MinFileSize
+
+// helperCapabilities retrieves and caches the capabilities of a sync helper server.
+// Older helper binaries do not implement the capabilities call, in which case all
+// optional features are disabled.
+type helperCapabilities struct {
+	m            sync.Mutex
+	capabilities *remote.Capabilities
+}
+
+func (h *helperCapabilities) get(ctx context.Context, retrieve func(ctx context.Context, in *remote.Empty, opts ...grpc.CallOption) (*remote.Capabilities, error), log log.Logger) *remote.Capabilities {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.capabilities != nil {
+		return h.capabilities
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	capabilities, err := retrieve(ctx, &remote.Empty{})
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			// try again next time
+			log.Debugf("Error retrieving sync helper capabilities: %v", err)
+			return &remote.Capabilities{}
+		}
+
+		log.Debugf("Sync helper does not support capabilities, please consider updating the helper")
+		capabilities = &remote.Capabilities{}
+	}
+
+	h.capabilities = capabilities
+	return h.capabilities
+}
+
+// isUpdate checks if the file is a regular file that exists in the container already
+func (u *upstream) isUpdate(file *FileInformation) bool {
+	if file.IsDirectory || file.IsSymbolicLink {
+		return false
+	}
+
+	remoteFile := u.sync.fileIndex.fileMap[file.Name]
+	if remoteFile == nil || remoteFile.IsDirectory {
+		return false
+	} else if u.ignoreMatcher != nil && u.ignoreMatcher.Matches(file.Name, false) {
+		return false
+	}
+
+	stat, err := os.Lstat(path.Join(u.sync.LocalPath, file.Name))
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode().IsRegular()
+}
+
+// isRemoteConflict checks if the file was changed in the container as well since it was last synced.
+// Such files are not uploaded, downstream downloads them and resolves the conflict instead.
+// Function assumes that fileMap is locked for access
+func (u *upstream) isRemoteConflict(file *FileInformation, signature *remote.FileSignature) bool {
+	// older helpers do not send the modification time
+	if u.sync.Options.DownstreamDisabled || !signature.Exists || signature.MtimeUnix == 0 {
+		return false
+	} else if u.sync.downloadIgnoreMatcher != nil && u.sync.downloadIgnoreMatcher.Matches(file.Name, false) {
+		return false
+	}
+
+	return changedOnBothSides(u.sync.fileIndex.fileMap[file.Name], file.Mtime, file.Size, signature.MtimeUnix, signature.Size)
+}
+
+// applyDeltas skips files that were changed in the container as well and uploads only the
+// changed blocks of bigger files that already exist in the container. It returns the files
+// that have to be uploaded completely and the files that were written.
+// Function assumes that fileMap is locked for access
+func (u *upstream) applyDeltas(files []*FileInformation) ([]*FileInformation, map[string]*FileInformation, error) {
+	updates := make([]string, 0, len(files))
+	for _, file := range files {
+		if u.isUpdate(file) {
+			updates = append(updates, file.Name)
+		}
+	}
+	if len(updates) == 0 {
+		return files, nil, nil
+	} else if !u.capabilities.get(u.sync.ctx, u.client.Capabilities, u.sync.log).DeltaTransfer {
+		files, err := u.skipRemoteConflicts(files, updates)
+		return files, nil, err
+	}
+
+	// cancel after 1 hour
+	ctx, cancel := context.WithTimeout(u.sync.ctx, time.Hour)
+	defer cancel()
+
+	// retrieve the block checksums and modification times of the remote files
+	signatureClient, err := u.client.Signatures(ctx, &remote.Paths{Paths: updates})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "signatures")
+	}
+
+	signatures := make(map[string]*remote.FileSignature, len(updates))
+	for {
+		signature, err := signatureClient.Recv()
+		if signature != nil {
+			signatures[signature.Path] = signature
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, errors.Wrap(err, "recv signature")
+		}
+	}
+
+	candidates := make([]*FileInformation, 0, len(updates))
+	rest := make([]*FileInformation, 0, len(files))
+	for _, file := range files {
+		signature := signatures[file.Name]
+		if signature == nil {
+			rest = append(rest, file)
+			continue
+		} else if u.isRemoteConflict(file, signature) {
+			u.sync.log.Infof("Upstream - Skip '%s' because it was changed in the container as well", u.getRelativeUpstreamPath(file.Name))
+			continue
+		}
+
+		// the helper only sends blocks for files that are big enough
+		if signature.Exists && signature.BlockSize > 0 && file.Size >= deltaMinFileSize {
+			candidates = append(candidates, file)
+		} else {
+			rest = append(rest, file)
+		}
+	}
+	if len(candidates) == 0 {
+		return rest, nil, nil
+	}
+
+	uploadClient, err := u.client.UploadDelta(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "upload delta")
+	}
+
+	var (
+		written     = make(map[string]*FileInformation, len(candidates))
+		size        = int64(0)
+		transferred = int64(0)
+	)
+	for _, c := range candidates {
+		fileInformation, sent, err := u.sendDelta(uploadClient, c, signatures[c.Name])
+		if err != nil {
+			_, recvErr := uploadClient.CloseAndRecv()
+			if recvErr != nil {
+				return nil, nil, errors.Wrap(recvErr, "upload delta")
+			}
+
+			return nil, nil, errors.Wrapf(err, "upload delta %s", c.Name)
+		} else if fileInformation == nil {
+			rest = append(rest, c)
+			continue
+		}
+
+		if u.sync.Options.Verbose || len(candidates) <= 3 {
+			u.sync.log.Infof("Upstream - Upload File '%s' as delta", u.getRelativeUpstreamPath(c.Name))
+		}
+
+		written[c.Name] = fileInformation
+		size += fileInformation.Size
+		transferred += sent
+	}
+
+	_, err = uploadClient.CloseAndRecv()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "after upload delta")
+	}
+
+	if len(written) > 0 {
+		u.sync.log.Infof("Upstream - Upload %d file(s) as delta (~%0.2f KB changed of ~%0.2f KB)", len(written), float64(transferred)/1024.0, float64(size)/1024.0)
+		for _, element := range written {
+			u.sync.fileIndex.CreateDirInFileMap(path.Dir(element.Name))
+			u.sync.fileIndex.fileMap[element.Name] = element
+		}
+	}
+
+	return rest, written, nil
+}
+
+// skipRemoteConflicts skips files that were changed in the container as well for helpers without
+// delta transfer, which can't send the modification times of the remote files. Since these helpers
+// have no call to stat files, the files are downloaded and only the tar headers are read.
+// Function assumes that fileMap is locked for access
+func (u *upstream) skipRemoteConflicts(files []*FileInformation, updates []string) ([]*FileInformation, error) {
+	if u.sync.Options.DownstreamDisabled || u.sync.downstream == nil {
+		return files, nil
+	}
+
+	signatures, err := u.sync.downstream.stat(updates)
+	if err != nil {
+		return nil, errors.Wrap(err, "stat remote files")
+	}
+
+	rest := make([]*FileInformation, 0, len(files))
+	for _, file := range files {
+		signature := signatures[file.Name]
+		if signature != nil && u.isRemoteConflict(file, signature) {
+			u.sync.log.Infof("Upstream - Skip '%s' because it was changed in the container as well", u.getRelativeUpstreamPath(file.Name))
+			continue
+		}
+
+		rest = append(rest, file)
+	}
+
+	return rest, nil
+}
+
+func (u *upstream) sendDelta(client remote.Upstream_UploadDeltaClient, file *FileInformation, signature *remote.FileSignature) (*FileInformation, int64, error) {
+	f, err := os.Open(path.Join(u.sync.LocalPath, file.Name))
+	if err != nil {
+		// We skip files that are suddenly not there anymore
+		return nil, 0, nil
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil || !stat.Mode().IsRegular() {
+		return nil, 0, nil
+	}
+
+	transferred := int64(0)
+	fileInformation := createFileInformationFromStat(file.Name, stat)
+	err = delta.Send(&remote.DeltaChunk{
+		Path:      file.Name,
+		MtimeUnix: fileInformation.Mtime,
+		Mode:      uint32(chmodTarEntry(stat.Mode())),
+	}, delta.FromRemote(signature), f, func(chunk *remote.DeltaChunk) error {
+		for _, op := range chunk.Operations {
+			transferred += int64(len(op.Data))
+		}
+
+		return client.Send(chunk)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return fileInformation, transferred, nil
+}
+
+// stat returns the size and modification time of the given remote files by downloading them
+// and reading the tar headers. Files that don't exist in the container are not returned.
+func (d *downstream) stat(paths []string) (map[string]*remote.FileSignature, error) {
+	// cancel after 1 hour
+	ctx, cancel := context.WithTimeout(d.sync.ctx, time.Hour)
+	defer cancel()
+
+	downloadClient, err := d.client.Download(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "download files")
+	}
+
+	// an empty compression is understood by every helper and means gzip
+	err = downloadClient.Send(&remote.Paths{Paths: paths})
+	if err != nil {
+		return nil, errors.Wrap(err, "send path")
+	}
+
+	err = downloadClient.CloseSend()
+	if err != nil {
+		return nil, errors.Wrap(err, "close send")
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		for {
+			chunk, err := downloadClient.Recv()
+			if chunk != nil {
+				_, writeErr := writer.Write(chunk.Content)
+				if writeErr != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	decompressed, err := compression.NewReader(compression.Gzip, reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompress")
+	}
+	defer decompressed.Close()
+
+	signatures := make(map[string]*remote.FileSignature, len(paths))
+	tarReader := tar.NewReader(decompressed)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read tar")
+		} else if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := getRelativeFromFullPath("/"+header.Name, "")
+		signatures[name] = &remote.FileSignature{
+			Path:      name,
+			Exists:    true,
+			Size:      header.Size,
+			MtimeUnix: header.ModTime.Unix(),
+		}
+	}
+
+	return signatures, nil
+}
+
+func (d *downstream) isDeltaCandidate(change *remote.Change) bool {
+	if change.IsDir || change.Size < deltaMinFileSize {
+		return false
+	}
+
+	stat, err := os.Lstat(filepath.Join(d.sync.LocalPath, change.Path))
+	if err != nil || !stat.Mode().IsRegular() {
+		return false
+	}
+
+	// the unarchiver keeps local files that are newer and resolves conflicts, so we leave those to it
+	if stat.ModTime().Unix() > change.MtimeUnix {
+		return false
+	}
+
+	d.sync.fileIndex.fileMapMutex.Lock()
+	defer d.sync.fileIndex.fileMapMutex.Unlock()
+
+	return !changedOnBothSides(d.sync.fileIndex.fileMap[change.Path], stat.ModTime().Unix(), stat.Size(), change.MtimeUnix, change.Size)
+}
+
+// downloadDeltas downloads only the changed blocks of files that already exist locally.
+// It returns the changes that have to be downloaded completely.
+func (d *downstream) downloadDeltas(changes []*remote.Change) ([]*remote.Change, error) {
+	candidates := make([]*remote.Change, 0, len(changes))
+	rest := make([]*remote.Change, 0, len(changes))
+	for _, change := range changes {
+		if d.isDeltaCandidate(change) {
+			candidates = append(candidates, change)
+		} else {
+			rest = append(rest, change)
+		}
+	}
+	if len(candidates) == 0 || !d.capabilities.get(d.sync.ctx, d.client.Capabilities, d.sync.log).DeltaTransfer {
+		return changes, nil
+	}
+
+	// cancel after 1 hour
+	ctx, cancel := context.WithTimeout(d.sync.ctx, time.Hour)
+	defer cancel()
+
+	downloadClient, err := d.client.DownloadDelta(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "download delta")
+	}
+
+	// send the signatures of the local files
+	sent := make(map[string]*remote.Change, len(candidates))
+	for _, c := range candidates {
+		signature, err := delta.FileSignature(filepath.Join(d.sync.LocalPath, c.Path))
+		if err != nil {
+			rest = append(rest, c)
+			continue
+		}
+
+		err = downloadClient.Send(delta.ToRemote(c.Path, signature))
+		if err != nil {
+			return nil, errors.Wrap(err, "send signature")
+		}
+
+		sent[c.Path] = c
+	}
+
+	err = downloadClient.CloseSend()
+	if err != nil {
+		return nil, errors.Wrap(err, "close send")
+	}
+
+	// receive and apply the changed blocks
+	var (
+		written     = make(map[string]bool, len(sent))
+		size        = int64(0)
+		transferred = int64(0)
+	)
+	err = delta.Receive(func() (*remote.DeltaChunk, error) {
+		chunk, err := downloadClient.Recv()
+		if chunk != nil {
+			for _, op := range chunk.Operations {
+				transferred += int64(len(op.Data))
+			}
+		}
+
+		return chunk, err
+	}, func(header *remote.DeltaChunk) (string, error) {
+		if sent[header.Path] == nil {
+			return "", errors.Errorf("unexpected delta for %s", header.Path)
+		}
+
+		return filepath.Join(d.sync.LocalPath, header.Path), nil
+	}, func(header *remote.DeltaChunk) error {
+		absolutePath := filepath.Join(d.sync.LocalPath, header.Path)
+		_ = os.Chtimes(absolutePath, time.Now(), time.Unix(header.MtimeUnix, 0))
+
+		stat, err := os.Stat(absolutePath)
+		if err != nil {
+			return errors.Wrapf(err, "stat %s", header.Path)
+		}
+
+		if len(candidates) <= 3 || d.sync.Options.Verbose {
+			d.sync.log.Infof("Downstream - Download file '.%s' as delta", header.Path)
+		}
+
+		// Update fileMap so that upstream does not upload the file
+		d.sync.fileIndex.fileMapMutex.Lock()
+		d.sync.fileIndex.fileMap[header.Path] = &FileInformation{
+			Name:  header.Path,
+			Mtime: header.MtimeUnix,
+			Mode:  os.FileMode(header.Mode),
+			Size:  stat.Size(),
+		}
+		d.sync.fileIndex.fileMapMutex.Unlock()
+
+		written[header.Path] = true
+		size += stat.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "receive delta")
+	}
+
+	// files that are not there anymore are left to the tar download
+	for p, c := range sent {
+		if !written[p] {
+			rest = append(rest, c)
+		}
+	}
+
+	if len(written) > 0 {
+		d.sync.log.Infof("Downstream - Download %d file(s) as delta (~%0.2f KB changed of ~%0.2f KB)", len(written), float64(transferred)/1024.0, float64(size)/1024.0)
+	}
+
+	return rest, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/server/ignoreparser"
 	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	"google.golang.org/grpc"
 
@@ -27,6 +28,8 @@ type downstream struct {
 	writer io.WriteCloser
 	client remote.DownstreamClient
 
+	capabilities helperCapabilities
+
 	ignoreMatcher ignoreparser.IgnoreParser
 	conn          *grpc.ClientConn
 
@@ -205,6 +208,7 @@ func (d *downstream) mainLoop() error {
 		} else {
 			lastAmountChanges = changeAmount.Amount
 		}
+		d.sync.setPendingDownstream(int(lastAmountChanges))
 	}
 }
 
@@ -270,6 +274,7 @@ func (d *downstream) applyChanges(changes []*remote.Change, force bool) error {
 	}
 
 	d.sync.log.Infof("Downstream - Successfully processed %d change(s)", len(changes))
+	d.sync.markSynced()
 	return nil
 }
 
@@ -288,6 +293,17 @@ func (d *downstream) updateDownloadChanges(download []*remote.Change) []*remote.
 }
 
 func (d *downstream) initDownload(download []*remote.Change) error {
+	// transfer only the changed blocks of bigger files that already exist locally
+	download, err := d.downloadDeltas(download)
+	if err != nil {
+		return errors.Wrap(err, "download deltas")
+	} else if len(download) == 0 {
+		return nil
+	}
+
+	// choose a compression both sides understand
+	codec := compression.Negotiate(string(d.sync.Options.Compression), d.capabilities.get(d.sync.ctx, d.client.Capabilities, d.sync.log).Compressions)
+
 	reader, writer := io.Pipe()
 
 	defer reader.Close()
@@ -295,12 +311,12 @@ func (d *downstream) initDownload(download []*remote.Change) error {
 
 	errorChan := make(chan error)
 	go func() {
-		errorChan <- d.downloadFiles(writer, download)
+		errorChan <- d.downloadFiles(writer, codec, download)
 	}()
 
 	// Untaring all downloaded files to the right location
 	// this can be a lengthy process when we downloaded a lot of files
-	err := d.unarchiver.Untar(reader, d.sync.LocalPath)
+	err = d.unarchiver.Untar(reader, codec, d.sync.LocalPath)
 	if err != nil {
 		return errors.Wrap(err, "untar files")
 	}
@@ -309,7 +325,7 @@ func (d *downstream) initDownload(download []*remote.Change) error {
 }
 
 // downloadFiles downloads the given files from the remote server and writes the contents into the given writer
-func (d *downstream) downloadFiles(writer io.WriteCloser, changes []*remote.Change) error {
+func (d *downstream) downloadFiles(writer io.WriteCloser, codec string, changes []*remote.Change) error {
 	defer writer.Close()
 
 	// cancel after 1 hour
@@ -336,26 +352,30 @@ func (d *downstream) downloadFiles(writer io.WriteCloser, changes []*remote.Chan
 		return errors.Wrap(err, "download files")
 	}
 
-	// Send files to download
+	// Send files to download, the first message also tells the helper how to compress the archive
+	pathsCodec := codec
 	downloadFiles := make([]string, 0, downloadFilesBufferSize)
 	for _, change := range changes {
 		downloadFiles = append(downloadFiles, change.Path)
 
 		if len(downloadFiles) >= downloadFilesBufferSize {
 			err = downloadClient.Send(&remote.Paths{
-				Paths: downloadFiles,
+				Paths:       downloadFiles,
+				Compression: pathsCodec,
 			})
 			if err != nil {
 				return errors.Wrap(err, "send path")
 			}
 
+			pathsCodec = ""
 			downloadFiles = make([]string, 0, downloadFilesBufferSize)
 		}
 	}
 
 	if len(downloadFiles) >= 0 {
 		err = downloadClient.Send(&remote.Paths{
-			Paths: downloadFiles,
+			Paths:       downloadFiles,
+			Compression: pathsCodec,
 		})
 		if err != nil {
 			return errors.Wrap(err, "send path")
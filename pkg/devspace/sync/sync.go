@@ -52,6 +52,11 @@ type Options struct {
 	InitialSyncCompareBy latest.InitialSyncCompareBy
 	InitialSync          latest.InitialSyncStrategy
 
+	Compression latest.SyncCompression
+
+	ConflictStrategy latest.SyncConflictStrategy
+	OnConflict       func(conflict *Conflict)
+
 	Starter DelayedContainerStarter
 
 	Log log.Logger
@@ -79,6 +84,11 @@ type Sync struct {
 
 	stopOnce sync.Once
 
+	statusMutex       sync.Mutex
+	lastSync          time.Time
+	pendingUpstream   int
+	pendingDownstream int
+
 	onError chan error
 	onDone  chan struct{}
 
@@ -109,9 +119,10 @@ func NewSync(ctx context.Context, localPath string, options Options) (*Sync, err
 		return nil, errors.Wrap(err, "eval symlinks")
 	}
 
-	// We exclude the sync log to prevent an endless loop in upstream
+	// We exclude the sync log to prevent an endless loop in upstream and the
+	// temporary files delta transfers write to
 	newExcludes := []string{}
-	newExcludes = append(newExcludes, ".devspace/")
+	newExcludes = append(newExcludes, ".devspace/", ".*.devspace-delta-*")
 	newExcludes = append(newExcludes, options.ExcludePaths...)
 	options.ExcludePaths = newExcludes
 
@@ -135,6 +146,43 @@ func NewSync(ctx context.Context, localPath string, options Options) (*Sync, err
 	return s, nil
 }
 
+// LastSync returns the time changes were last applied in either direction
+func (s *Sync) LastSync() time.Time {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	return s.lastSync
+}
+
+// PendingChanges returns the amount of changes that were detected but not yet applied
+func (s *Sync) PendingChanges() int {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	return s.pendingUpstream + s.pendingDownstream
+}
+
+func (s *Sync) setPendingUpstream(amount int) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	s.pendingUpstream = amount
+}
+
+func (s *Sync) setPendingDownstream(amount int) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	s.pendingDownstream = amount
+}
+
+func (s *Sync) markSynced() {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	s.lastSync = time.Now()
+}
+
 // Error handles a sync error
 func (s *Sync) Error(err error) {
 	s.log.Errorf("Sync Error on %s: %v", s.LocalPath, err)
@@ -301,6 +349,7 @@ func (s *Sync) initialSync(onInitUploadDone chan struct{}, onInitDownloadDone ch
 			}
 
 			// signal upstream that initial sync is done
+			s.markSynced()
 			s.upstream.initialSyncCompletedMutex.Lock()
 			s.upstream.initialSyncCompleted = true
 			s.upstream.initialSyncCompletedMutex.Unlock()
@@ -4,7 +4,12 @@
 package sync
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"path"
 	"path/filepath"
@@ -17,6 +22,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/log"
 
 	"github.com/loft-sh/devspace/helper/server"
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/pkg/errors"
 )
@@ -754,3 +760,89 @@ func TestRemoveDirInFileMap(t *testing.T) {
 		t.Fatal("Remove dir in file map failed!")
 	}
 }
+
+type countingWriteCloser struct {
+	io.Writer
+	n int64
+}
+
+func (c *countingWriteCloser) Write(p []byte) (int, error) {
+	n, err := c.Writer.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingWriteCloser) Close() error {
+	return nil
+}
+
+func BenchmarkCompression(b *testing.B) {
+	local := b.TempDir()
+	r := rand.New(rand.NewSource(1))
+
+	// a mix of source code like text and binary data
+	files := []*FileInformation{}
+	size := int64(0)
+	for i := 0; i < 50; i++ {
+		content := []byte(strings.Repeat(fmt.Sprintf("func handler%d(w http.ResponseWriter, r *http.Request) {\n\tw.WriteHeader(%d)\n}\n", i, r.Intn(600)), 400))
+		if i%5 == 0 {
+			content = make([]byte, 64*1024)
+			_, _ = r.Read(content)
+		}
+
+		name := fmt.Sprintf("/file%d", i)
+		err := os.WriteFile(filepath.Join(local, name), content, 0666)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		files = append(files, &FileInformation{Name: name, Size: int64(len(content))})
+		size += int64(len(content))
+	}
+
+	sync, err := NewSync(context.Background(), local, Options{Log: log.Discard})
+	if err != nil {
+		b.Fatal(err)
+	}
+	u := &upstream{sync: sync}
+
+	for _, codec := range []string{compression.Gzip, compression.Zstd, compression.LZ4, compression.None} {
+		b.Run(codec, func(b *testing.B) {
+			b.SetBytes(size)
+			compressed := int64(0)
+			for i := 0; i < b.N; i++ {
+				buf := &bytes.Buffer{}
+				writer := &countingWriteCloser{Writer: buf}
+				_, err := u.compress(writer, codec, files, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				reader, err := compression.NewReader(codec, buf)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				tarReader := tar.NewReader(reader)
+				for {
+					_, err := tarReader.Next()
+					if err == io.EOF {
+						break
+					} else if err != nil {
+						b.Fatal(err)
+					}
+
+					_, err = io.Copy(io.Discard, tarReader)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				reader.Close()
+				compressed = writer.n
+			}
+
+			b.ReportMetric(float64(compressed)/float64(size), "ratio")
+		})
+	}
+}
@@ -2,7 +2,7 @@ package sync
 
 import (
 	"archive/tar"
-	"compress/gzip"
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	"io"
 	"os"
@@ -36,19 +36,19 @@ func NewUnarchiver(syncConfig *Sync, forceOverride bool, log log.Logger) *Unarch
 	}
 }
 
-// Untar untars the given reader into the destination directory
-func (u *Unarchiver) Untar(fromReader io.ReadCloser, toPath string) error {
+// Untar decompresses the given reader with codec and untars it into the destination directory
+func (u *Unarchiver) Untar(fromReader io.ReadCloser, codec string, toPath string) error {
 	defer fromReader.Close()
 
 	fileCounter := 0
-	gzr, err := gzip.NewReader(fromReader)
+	decompressor, err := compression.NewReader(codec, fromReader)
 	if err != nil {
 		return errors.Errorf("error decompressing: %v", err)
 	}
 
-	defer gzr.Close()
+	defer decompressor.Close()
 
-	tarReader := tar.NewReader(gzr)
+	tarReader := tar.NewReader(decompressor)
 	for {
 		shouldContinue, err := u.untarNext(toPath, tarReader)
 		if err != nil {
@@ -84,7 +84,17 @@ func (u *Unarchiver) untarNext(destPath string, tarReader *tar.Reader) (bool, er
 	// Check if newer file is there and then don't override?
 	stat, err := os.Stat(outFileName)
 	if err == nil && !u.forceOverride {
-		if stat.ModTime().Unix() > header.FileInfo().ModTime().Unix() {
+		if isConflict(u.syncConfig, relativePath, stat, header) {
+			keepLocal, err := u.resolveConflict(relativePath, outFileName, stat, header, tarReader)
+			if err != nil {
+				return false, err
+			} else if keepLocal {
+				return true, nil
+			}
+
+			// the local file might have been moved away
+			stat, _ = os.Stat(outFileName)
+		} else if stat.ModTime().Unix() > header.FileInfo().ModTime().Unix() {
 			// Update filemap otherwise we download and download again
 			u.syncConfig.fileIndex.fileMap[relativePath] = &FileInformation{
 				Name:        relativePath,
@@ -3,7 +3,6 @@ package sync
 import (
 	"archive/tar"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -17,11 +16,13 @@ import (
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/server/ignoreparser"
 	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/compression"
 	"github.com/loft-sh/devspace/helper/util/crc32"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/restart"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/loft-sh/devspace/pkg/util/idle"
 	"google.golang.org/grpc"
 
 	"github.com/loft-sh/notify"
@@ -46,6 +47,8 @@ type upstream struct {
 	writer io.WriteCloser
 	client remote.UpstreamClient
 
+	capabilities helperCapabilities
+
 	isBusy      bool
 	isBusyMutex sync.Mutex
 
@@ -244,6 +247,7 @@ func (u *upstream) mainLoop() error {
 			}
 
 			changeAmount = len(changes)
+			u.sync.setPendingUpstream(changeAmount)
 			if changeAmount == 0 && len(u.events) == 0 {
 				u.isBusyMutex.Lock()
 				if len(u.events) == 0 {
@@ -263,6 +267,7 @@ func (u *upstream) mainLoop() error {
 		if err != nil {
 			return errors.Wrap(err, "apply changes")
 		}
+		u.sync.setPendingUpstream(0)
 	}
 }
 
@@ -642,6 +647,9 @@ func (u *upstream) applyChanges(changes []*FileInformation) error {
 	u.sync.log.Debugf("Upstream - Start applying %d changes", len(changes))
 	defer u.sync.log.Debugf("Upstream - Done applying changes")
 
+	// local changes mean the user is active
+	idle.Touch()
+
 	var creates []*FileInformation
 	var removes []*FileInformation
 
@@ -702,6 +710,7 @@ func (u *upstream) applyChanges(changes []*FileInformation) error {
 	}
 
 	u.sync.log.Infof("Upstream - Successfully processed %d change(s)", changeAmount)
+	u.sync.markSynced()
 	changeNames := make([]string, 0, changeAmount)
 	for _, c := range removes {
 		changeNames = append(changeNames, c.Name)
@@ -769,6 +778,14 @@ func (u *upstream) applyCreates(files []*FileInformation) (map[string]*FileInfor
 		return nil, nil
 	}
 
+	// transfer only the changed blocks of bigger files that already exist remotely
+	files, deltaFiles, err := u.applyDeltas(files)
+	if err != nil {
+		return nil, errors.Wrap(err, "apply deltas")
+	} else if len(files) == 0 {
+		return deltaFiles, nil
+	}
+
 	size := int64(0)
 	for _, c := range files {
 		if c.IsDirectory {
@@ -793,16 +810,19 @@ func (u *upstream) applyCreates(files []*FileInformation) (map[string]*FileInfor
 	defer reader.Close()
 	defer writer.Close()
 
+	// choose a compression both sides understand
+	codec := compression.Negotiate(string(u.sync.Options.Compression), u.capabilities.get(u.sync.ctx, u.client.Capabilities, u.sync.log).Compressions)
+
 	var archiver *Archiver
 	errorChan := make(chan error)
 	go func() {
 		var compressErr error
-		archiver, compressErr = u.compress(writer, files, u.ignoreMatcher)
+		archiver, compressErr = u.compress(writer, codec, files, u.ignoreMatcher)
 		errorChan <- compressErr
 	}()
 
 	// upload the archive
-	err = u.uploadArchive(reader)
+	err = u.uploadArchive(reader, codec)
 	if err != nil {
 		return nil, errors.Wrap(err, "upload archive")
 	}
@@ -814,12 +834,16 @@ func (u *upstream) applyCreates(files []*FileInformation) (map[string]*FileInfor
 	}
 
 	// finally update written files
-	for _, element := range archiver.WrittenFiles() {
+	writtenFiles := archiver.WrittenFiles()
+	for _, element := range writtenFiles {
 		u.sync.fileIndex.CreateDirInFileMap(path.Dir(element.Name))
 		u.sync.fileIndex.fileMap[element.Name] = element
 	}
+	for name, element := range deltaFiles {
+		writtenFiles[name] = element
+	}
 
-	return archiver.WrittenFiles(), nil
+	return writtenFiles, nil
 }
 
 func (u *upstream) filterChanges(files []*FileInformation) ([]*FileInformation, error) {
@@ -939,15 +963,18 @@ func (u *upstream) filterChanges(files []*FileInformation) ([]*FileInformation,
 	return newChanges, nil
 }
 
-func (u *upstream) compress(writer io.WriteCloser, files []*FileInformation, ignoreMatcher ignoreparser.IgnoreParser) (*Archiver, error) {
+func (u *upstream) compress(writer io.WriteCloser, codec string, files []*FileInformation, ignoreMatcher ignoreparser.IgnoreParser) (*Archiver, error) {
 	defer writer.Close()
 
 	// Use compression
-	gw := gzip.NewWriter(writer)
-	defer gw.Close()
+	compressor, err := compression.NewWriter(codec, writer)
+	if err != nil {
+		return nil, err
+	}
+	defer compressor.Close()
 
 	// Create tar writer
-	tarWriter := tar.NewWriter(gw)
+	tarWriter := tar.NewWriter(compressor)
 	defer tarWriter.Close()
 
 	// Archive the given files
@@ -962,7 +989,7 @@ func (u *upstream) compress(writer io.WriteCloser, files []*FileInformation, ign
 	return archiver, nil
 }
 
-func (u *upstream) uploadArchive(reader io.ReadCloser) error {
+func (u *upstream) uploadArchive(reader io.ReadCloser, codec string) error {
 	defer reader.Close()
 
 	// cancel after 1 hour
@@ -975,13 +1002,21 @@ func (u *upstream) uploadArchive(reader io.ReadCloser) error {
 		return errors.Wrap(err, "upload")
 	}
 
+	// the first chunk tells the helper how to decompress the archive
+	first := true
 	buf := make([]byte, 16*1024)
 	for {
 		n, err := reader.Read(buf)
 		if n > 0 {
-			err := uploadClient.Send(&remote.Chunk{
+			chunk := &remote.Chunk{
 				Content: buf[:n],
-			})
+			}
+			if first {
+				chunk.Compression = codec
+				first = false
+			}
+
+			err := uploadClient.Send(chunk)
 			if err != nil {
 				_, recvErr := uploadClient.CloseAndRecv()
 				if recvErr != nil {
@@ -188,6 +188,15 @@ func SendData(stream remote.Tunnel_InitTunnelClient, sessions <-chan *tunnel.Ses
 }
 
 func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, stopChan chan struct{}, namespace string, name string, log logpkg.Logger) error {
+	return startTunnels(ctx, reader, writer, tunnels, false, stopChan, log)
+}
+
+// StartForward forwards the given udp ports from the local machine into the container
+func StartForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, stopChan chan struct{}, log logpkg.Logger) error {
+	return startTunnels(ctx, reader, writer, tunnels, true, stopChan, log)
+}
+
+func startTunnels(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, forward bool, stopChan chan struct{}, log logpkg.Logger) error {
 	scheme := "TCP"
 	closeStreams := make([]chan bool, len(tunnels))
 	defer func() {
@@ -246,6 +255,19 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 		localPort := mappings[0].Local
 		remotePort := mappings[0].Remote
 		c := make(chan bool, 1)
+		if portMapping.Protocol == latest.PortProtocolUDP {
+			go func(closeStream chan bool, localPort, remotePort int32, bindAddress string) {
+				err := startUDPTunnel(ctx, client, closeStream, localPort, remotePort, bindAddress, forward, log)
+				if err != nil {
+					errorsChan <- err
+				}
+			}(c, int32(localPort), int32(remotePort), portMapping.BindAddress)
+			closeStreams[i] = c
+			continue
+		} else if forward {
+			return fmt.Errorf("forwarding port %s: only udp ports can be forwarded through the helper", portMapping.Port)
+		}
+
 		go func(closeStream chan bool, localPort, remotePort int32) {
 			tunnelScheme, ok := remote.TunnelScheme_value[scheme]
 			if !ok {
@@ -299,3 +321,74 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 		return nil
 	}
 }
+
+func startUDPTunnel(ctx context.Context, client remote.TunnelClient, closeStream <-chan bool, localPort, remotePort int32, bindAddress string, forward bool, log logpkg.Logger) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-closeStream:
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	stream, err := client.InitTunnel(streamCtx)
+	if err != nil {
+		return fmt.Errorf("error sending init tunnel request: %v", err)
+	}
+
+	err = stream.Send(&remote.SocketDataRequest{
+		Port:    remotePort,
+		Scheme:  remote.TunnelScheme_UDP,
+		Forward: forward,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send initial tunnel request to server")
+	}
+
+	datagramStream := &clientDatagramStream{stream: stream}
+	if forward {
+		if bindAddress == "" {
+			bindAddress = "localhost"
+		}
+
+		log.Donef("Port forwarding started on: %s", ansi.Color(fmt.Sprintf("%d -> %d (udp)", localPort, remotePort), "white+b"))
+		err = tunnel.ListenUDP(streamCtx, datagramStream, fmt.Sprintf("%s:%d", bindAddress, localPort))
+	} else {
+		log.Donef("Port forwarding started on: %s", ansi.Color(fmt.Sprintf("%d <- %d (udp)", localPort, remotePort), "white+b"))
+		err = tunnel.DialUDP(streamCtx, datagramStream, fmt.Sprintf("localhost:%d", localPort))
+	}
+	if streamCtx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+type clientDatagramStream struct {
+	stream remote.Tunnel_InitTunnelClient
+}
+
+func (c *clientDatagramStream) Send(requestID string, data []byte, shouldClose bool) error {
+	return c.stream.Send(&remote.SocketDataRequest{
+		RequestId:   requestID,
+		Data:        data,
+		ShouldClose: shouldClose,
+	})
+}
+
+func (c *clientDatagramStream) Recv() (string, []byte, bool, error) {
+	m, err := c.stream.Recv()
+	if err != nil {
+		return "", nil, false, fmt.Errorf("error reading from stream: %v", err)
+	} else if m.HasErr {
+		if m.LogMessage == nil {
+			return "", nil, false, fmt.Errorf("remote error: unknown")
+		}
+
+		return "", nil, false, fmt.Errorf("helper error: %s", m.LogMessage.Message)
+	}
+
+	return m.RequestId, m.Data, m.ShouldClose, nil
+}
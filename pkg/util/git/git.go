@@ -98,3 +98,40 @@ func (gr *GitCLIRepository) Pull(ctx context.Context) error {
 		return nil
 	}
 }
+
+// Fetch fetches the given ref from origin into FETCH_HEAD
+func (gr *GitCLIRepository) Fetch(ctx context.Context, ref string, shallow bool) error {
+	args := []string{"-C", gr.LocalPath, "fetch", "origin", ref}
+	if shallow {
+		args = append(args, "--depth", "1")
+	}
+
+	gitEnv := []string{
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_SSH_COMMAND=ssh -oBatchMode=yes",
+	}
+	gitEnv = append(gitEnv, os.Environ()...)
+	out, err := command.CombinedOutput(ctx, gr.LocalPath, expand.ListEnviron(gitEnv...), "git", args...)
+	if err != nil {
+		return errors.Errorf("Error running 'git fetch origin %s': %v -> %s", ref, err, string(out))
+	}
+
+	return nil
+}
+
+// Checkout checks out the given commit. If a branch is checked out, the branch is moved
+// to the commit, so that it can be pulled afterwards.
+func (gr *GitCLIRepository) Checkout(ctx context.Context, commit string) error {
+	args := []string{"-C", gr.LocalPath, "checkout", "--detach", commit}
+	out, err := command.Output(ctx, gr.LocalPath, expand.ListEnviron(os.Environ()...), "git", "-C", gr.LocalPath, "symbolic-ref", "--short", "-q", "HEAD")
+	if err == nil && strings.TrimSpace(string(out)) != "" {
+		args = []string{"-C", gr.LocalPath, "checkout", "-B", strings.TrimSpace(string(out)), commit}
+	}
+
+	out, err = command.CombinedOutput(ctx, gr.LocalPath, expand.ListEnviron(os.Environ()...), "git", args...)
+	if err != nil {
+		return errors.Errorf("Error running 'git checkout %s': %v -> %s", commit, err, string(out))
+	}
+
+	return nil
+}
@@ -104,6 +104,78 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 		return fmt.Sprintf("%x", hash.Sum(nil)), nil
 	}
 
+	err = walkExcludes(srcPath, excludePatterns, func(filePath, relFilePath string, f os.FileInfo) {
+		if f.IsDir() {
+			// Path is enough
+			_, _ = io.WriteString(hash, filePath)
+		} else {
+			if fast {
+				_, _ = io.WriteString(hash, filePath+";"+strconv.FormatInt(f.Size(), 10)+";"+strconv.FormatInt(f.ModTime().Unix(), 10))
+			} else {
+				// Check file change
+				checksum, err := hashFileCRC32(filePath, 0xedb88320)
+				if err != nil {
+					return
+				}
+
+				_, _ = io.WriteString(hash, filePath+";"+checksum)
+			}
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// DirectoryContentExcludes calculates a hash for a directory that only depends on the relative paths,
+// file modes and contents of the files and not on the location or modification times, which means the
+// hash is the same on different machines for the same directory contents.
+func DirectoryContentExcludes(srcPath string, excludePatterns []string) (string, error) {
+	srcPath, err := filepath.Abs(srcPath)
+	if err != nil {
+		return "", err
+	}
+
+	fileInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return "", err
+	} else if !fileInfo.IsDir() {
+		return File(srcPath)
+	}
+
+	hash := sha256.New()
+	err = walkExcludes(srcPath, excludePatterns, func(filePath, relFilePath string, f os.FileInfo) {
+		relFilePath = filepath.ToSlash(relFilePath)
+		if f.IsDir() {
+			_, _ = io.WriteString(hash, relFilePath+"/\n")
+			return
+		}
+
+		var checksum string
+		if f.Mode()&os.ModeSymlink != 0 {
+			checksum, _ = os.Readlink(filePath)
+		} else {
+			checksum, err = File(filePath)
+			if err != nil {
+				return
+			}
+		}
+
+		executable := f.Mode()&0111 != 0
+		_, _ = io.WriteString(hash, relFilePath+";"+strconv.FormatBool(executable)+";"+checksum+"\n")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// walkExcludes calls fn for every file and directory within srcPath that is not excluded by the
+// given patterns
+func walkExcludes(srcPath string, excludePatterns []string, fn func(filePath, relFilePath string, f os.FileInfo)) error {
 	// Fix the source path to work with long path names. This is a no-op
 	// on platforms other than Windows.
 	if runtime.GOOS == "windows" {
@@ -112,7 +184,7 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 
 	pm, err := patternmatcher.New(excludePatterns)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// In general we log errors here but ignore them because
@@ -122,11 +194,11 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 
 	stat, err := os.Lstat(srcPath)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	if !stat.IsDir() {
-		return "", errors.Errorf("Path %s is not a directory", srcPath)
+		return errors.Errorf("Path %s is not a directory", srcPath)
 	}
 
 	include := "."
@@ -195,31 +267,14 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 			return nil
 		}
 		seen[relFilePath] = true
-		if f.IsDir() {
-			// Path is enough
-			_, _ = io.WriteString(hash, filePath)
-		} else {
-			if fast {
-				_, _ = io.WriteString(hash, filePath+";"+strconv.FormatInt(f.Size(), 10)+";"+strconv.FormatInt(f.ModTime().Unix(), 10))
-			} else {
-				// Check file change
-				checksum, err := hashFileCRC32(filePath, 0xedb88320)
-				if err != nil {
-					return nil
-				}
-
-				_, _ = io.WriteString(hash, filePath+";"+checksum)
-			}
-		}
-
+		fn(filePath, relFilePath, f)
 		return nil
 	})
-
 	if err != nil {
-		return "", errors.Errorf("Error hashing %s: %v", srcPath, err)
+		return errors.Errorf("Error hashing %s: %v", srcPath, err)
 	}
 
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return nil
 }
 
 // StringToNumber hashes a given string to a number
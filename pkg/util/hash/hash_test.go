@@ -2,7 +2,9 @@ package hash
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 
@@ -86,3 +88,28 @@ func TestHashDirectoryExcludes(t *testing.T) {
 	}
 
 }
+
+func TestHashDirectoryContentExcludes(t *testing.T) {
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+	for _, dir := range []string{dirA, dirB} {
+		_ = fsutil.WriteToFile([]byte("FROM alpine"), filepath.Join(dir, "Dockerfile"))
+		_ = fsutil.WriteToFile([]byte("main"), filepath.Join(dir, "src", "main.go"))
+	}
+	_ = fsutil.WriteToFile([]byte("ignored"), filepath.Join(dirB, "excludedDir", "someFile"))
+
+	// hash has to be independent of the location and modification time
+	future := time.Now().Add(time.Hour)
+	_ = os.Chtimes(filepath.Join(dirB, "Dockerfile"), future, future)
+
+	hashA, err := DirectoryContentExcludes(dirA, []string{"excludedDir"})
+	assert.NilError(t, err)
+	hashB, err := DirectoryContentExcludes(dirB, []string{"excludedDir"})
+	assert.NilError(t, err)
+	assert.Equal(t, hashA, hashB)
+
+	_ = fsutil.WriteToFile([]byte("changed"), filepath.Join(dirB, "src", "main.go"))
+	hashB, err = DirectoryContentExcludes(dirB, []string{"excludedDir"})
+	assert.NilError(t, err)
+	assert.Assert(t, hashA != hashB)
+}
@@ -0,0 +1,19 @@
+package idle
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// lastActivity is the unix nano time of the last activity that was observed in this session
+var lastActivity = time.Now().UnixNano()
+
+// Touch marks the current session as active, e.g. because a file was synced
+func Touch() {
+	atomic.StoreInt64(&lastActivity, time.Now().UnixNano())
+}
+
+// SinceLastActivity returns the time since the last activity in this session
+func SinceLastActivity() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&lastActivity)))
+}
@@ -2,9 +2,11 @@ package idle
 
 import (
 	"fmt"
-	"github.com/loft-sh/devspace/pkg/devspace/kill"
+	"sync"
 	"time"
 
+	"github.com/loft-sh/devspace/pkg/devspace/kill"
+
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -33,6 +35,19 @@ func NewIdleMonitor() (Monitor, error) {
 	}, nil
 }
 
+// onIdle is executed once before DevSpace exits because of inactivity
+var onIdle func() error
+var onIdleMutex sync.Mutex
+
+// SetOnIdleFunction sets the function that is executed before DevSpace exits because
+// of inactivity, e.g. to scale down the dev pods in the cluster
+func SetOnIdleFunction(fn func() error) {
+	onIdleMutex.Lock()
+	defer onIdleMutex.Unlock()
+
+	onIdle = fn
+}
+
 type monitor struct {
 	Getter Getter
 }
@@ -49,6 +64,17 @@ func (m *monitor) Start(timeout time.Duration, log log.Logger) {
 				// don't do anything
 				return
 			} else if duration > timeout {
+				onIdleMutex.Lock()
+				fn := onIdle
+				onIdle = nil
+				onIdleMutex.Unlock()
+				if fn != nil {
+					err = fn()
+					if err != nil {
+						log.Warnf("Error executing inactivity action: %v", err)
+					}
+				}
+
 				// we exit here
 				kill.StopDevSpace(fmt.Sprintf("Automatically exit DevSpace, because the user is inactive for %s. To disable automatic exiting, run with --inactivity-timeout=0", duration.String()))
 			}
@@ -0,0 +1,51 @@
+//go:build linux
+// +build linux
+
+package idle
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// NewIdleGetter returns a new idle getter for linux. Since there is no X server
+// required, the idle time is determined by the activity of the current session
+// and the last input on any terminal of the current user, the same way who and w
+// determine the idle time of a login.
+//
+// The kernel only updates the access time of a terminal when a program reads from it,
+// so input is only noticed if a program such as a shell consumes it. Input into the
+// terminal devspace runs in is usually not read while devspace is running and mouse or
+// keyboard input in graphical applications is not considered at all.
+func NewIdleGetter() (Getter, error) {
+	return &idleGetter{}, nil
+}
+
+type idleGetter struct{}
+
+func (i *idleGetter) Idle() (time.Duration, error) {
+	idle := SinceLastActivity()
+	terminals, _ := filepath.Glob("/dev/pts/[0-9]*")
+	terminals = append(terminals, "/proc/self/fd/0")
+	for _, terminal := range terminals {
+		terminalIdle, ok := terminalIdle(terminal)
+		if ok && terminalIdle < idle {
+			idle = terminalIdle
+		}
+	}
+
+	return idle, nil
+}
+
+// terminalIdle returns the time since the last input on the terminal of the current user
+func terminalIdle(path string) (time.Duration, bool) {
+	stat := &syscall.Stat_t{}
+	err := syscall.Stat(path, stat)
+	if err != nil || stat.Mode&syscall.S_IFMT != syscall.S_IFCHR || int(stat.Uid) != os.Getuid() {
+		return 0, false
+	}
+
+	return time.Since(time.Unix(int64(stat.Atim.Sec), int64(stat.Atim.Nsec))), true
+}